@@ -5,6 +5,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/util"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	"path"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,6 +20,52 @@ const (
 	StorageProviderMinio  StorageProvider = "minio"
 	StorageProviderCeph   StorageProvider = "ceph"
 	StorageProviderOracle StorageProvider = "oracle"
+	StorageProviderFile   StorageProvider = "file"
+)
+
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the backup in the storage when the CassandraBackup is deleted
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDelete removes the backup from the storage when the CassandraBackup is deleted
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+)
+
+// CassandraBackupLabel is set on the VolumeSnapshots taken by a CassandraBackup
+const CassandraBackupLabel = "cassandra-backup"
+
+type BackupMode string
+
+const (
+	// BackupModeIcarus uploads the SSTables of each node to the storage location with Icarus
+	BackupModeIcarus BackupMode = "Icarus"
+	// BackupModeVolumeSnapshot takes a CSI VolumeSnapshot of the Cassandra volumes of each node
+	BackupModeVolumeSnapshot BackupMode = "VolumeSnapshot"
+)
+
+type EncryptionKeyType string
+
+const (
+	// EncryptionKeyTypeDataKey encrypts the backup data with the key from the secret
+	EncryptionKeyTypeDataKey EncryptionKeyType = "DataKey"
+	// EncryptionKeyTypeKeyEncryptionKey encrypts the backup data with a data key generated for the backup,
+	// which is stored with the backup wrapped by the key from the secret
+	EncryptionKeyTypeKeyEncryptionKey EncryptionKeyType = "KeyEncryptionKey"
+)
+
+// DefaultEncryptionSecretKey is the key of the secret entry with the encryption key if not set in the encryption block
+const DefaultEncryptionSecretKey = "key"
+
+const (
+	// ConditionTypeProgressing is true while the backup or restore is running
+	ConditionTypeProgressing = "Progressing"
+	// ConditionTypeComplete is true if the backup or restore has completed successfully
+	ConditionTypeComplete = "Complete"
+	// ConditionTypeFailed is true if the backup or restore has failed
+	ConditionTypeFailed = "Failed"
+	// ConditionTypeStorageVerified is true if the storage credentials have been verified against the storage location
+	ConditionTypeStorageVerified = "StorageVerified"
 )
 
 type CassandraBackupSpec struct {
@@ -27,10 +74,13 @@ type CassandraBackupSpec struct {
 	// example: gcp://myBucket
 	// location where SSTables will be uploaded.
 	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name
-	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph' or 'oracle'.
-	StorageLocation string `json:"storageLocation"`
+	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph', 'oracle' or 'file'.
+	// For 'file' the location is a directory in the Icarus backup volume configured in the CassandraCluster, e.g. 'file://backups'.
+	// Required in the Icarus mode.
+	StorageLocation string `json:"storageLocation,omitempty"`
 	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
-	SecretName string `json:"secretName"`
+	// Not used by the 'file' storage provider.
+	SecretName string `json:"secretName,omitempty"`
 	// Tag name that identifies the backup. Defaulted to the name of the CassandraBackup.
 	SnapshotTag string `json:"snapshotTag,omitempty"`
 	// Based on this field, there will be throughput per second computed based on what size data we want to upload we have.
@@ -64,12 +114,46 @@ type CassandraBackupSpec struct {
 	// Do not check the existence of a bucket.
 	// Some storage providers (e.g. S3) requires a special permissions to be able to list buckets or query their existence which might not be allowed.
 	// This flag will skip that check. Keep in mind that if that bucket does not exist, the whole backup operation will fail.
+	// The operator doesn't verify the storage credentials against the bucket either.
 	SkipBucketVerification bool `json:"skipBucketVerification,omitempty"`
 	// If set to true, refreshment of an object in a remote bucket (e.g. for s3) will be skipped.
 	// This might help upon backuping to specific s3 storage providers like Dell ECS storage.
 	// You will also skip versioning creating new versions when turned off as refreshment creates new version of files as a side effect.
 	SkipRefreshing bool  `json:"skipRefreshing,omitempty"`
 	Retry          Retry `json:"retry,omitempty"`
+	// Defines what happens to the backup data in the storage when the CassandraBackup is deleted.
+	// 'Retain' keeps the data, 'Delete' removes the backup from the storage before the CassandraBackup is removed. Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// A completed CassandraBackup is deleted once it's older than maxAge, e.g. '720h'.
+	// The backup data is removed from the storage as well if deletionPolicy is set to 'Delete'.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// Cancels the backup if it's running. The backup is CANCELLING until all nodes have stopped their Icarus operations, then CANCELLED.
+	// A cancelled backup can't be resumed, create a new CassandraBackup to back up the cluster again.
+	Cancel bool `json:"cancel,omitempty"`
+	// How the backup is taken. 'Icarus' uploads the SSTables of each node to the storageLocation.
+	// 'VolumeSnapshot' flushes all nodes and takes a CSI VolumeSnapshot of the Cassandra volumes of each node.
+	// The VolumeSnapshot mode requires persistence to be enabled in the CassandraCluster. Defaults to Icarus.
+	// +kubebuilder:validation:Enum=Icarus;VolumeSnapshot
+	Mode BackupMode `json:"mode,omitempty"`
+	// The VolumeSnapshotClass of the snapshots taken in the VolumeSnapshot mode.
+	// The default VolumeSnapshotClass of the CSI driver is used if not set.
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+	// Encrypts the backup data with a key from a secret before it's uploaded to the storage location.
+	// An encrypted backup can only be restored with the same key. Can't be changed once the backup is created.
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+}
+
+// BackupEncryption configures the client-side encryption of the backup data. The SSTables are encrypted by Icarus before they leave the pod.
+type BackupEncryption struct {
+	// Name of the secret that holds the key. The secret must be in the namespace of the backup.
+	SecretName string `json:"secretName"`
+	// The secret entry with the key, an AES key of 16, 24 or 32 bytes. Defaults to 'key'.
+	SecretKey string `json:"secretKey,omitempty"`
+	// Whether the key encrypts the data ('DataKey') or wraps a data key generated for each backup ('KeyEncryptionKey').
+	// Defaults to DataKey.
+	// +kubebuilder:validation:Enum=DataKey;KeyEncryptionKey
+	KeyType EncryptionKeyType `json:"keyType,omitempty"`
 }
 
 type Retry struct {
@@ -100,6 +184,63 @@ type CassandraBackupStatus struct {
 	Errors []BackupError `json:"errors,omitempty"`
 	// A value from 0 to 100 indicating the progress of the backup as a percentage
 	Progress int `json:"progress,omitempty"`
+	// The snapshot tag of the backup as reported by Icarus. Includes the schema version and the time of the backup.
+	// Used to remove the backup from the storage.
+	SnapshotTag string `json:"snapshotTag,omitempty"`
+	// The Cassandra pod that coordinates the backup in Icarus
+	Coordinator string `json:"coordinator,omitempty"`
+	// The time the backup was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the backup has completed or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// The schema version of the cluster captured by the backup
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// Bytes uploaded by all nodes so far
+	UploadedBytes int64 `json:"uploadedBytes,omitempty"`
+	// Bytes all nodes have to upload in total
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// The state of the backup on each node
+	Nodes []NodeProgress `json:"nodes,omitempty"`
+	// Conditions of the backup. Can be Progressing, Complete or Failed.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The VolumeSnapshots taken in the VolumeSnapshot mode, one for each volume of each node
+	VolumeSnapshots []BackupVolumeSnapshot `json:"volumeSnapshots,omitempty"`
+}
+
+// BackupVolumeSnapshot is a VolumeSnapshot of a Cassandra volume taken by a backup
+type BackupVolumeSnapshot struct {
+	// Name of the VolumeSnapshot
+	Name string `json:"name"`
+	// The volume claim template of the snapshotted volume, 'data' or 'commitlog'
+	Volume string `json:"volume"`
+	// Name of the snapshotted PersistentVolumeClaim
+	PVC string `json:"pvc"`
+	// Name of the Cassandra pod the volume belongs to
+	Pod string `json:"pod"`
+	// The DC of the pod
+	DC string `json:"dc"`
+	// True once volumes can be provisioned from the snapshot
+	ReadyToUse bool `json:"readyToUse,omitempty"`
+	// The minimum size of a volume provisioned from the snapshot
+	RestoreSize string `json:"restoreSize,omitempty"`
+}
+
+// NodeProgress is the state of a backup or restore on a single node
+type NodeProgress struct {
+	// Name of the Cassandra pod
+	Name string `json:"name"`
+	// The state of the operation on the node
+	State string `json:"state,omitempty"`
+	// A value from 0 to 100 indicating the progress on the node as a percentage
+	Progress int `json:"progress,omitempty"`
+	// The time the operation was started on the node
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the operation has completed or failed on the node
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Bytes uploaded by a backup or downloaded by a restore on the node so far
+	TransferredBytes int64 `json:"transferredBytes,omitempty"`
+	// Bytes the node has to upload or download in total
+	TotalBytes int64 `json:"totalBytes,omitempty"`
 }
 
 type BackupError struct {
@@ -111,6 +252,16 @@ type BackupError struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cassandraCluster`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=`.status.progress`
+// +kubebuilder:printcolumn:name="Uploaded",type=integer,JSONPath=`.status.uploadedBytes`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalBytes`,priority=1
+// +kubebuilder:printcolumn:name="Coordinator",type=string,JSONPath=`.status.coordinator`,priority=1
+// +kubebuilder:printcolumn:name="Schema Version",type=string,JSONPath=`.status.schemaVersion`,priority=1
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+// +kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.completionTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // CassandraBackup is the Schema for the CassandraBackups API
 type CassandraBackup struct {
@@ -157,11 +308,65 @@ func storageProvider(storageLocation string) StorageProvider {
 	if strings.HasPrefix(storageLocation, "ceph://") {
 		return StorageProviderCeph
 	}
+	if strings.HasPrefix(storageLocation, "file://") {
+		return StorageProviderFile
+	}
 
 	return ""
 }
 
+// IcarusStorageLocation returns the storage location as used by Icarus.
+// `file` locations are relative to the backup volume mounted into the Icarus container.
+func IcarusStorageLocation(storageLocation string) string {
+	if storageProvider(storageLocation) != StorageProviderFile {
+		return storageLocation
+	}
+
+	return "file://" + path.Join(IcarusBackupVolumeMountPath, strings.TrimPrefix(storageLocation, "file://"))
+}
+
+// EncryptionSecretKey returns the secret entry with the encryption key
+func (in *BackupEncryption) EncryptionSecretKey() string {
+	if len(in.SecretKey) == 0 {
+		return DefaultEncryptionSecretKey
+	}
+
+	return in.SecretKey
+}
+
+// EncryptionKeyType returns the type of the encryption key
+func (in *BackupEncryption) EncryptionKeyType() EncryptionKeyType {
+	if len(in.KeyType) == 0 {
+		return EncryptionKeyTypeDataKey
+	}
+
+	return in.KeyType
+}
+
+// ValidateEncryptionSecret checks that the secret holds an AES key in the entry referenced by the encryption
+func ValidateEncryptionSecret(secret *v1.Secret, encryption *BackupEncryption) error {
+	key, found := secret.Data[encryption.EncryptionSecretKey()]
+	if !found {
+		return fmt.Errorf("key %q for secret %s is not set", encryption.EncryptionSecretKey(), secret.Name)
+	}
+
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return fmt.Errorf("key %q for secret %s should be an AES key of 16, 24 or 32 bytes, got %d bytes", encryption.EncryptionSecretKey(), secret.Name, len(key))
+	}
+
+	return nil
+}
+
+// StorageSecretRequired returns true if the storage provider needs credentials from a secret
+func StorageSecretRequired(storageProvider StorageProvider) bool {
+	return storageProvider != StorageProviderFile
+}
+
 func ValidateStorageSecret(logger *zap.SugaredLogger, secret *v1.Secret, storageProvider StorageProvider) error {
+	if !StorageSecretRequired(storageProvider) {
+		return nil
+	}
+
 	if util.Contains([]string{
 		string(StorageProviderS3),
 		string(StorageProviderMinio),
@@ -191,7 +396,7 @@ func ValidateStorageSecret(logger *zap.SugaredLogger, secret *v1.Secret, storage
 	}
 
 	if storageProvider == StorageProviderGCP && len(secret.Data["gcp"]) == 0 {
-		return fmt.Errorf("storage provider is GCP but key 'gpc' for secret %s is not set", secret.Name)
+		return fmt.Errorf("storage provider is GCP but key 'gcp' for secret %s is not set", secret.Name)
 	}
 
 	if storageProvider == StorageProviderAzure {
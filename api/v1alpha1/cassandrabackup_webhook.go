@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -54,7 +55,17 @@ func (cb *CassandraBackup) ValidateUpdate(old runtime.Object) error {
 		return fmt.Errorf("old casandra cluster object: (%s) is not of type CassandraBackup", cbOld.Name)
 	}
 
-	return kerrors.NewAggregate(validateBackupCreateUpdate(cb))
+	verrors := validateBackupCreateUpdate(cb)
+	// changing the key of a running backup would mix up data encrypted with different keys
+	if !reflect.DeepEqual(cbOld.Spec.Encryption, cb.Spec.Encryption) {
+		verrors = append(verrors, errors.New(".spec.encryption can't be changed"))
+	}
+
+	if cbOld.Spec.Cancel && !cb.Spec.Cancel {
+		verrors = append(verrors, errors.New(".spec.cancel can't be unset, a cancelled backup can't be resumed"))
+	}
+
+	return kerrors.NewAggregate(verrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -64,17 +75,62 @@ func (cb *CassandraBackup) ValidateDelete() error {
 }
 
 func validateBackupCreateUpdate(cb *CassandraBackup) (verrors []error) {
+	if cb.Spec.MaxAge != nil && cb.Spec.MaxAge.Duration <= 0 {
+		verrors = append(verrors, errors.New("maxAge should be a positive duration"))
+	}
+
+	if cb.Spec.Mode == BackupModeVolumeSnapshot {
+		return append(verrors, validateVolumeSnapshotBackup(cb)...)
+	}
+
+	if len(cb.Spec.VolumeSnapshotClassName) != 0 {
+		verrors = append(verrors, errors.New("volumeSnapshotClassName can be used with the VolumeSnapshot mode only"))
+	}
+
 	if err := validateStorageLocation(cb.Spec.StorageLocation); err != nil {
 		verrors = append(verrors, err)
 	}
 
+	if StorageSecretRequired(cb.StorageProvider()) && len(cb.Spec.SecretName) == 0 {
+		verrors = append(verrors, fmt.Errorf("secretName should be set for the %s storage provider", cb.StorageProvider()))
+	}
+
 	if err := validateDuration(cb.Spec.Duration); err != nil {
 		verrors = append(verrors, err)
 	}
 
+	if err := validateEncryption(cb.Spec.Encryption); err != nil {
+		verrors = append(verrors, err)
+	}
+
 	return verrors
 }
 
+func validateVolumeSnapshotBackup(cb *CassandraBackup) (verrors []error) {
+	if len(cb.Spec.StorageLocation) != 0 || len(cb.Spec.SecretName) != 0 {
+		verrors = append(verrors, errors.New("storageLocation and secretName can't be used with the VolumeSnapshot mode, the snapshots are stored by the CSI driver"))
+	}
+
+	// the volumes of all nodes are snapshotted as a whole
+	if len(cb.Spec.DC) != 0 || len(cb.Spec.Entities) != 0 {
+		verrors = append(verrors, errors.New("dc and entities can't be used with the VolumeSnapshot mode"))
+	}
+
+	if cb.Spec.Encryption != nil {
+		verrors = append(verrors, errors.New("encryption can't be used with the VolumeSnapshot mode, use the encryption of the storage class instead"))
+	}
+
+	return verrors
+}
+
+func validateEncryption(encryption *BackupEncryption) error {
+	if encryption != nil && len(encryption.SecretName) == 0 {
+		return errors.New("encryption.secretName should be set")
+	}
+
+	return nil
+}
+
 func validateDuration(durationStr string) error {
 	if len(durationStr) == 0 {
 		return nil
@@ -114,11 +170,19 @@ func validateStorageLocation(location string) error {
 		string(StorageProviderCeph),
 		string(StorageProviderGCP),
 		string(StorageProviderAzure),
+		string(StorageProviderFile),
 	}
 	requestedProtocol := location[:index]
 	if !util.Contains(supportedProtocols, requestedProtocol) {
 		return fmt.Errorf("protocol %s is not supported. Should be one of the following: %v", requestedProtocol, supportedProtocols)
 	}
 
+	if requestedProtocol == string(StorageProviderFile) {
+		dir := location[index+len("://"):]
+		if len(strings.Trim(dir, "/")) == 0 || strings.Contains(dir, "..") {
+			return errors.New("file storage location should be a directory in the backup volume, e.g. 'file://backups'")
+		}
+	}
+
 	return nil
 }
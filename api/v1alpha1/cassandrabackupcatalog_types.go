@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypeSynced is true if the last sync of a CassandraBackupCatalog with the storage succeeded
+	ConditionTypeSynced = "Synced"
+)
+
+type CassandraBackupCatalogSpec struct {
+	// CassandraCluster whose Icarus sidecars list the backups in the storage
+	CassandraCluster string `json:"cassandraCluster"`
+	// example: gcp://myBucket
+	// location the backups were uploaded to.
+	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name
+	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph', 'oracle' or 'file'.
+	StorageLocation string `json:"storageLocation"`
+	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
+	// Not used by the 'file' storage provider.
+	SecretName string `json:"secretName,omitempty"`
+	// Name of the backed up cluster. Defaults to cassandraCluster.
+	// The nodes of cassandraCluster are renamed after the source cluster to find the backups of each node.
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// How often the catalog is synced with the storage, e.g. '6h'. Defaults to 1h.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// Relevant for S3-like buckets only. If true, communication is done via HTTP instead of HTTPS. Defaults to false.
+	Insecure bool `json:"insecure,omitempty"`
+	// Do not check the existence of a bucket.
+	// Some storage providers (e.g. S3) requires a special permissions to be able to list buckets or query their existence which might not be allowed.
+	SkipBucketVerification bool `json:"skipBucketVerification,omitempty"`
+}
+
+// CatalogBackup is a backup found in the storage
+type CatalogBackup struct {
+	// The name of the backup as uploaded by Icarus. Includes the schema version and the time of the backup.
+	Name string `json:"name"`
+	// The snapshot tag the backup was created with
+	SnapshotTag string `json:"snapshotTag"`
+	// The schema version of the backed up cluster
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// The time of the backup
+	Time *metav1.Time `json:"time,omitempty"`
+	// The size of the backup of all nodes in bytes
+	Size int64 `json:"size,omitempty"`
+	// The backed up DCs and nodes
+	DCs []CatalogDC `json:"dcs,omitempty"`
+	// True if the backup data is encrypted on the client side. Restoring it requires the encryption key.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+type CatalogDC struct {
+	// Name of the DC
+	Name string `json:"name"`
+	// Names of the backed up nodes of the DC
+	Nodes []string `json:"nodes"`
+}
+
+type CassandraBackupCatalogStatus struct {
+	// The backups found in the storage, ordered by time
+	Backups []CatalogBackup `json:"backups,omitempty"`
+	// The Cassandra pod that lists the backups in Icarus
+	Coordinator string `json:"coordinator,omitempty"`
+	// The time the running sync was started
+	SyncStartTime *metav1.Time `json:"syncStartTime,omitempty"`
+	// The time the last sync has finished
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// The generation of the spec the last sync was started for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the catalog. Can be Synced.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Storage Location",type=string,JSONPath=`.spec.storageLocation`
+// +kubebuilder:printcolumn:name="Source Cluster",type=string,JSONPath=`.spec.sourceCluster`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+
+// CassandraBackupCatalog is the Schema for the CassandraBackupCatalogs API
+type CassandraBackupCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CassandraBackupCatalogSpec   `json:"spec"`
+	Status CassandraBackupCatalogStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CassandraBackupCatalogList contains a list of CassandraBackupCatalog
+type CassandraBackupCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CassandraBackupCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CassandraBackupCatalog{}, &CassandraBackupCatalogList{})
+}
+
+func (in *CassandraBackupCatalog) StorageProvider() StorageProvider {
+	return storageProvider(in.Spec.StorageLocation)
+}
+
+// SourceClusterName returns the name of the cluster the backups were taken of
+func (in *CassandraBackupCatalog) SourceClusterName() string {
+	if len(in.Spec.SourceCluster) != 0 {
+		return in.Spec.SourceCluster
+	}
+
+	return in.Spec.CassandraCluster
+}
+
+// FindBackup returns the latest backup with the snapshot tag. The schema version is matched too if set.
+func (in *CassandraBackupCatalog) FindBackup(snapshotTag, schemaVersion string) (CatalogBackup, bool) {
+	for i := len(in.Status.Backups) - 1; i >= 0; i-- {
+		backup := in.Status.Backups[i]
+		if backup.SnapshotTag != snapshotTag || (len(schemaVersion) != 0 && backup.SchemaVersion != schemaVersion) {
+			continue
+		}
+
+		return backup, true
+	}
+
+	return CatalogBackup{}, false
+}
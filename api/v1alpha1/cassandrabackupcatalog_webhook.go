@@ -0,0 +1,76 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func (cbc *CassandraBackupCatalog) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(cbc).
+		Complete()
+}
+
+var _ webhook.Validator = &CassandraBackupCatalog{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (cbc *CassandraBackupCatalog) ValidateCreate() error {
+	webhookLogger.Debugf("Validating webhook has been called on create request for backup catalog: %s", cbc.Name)
+
+	return kerrors.NewAggregate(validateBackupCatalogCreateUpdate(cbc))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (cbc *CassandraBackupCatalog) ValidateUpdate(old runtime.Object) error {
+	webhookLogger.Debugf("Validating webhook has been called on update request for backup catalog: %s", cbc.Name)
+
+	cbcOld, ok := old.(*CassandraBackupCatalog)
+	if !ok {
+		return fmt.Errorf("old casandra backup catalog object: (%s) is not of type CassandraBackupCatalog", cbcOld.Name)
+	}
+
+	return kerrors.NewAggregate(validateBackupCatalogCreateUpdate(cbc))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (cbc *CassandraBackupCatalog) ValidateDelete() error {
+	webhookLogger.Debugf("Validating webhook has been called on delete request for backup catalog: %s", cbc.Name)
+	return nil
+}
+
+func validateBackupCatalogCreateUpdate(cbc *CassandraBackupCatalog) (verrors []error) {
+	if err := validateStorageLocation(cbc.Spec.StorageLocation); err != nil {
+		verrors = append(verrors, err)
+	}
+
+	if StorageSecretRequired(cbc.StorageProvider()) && len(cbc.Spec.SecretName) == 0 {
+		verrors = append(verrors, fmt.Errorf("secretName should be set for the %s storage provider", cbc.StorageProvider()))
+	}
+
+	if cbc.Spec.RefreshInterval != nil && cbc.Spec.RefreshInterval.Duration <= 0 {
+		verrors = append(verrors, errors.New("refreshInterval should be a positive duration"))
+	}
+
+	return verrors
+}
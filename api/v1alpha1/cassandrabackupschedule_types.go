@@ -0,0 +1,115 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CassandraBackupScheduleLabel is set on CassandraBackups created by a CassandraBackupSchedule
+	CassandraBackupScheduleLabel = "cassandra-backup-schedule"
+	// CassandraBackupScheduledAtAnnotation holds the time a CassandraBackup was scheduled for (RFC3339)
+	CassandraBackupScheduledAtAnnotation = "db.ibm.com/scheduled-at"
+)
+
+// ConcurrencyPolicy describes how the schedule treats concurrently running backups
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyPolicyAllow allows backups to run concurrently
+	ConcurrencyPolicyAllow ConcurrencyPolicy = "Allow"
+	// ConcurrencyPolicyForbid skips a scheduled run if the previous backup hasn't finished yet
+	ConcurrencyPolicyForbid ConcurrencyPolicy = "Forbid"
+	// ConcurrencyPolicyReplace deletes the running backup and replaces it with a new one
+	ConcurrencyPolicyReplace ConcurrencyPolicy = "Replace"
+)
+
+type CassandraBackupScheduleSpec struct {
+	// The schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// A time zone can be set using the `CRON_TZ=` prefix, e.g. `CRON_TZ=Europe/Berlin 0 3 * * *`. UTC is used if not set.
+	Schedule string `json:"schedule"`
+	// If set to true, no new backups are created. Running backups are not affected.
+	Suspend bool `json:"suspend,omitempty"`
+	// Specifies how to treat concurrent backups. Valid values are:
+	// 'Allow' allows backups to run concurrently;
+	// 'Forbid' skips the scheduled run if the previous backup hasn't finished yet;
+	// 'Replace' deletes the currently running backup and replaces it with a new one.
+	// Defaults to Forbid.
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// The number of successful backups to keep. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulBackupsHistoryLimit *int32 `json:"successfulBackupsHistoryLimit,omitempty"`
+	// The number of failed backups to keep. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	FailedBackupsHistoryLimit *int32 `json:"failedBackupsHistoryLimit,omitempty"`
+	// Retention rules for completed backups. If set, successfulBackupsHistoryLimit is not used.
+	Retention *BackupRetention `json:"retention,omitempty"`
+	// The spec of the CassandraBackup objects created by the schedule.
+	// The snapshot tag is made unique for each backup by adding the time of the scheduled run.
+	BackupTemplate CassandraBackupSpec `json:"backupTemplate"`
+}
+
+// BackupRetention defines which completed backups are kept. A backup is kept if it matches any of the keep rules.
+// If no keep rules are set, all backups within maxAge are kept.
+type BackupRetention struct {
+	// The number of most recent backups to keep
+	// +kubebuilder:validation:Minimum=0
+	KeepLast int32 `json:"keepLast,omitempty"`
+	// The number of days to keep the most recent backup for
+	// +kubebuilder:validation:Minimum=0
+	KeepDaily int32 `json:"keepDaily,omitempty"`
+	// The number of weeks to keep the most recent backup for
+	// +kubebuilder:validation:Minimum=0
+	KeepWeekly int32 `json:"keepWeekly,omitempty"`
+	// The number of months to keep the most recent backup for
+	// +kubebuilder:validation:Minimum=0
+	KeepMonthly int32 `json:"keepMonthly,omitempty"`
+	// Backups older than maxAge are removed even if they match a keep rule, e.g. '2160h'
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+type CassandraBackupScheduleStatus struct {
+	// Names of the currently running backups
+	Active []string `json:"active,omitempty"`
+	// The last time a backup was scheduled
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// The next time a backup will be scheduled
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// The name of the last successfully completed backup
+	LastSuccessfulBackup string `json:"lastSuccessfulBackup,omitempty"`
+	// The time the last successful backup was observed as completed
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// The name of the last failed backup
+	LastFailedBackup string `json:"lastFailedBackup,omitempty"`
+	// The time the last failed backup was observed as failed
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+// +kubebuilder:printcolumn:name="Last Success",type=date,JSONPath=`.status.lastSuccessfulTime`
+
+// CassandraBackupSchedule is the Schema for the CassandraBackupSchedules API
+type CassandraBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CassandraBackupScheduleSpec   `json:"spec"`
+	Status CassandraBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CassandraBackupScheduleList contains a list of CassandraBackupSchedule
+type CassandraBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CassandraBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CassandraBackupSchedule{}, &CassandraBackupScheduleList{})
+}
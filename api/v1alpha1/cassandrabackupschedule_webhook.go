@@ -0,0 +1,81 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/robfig/cron/v3"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func (cbs *CassandraBackupSchedule) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(cbs).
+		Complete()
+}
+
+var _ webhook.Validator = &CassandraBackupSchedule{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (cbs *CassandraBackupSchedule) ValidateCreate() error {
+	webhookLogger.Debugf("Validating webhook has been called on create request for backup schedule: %s", cbs.Name)
+
+	return kerrors.NewAggregate(validateBackupScheduleCreateUpdate(cbs))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (cbs *CassandraBackupSchedule) ValidateUpdate(old runtime.Object) error {
+	webhookLogger.Debugf("Validating webhook has been called on update request for backup schedule: %s", cbs.Name)
+
+	cbsOld, ok := old.(*CassandraBackupSchedule)
+	if !ok {
+		return fmt.Errorf("old casandra backup schedule object: (%s) is not of type CassandraBackupSchedule", cbsOld.Name)
+	}
+
+	return kerrors.NewAggregate(validateBackupScheduleCreateUpdate(cbs))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (cbs *CassandraBackupSchedule) ValidateDelete() error {
+	webhookLogger.Debugf("Validating webhook has been called on delete request for backup schedule: %s", cbs.Name)
+	return nil
+}
+
+func validateBackupScheduleCreateUpdate(cbs *CassandraBackupSchedule) (verrors []error) {
+	if _, err := ParseBackupSchedule(cbs.Spec.Schedule); err != nil {
+		verrors = append(verrors, fmt.Errorf("schedule %q is invalid: %s", cbs.Spec.Schedule, err.Error()))
+	}
+
+	if cbs.Spec.Retention != nil && cbs.Spec.Retention.MaxAge != nil && cbs.Spec.Retention.MaxAge.Duration <= 0 {
+		verrors = append(verrors, errors.New("retention.maxAge should be a positive duration"))
+	}
+
+	verrors = append(verrors, validateBackupCreateUpdate(&CassandraBackup{Spec: cbs.Spec.BackupTemplate})...)
+
+	return verrors
+}
+
+// ParseBackupSchedule parses a standard cron expression with an optional CRON_TZ prefix
+func ParseBackupSchedule(schedule string) (cron.Schedule, error) {
+	return cron.ParseStandard(schedule)
+}
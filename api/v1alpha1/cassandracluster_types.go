@@ -17,8 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -27,10 +33,12 @@ const (
 	CassandraClusterDC        = "cassandra-cluster-dc"
 	CassandraClusterChecksum  = "cassandra-cluster-checksum"
 	CassandraClusterSeed      = "cassandra-cluster-seed"
+	CassandraClusterRack      = "cassandra-cluster-rack"
 
 	CassandraClusterComponentProber    = "prober"
 	CassandraClusterComponentReaper    = "reaper"
 	CassandraClusterComponentCassandra = "cassandra"
+	CassandraClusterComponentRestore   = "restore"
 	CassandraClusterNetworkPolicy      = "network-policy"
 
 	CassandraAgentTlp         = "tlp"
@@ -62,6 +70,18 @@ const (
 	InstaclustrPort = 9500
 	IcarusPort      = 4567
 
+	// IcarusBackupVolumeMountPath is where the volume used by the `file` storage provider is mounted in the Icarus container
+	IcarusBackupVolumeMountPath = "/var/lib/cassandra-backups"
+	// CommitLogArchiveDir is where Cassandra archives commit log segments to be uploaded by Icarus
+	CommitLogArchiveDir = "/var/lib/cassandra/commitlog-archive"
+	// CommitLogRestoreDir is where Icarus downloads the commit logs replayed by a point-in-time restore
+	CommitLogRestoreDir = "/var/lib/cassandra/commitlog-restore"
+	// CommitLogRestorePointInTimeAnnotation is set on the CassandraCluster while commit logs are replayed up to the point in time it contains
+	CommitLogRestorePointInTimeAnnotation = "db.ibm.com/commitlog-restore-point-in-time"
+	// PlanAnnotation puts the cluster in plan mode. The operator writes the changes it would make to the plan ConfigMap instead of applying them.
+	// Nothing else is reconciled while it's set, including maintenance expiry, queued operations and runtime settings.
+	PlanAnnotation = "db.ibm.com/plan"
+
 	ReaperReplicasNumber     = 1
 	reaperRepairIntensityMin = 0.1
 	reaperRepairIntensityMax = 1.0
@@ -105,6 +125,88 @@ type CassandraClusterSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Policies for C* cluster"
 	// +optional
 	NetworkPolicies NetworkPolicies `json:"networkPolicies,omitempty"`
+	// Restarts the Cassandra pods one at a time. Each node is drained before its pod is deleted
+	// and the next pod is restarted once the cluster is ready again.
+	RollingRestart *RollingRestart `json:"rollingRestart,omitempty"`
+	// Defines when changes that restart the Cassandra pods are rolled out
+	RestartPolicy *RestartPolicy `json:"restartPolicy,omitempty"`
+	// Recurring time windows in which disruptive operations are allowed. If set, decommissions, rolling restarts,
+	// replication changes of system keyspaces, repairs of CQL ConfigMaps and certificate rotations wait for the next window.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// PodDisruptionBudgets limiting the number of Cassandra pods evicted at once, e.g. by node drains.
+	// One is created per DC.
+	PodDisruptionBudget PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+type PodDisruptionBudget struct {
+	// Defaults to true
+	Enabled *bool `json:"enabled,omitempty"`
+	// Number or percentage of the pods of a DC that can be unavailable at once. Defaults to 1.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+type RestartPolicyType string
+
+const (
+	// RestartPolicyImmediate rolls out changes as soon as they are reconciled
+	RestartPolicyImmediate RestartPolicyType = "Immediate"
+	// RestartPolicyManual holds changes back until they are approved
+	RestartPolicyManual RestartPolicyType = "Manual"
+	// RestartPolicyMaintenanceWindow holds changes back until a maintenance window starts or they are approved
+	RestartPolicyMaintenanceWindow RestartPolicyType = "MaintenanceWindow"
+)
+
+type RestartPolicy struct {
+	// Valid values are:
+	// 'Immediate' restarts the pods as soon as a change is reconciled;
+	// 'Manual' holds the change back until it's approved;
+	// 'MaintenanceWindow' holds the change back until a maintenance window starts or it's approved.
+	// Defaults to Immediate.
+	// +kubebuilder:validation:Enum=Immediate;Manual;MaintenanceWindow
+	Type RestartPolicyType `json:"type,omitempty"`
+	// Approves the restart pending since before that time
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
+}
+
+type MaintenanceWindow struct {
+	// The start of the window in Cron format, see https://en.wikipedia.org/wiki/Cron. E.g. `0 2 * * 6` for Saturdays at 2am.
+	Schedule string `json:"schedule"`
+	// The time zone of the schedule, e.g. `Europe/Berlin`. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+	// How long the window lasts, e.g. `4h`
+	Duration metav1.Duration `json:"duration"`
+}
+
+type RollingRestartState string
+
+const (
+	RollingRestartStateRunning   RollingRestartState = "Running"
+	RollingRestartStateCompleted RollingRestartState = "Completed"
+)
+
+type RollingRestart struct {
+	// Time the restart is requested at. Setting a later time starts a new rolling restart.
+	RequestedAt metav1.Time `json:"requestedAt"`
+	// Restarts the pods of these DCs. All pods of the cluster are restarted if neither dcs nor pods are set.
+	DCs []string `json:"dcs,omitempty"`
+	// Restarts these pods
+	Pods []PodName `json:"pods,omitempty"`
+}
+
+// RollingRestartStatus is the progress of the rolling restart requested in the spec
+type RollingRestartStatus struct {
+	// The requestedAt time of the rolling restart the status is for
+	RequestedAt metav1.Time         `json:"requestedAt"`
+	State       RollingRestartState `json:"state"`
+	// Pods that are not restarted yet, in the order they are restarted
+	PendingPods []string `json:"pendingPods,omitempty"`
+	// The pod being restarted
+	CurrentPod string `json:"currentPod,omitempty"`
+	// Time the current pod was deleted at
+	CurrentPodRestartTime *metav1.Time `json:"currentPodRestartTime,omitempty"`
+	RestartedPods         []string     `json:"restartedPods,omitempty"`
+	StartTime             *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime        *metav1.Time `json:"completionTime,omitempty"`
 }
 
 type ExternalRegions struct {
@@ -279,14 +381,159 @@ type Cassandra struct {
 	// +kubebuilder:validation:Minimum:=1
 	NumSeeds int32 `json:"numSeeds,omitempty"`
 	// +kubebuilder:validation:Minimum:=0
-	TerminationGracePeriodSeconds *int64            `json:"terminationGracePeriodSeconds,omitempty"`
-	PurgeGossip                   *bool             `json:"purgeGossip,omitempty"`
-	Persistence                   Persistence       `json:"persistence,omitempty"`
-	ZonesAsRacks                  bool              `json:"zonesAsRacks,omitempty"`
-	JVMOptions                    []string          `json:"jvmOptions,omitempty"`
-	Sysctls                       map[string]string `json:"sysctls,omitempty"`
-	Monitoring                    Monitoring        `json:"monitoring,omitempty"`
-	ConfigOverrides               string            `json:"configOverrides,omitempty"`
+	TerminationGracePeriodSeconds *int64      `json:"terminationGracePeriodSeconds,omitempty"`
+	PurgeGossip                   *bool       `json:"purgeGossip,omitempty"`
+	Persistence                   Persistence `json:"persistence,omitempty"`
+	ZonesAsRacks                  bool        `json:"zonesAsRacks,omitempty"`
+	// JVM options appended to the default ones. Heap sizes not set here are derived from the memory of the cassandra container.
+	JVMOptions []string          `json:"jvmOptions,omitempty"`
+	Sysctls    map[string]string `json:"sysctls,omitempty"`
+	Monitoring Monitoring        `json:"monitoring,omitempty"`
+	// Typed values of commonly tuned cassandra.yaml settings, rendered with the names used by the Cassandra version of the image.
+	// Settings not covered here can be set in configOverrides.
+	Config *CassandraConfig `json:"config,omitempty"`
+	// Settings applied to the running nodes through JMX without restarting them, and re-applied when a node restarts
+	RuntimeSettings *RuntimeSettings `json:"runtimeSettings,omitempty"`
+	// Logging of Cassandra, rendered into the logback.xml and the JVM options of the nodes
+	Logging *CassandraLogging `json:"logging,omitempty"`
+	// cassandra.yaml settings merged key by key into the default config. Only settings known by the Cassandra version
+	// of the image are accepted. Settings renamed in Cassandra 4.1 are translated by the operator to their new names.
+	ConfigOverrides string `json:"configOverrides,omitempty"`
+	// Archives commit log segments and uploads them to a backup storage location,
+	// which allows restoring the cluster to a point in time with a CassandraRestore
+	CommitLogArchiving *CommitLogArchiving `json:"commitLogArchiving,omitempty"`
+}
+
+// CassandraConfig holds commonly tuned cassandra.yaml settings
+type CassandraConfig struct {
+	// +kubebuilder:validation:Minimum:=1
+	ConcurrentReads *int32 `json:"concurrentReads,omitempty"`
+	// +kubebuilder:validation:Minimum:=1
+	ConcurrentWrites *int32 `json:"concurrentWrites,omitempty"`
+	// +kubebuilder:validation:Minimum:=1
+	ConcurrentCounterWrites *int32 `json:"concurrentCounterWrites,omitempty"`
+	// +kubebuilder:validation:Minimum:=1
+	ConcurrentCompactors *int32 `json:"concurrentCompactors,omitempty"`
+	// Compaction throughput in MiB/s. 0 disables throttling.
+	// +kubebuilder:validation:Minimum:=0
+	CompactionThroughputMBPerSec *int32 `json:"compactionThroughputMBPerSec,omitempty"`
+	// Outbound streaming throughput in megabits/s. 0 disables throttling.
+	// +kubebuilder:validation:Minimum:=0
+	StreamThroughputOutboundMegabitsPerSec *int32           `json:"streamThroughputOutboundMegabitsPerSec,omitempty"`
+	ReadRequestTimeout                     *metav1.Duration `json:"readRequestTimeout,omitempty"`
+	WriteRequestTimeout                    *metav1.Duration `json:"writeRequestTimeout,omitempty"`
+	RangeRequestTimeout                    *metav1.Duration `json:"rangeRequestTimeout,omitempty"`
+	RequestTimeout                         *metav1.Duration `json:"requestTimeout,omitempty"`
+	HintedHandoffEnabled                   *bool            `json:"hintedHandoffEnabled,omitempty"`
+	// How long hints are stored for a node that is down
+	MaxHintWindow *metav1.Duration `json:"maxHintWindow,omitempty"`
+	// +kubebuilder:validation:Minimum:=0
+	KeyCacheSizeMB              *int32 `json:"keyCacheSizeMB,omitempty"`
+	AutoSnapshot                *bool  `json:"autoSnapshot,omitempty"`
+	MaterializedViewsEnabled    *bool  `json:"materializedViewsEnabled,omitempty"`
+	UserDefinedFunctionsEnabled *bool  `json:"userDefinedFunctionsEnabled,omitempty"`
+}
+
+// Settings returns the set values as cassandra.yaml settings, named and formatted as before Cassandra 4.1
+func (in *CassandraConfig) Settings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	if in == nil {
+		return settings
+	}
+
+	setInt := func(key string, value *int32) {
+		if value != nil {
+			settings[key] = *value
+		}
+	}
+	setBool := func(key string, value *bool) {
+		if value != nil {
+			settings[key] = *value
+		}
+	}
+	setMillis := func(key string, value *metav1.Duration) {
+		if value != nil {
+			settings[key] = value.Milliseconds()
+		}
+	}
+
+	setInt("concurrent_reads", in.ConcurrentReads)
+	setInt("concurrent_writes", in.ConcurrentWrites)
+	setInt("concurrent_counter_writes", in.ConcurrentCounterWrites)
+	setInt("concurrent_compactors", in.ConcurrentCompactors)
+	setInt("compaction_throughput_mb_per_sec", in.CompactionThroughputMBPerSec)
+	setInt("stream_throughput_outbound_megabits_per_sec", in.StreamThroughputOutboundMegabitsPerSec)
+	setMillis("read_request_timeout_in_ms", in.ReadRequestTimeout)
+	setMillis("write_request_timeout_in_ms", in.WriteRequestTimeout)
+	setMillis("range_request_timeout_in_ms", in.RangeRequestTimeout)
+	setMillis("request_timeout_in_ms", in.RequestTimeout)
+	setBool("hinted_handoff_enabled", in.HintedHandoffEnabled)
+	setMillis("max_hint_window_in_ms", in.MaxHintWindow)
+	setInt("key_cache_size_in_mb", in.KeyCacheSizeMB)
+	setBool("auto_snapshot", in.AutoSnapshot)
+	setBool("enable_materialized_views", in.MaterializedViewsEnabled)
+	setBool("enable_user_defined_functions", in.UserDefinedFunctionsEnabled)
+
+	return settings
+}
+
+// RuntimeSettings are the settings the operator keeps in sync on the running nodes
+type RuntimeSettings struct {
+	// Compaction throughput in MiB/s. 0 disables throttling.
+	// +kubebuilder:validation:Minimum:=0
+	CompactionThroughputMBPerSec *int32 `json:"compactionThroughputMBPerSec,omitempty"`
+	// Outbound streaming throughput in megabits/s. 0 disables throttling.
+	// +kubebuilder:validation:Minimum:=0
+	StreamThroughputMegabitsPerSec *int32 `json:"streamThroughputMegabitsPerSec,omitempty"`
+	// +kubebuilder:validation:Minimum:=1
+	ConcurrentCompactors *int32 `json:"concurrentCompactors,omitempty"`
+	// Throughput of each hint delivery thread in KiB/s. 0 disables throttling.
+	// +kubebuilder:validation:Minimum:=0
+	HintedHandoffThrottleKB *int32 `json:"hintedHandoffThrottleKB,omitempty"`
+	// Probability a request is traced, between 0 and 1. Example: 0.001
+	// +kubebuilder:validation:Pattern:=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	TraceProbability string `json:"traceProbability,omitempty"`
+}
+
+// +kubebuilder:validation:Enum:=TRACE;DEBUG;INFO;WARN;ERROR;OFF
+type LoggerLevel string
+
+// +kubebuilder:validation:Enum:=plain;json
+type LogEncoder string
+
+const (
+	LogEncoderPlain LogEncoder = "plain"
+	LogEncoderJSON  LogEncoder = "json"
+)
+
+// CassandraLogging configures the logback loggers and appenders of Cassandra and its GC log
+type CassandraLogging struct {
+	// Levels of loggers by their name, e.g. `org.apache.cassandra.db.compaction: DEBUG`.
+	// Applied to the running nodes through JMX without restarting them. The root level is set by cassandra.logLevel.
+	Loggers map[string]LoggerLevel `json:"loggers,omitempty"`
+	// Format of the logs written to stdout, one JSON object per line for `json`. Defaults to `plain`.
+	Encoder LogEncoder `json:"encoder,omitempty"`
+	// Writes the audit log of Cassandra's FileAuditLogger to audit.log in the log directory. Requires Cassandra 4.0 or later.
+	// Audit logging itself is enabled with audit_logging_options in configOverrides.
+	AuditLog bool `json:"auditLog,omitempty"`
+	// Writes the queries slower than slow_query_log_timeout_in_ms to slow_queries.log in the log directory
+	SlowQueryLog bool `json:"slowQueryLog,omitempty"`
+	// Writes a detailed GC log with rotation to gc.log in the log directory
+	GCLog bool `json:"gcLog,omitempty"`
+}
+
+// CommitLogArchiving configures the archiving of commit log segments by Cassandra and their upload by Icarus
+type CommitLogArchiving struct {
+	// example: s3://myBucket
+	// Location the archived commit logs are uploaded to, in the format 'protocol://bucket-name'.
+	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph', 'oracle' or 'file'.
+	// Point-in-time restores download the commit logs from this location.
+	StorageLocation string `json:"storageLocation"`
+	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
+	// Not used by the 'file' storage provider.
+	SecretName string `json:"secretName,omitempty"`
+	// How often the archived commit logs are uploaded. Defaults to 10m.
+	UploadInterval *metav1.Duration `json:"uploadInterval,omitempty"`
 }
 
 type Persistence struct {
@@ -303,10 +550,25 @@ type Icarus struct {
 	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
 	ImagePullPolicy v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
 	Resources       v1.ResourceRequirements `json:"resources,omitempty"`
+	// Volume used by backups and restores with the `file://` storage location.
+	// It's mounted into the Icarus container of all Cassandra pods, so it should support mounting by multiple nodes.
+	BackupVolume *IcarusBackupVolume `json:"backupVolume,omitempty"`
+}
+
+// IcarusBackupVolume is a volume shared between the Cassandra pods. Only one of the fields can be set.
+type IcarusBackupVolume struct {
+	// A PVC with the ReadWriteMany access mode
+	PersistentVolumeClaim *v1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// An NFS share
+	NFS *v1.NFSVolumeSource `json:"nfs,omitempty"`
 }
 
 type Prober struct {
-	Image string `json:"image,omitempty"`
+	// Number of prober pods. Prober state shared between replicas is persisted in a ConfigMap,
+	// so Cassandra readiness checks keep working while one of the replicas is unavailable.
+	// +kubebuilder:validation:Minimum:=1
+	Replicas int32  `json:"replicas,omitempty"`
+	Image    string `json:"image,omitempty"`
 	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
 	ImagePullPolicy v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
 	Resources       v1.ResourceRequirements `json:"resources,omitempty"`
@@ -341,6 +603,30 @@ type Maintenance struct {
 	// +kubebuilder:validation:Pattern:=^[a-z0-9][a-z0-9\-]*$
 	DC   string    `json:"dc"`
 	Pods []PodName `json:"pods,omitempty"`
+	// Why the pods are put into maintenance
+	Reason string `json:"reason,omitempty"`
+	// Who requested the maintenance
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// The time the pods are taken out of maintenance automatically
+	Until *metav1.Time `json:"until,omitempty"`
+	// How long the pods stay in maintenance after entering it. Can't be set together with `until`
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// PodMaintenance records the time a pod spent in maintenance
+type PodMaintenance struct {
+	Pod         PodName `json:"pod"`
+	DC          string  `json:"dc"`
+	Reason      string  `json:"reason,omitempty"`
+	RequestedBy string  `json:"requestedBy,omitempty"`
+	// Time the pod entered maintenance
+	EnteredAt metav1.Time `json:"enteredAt"`
+	// Time the pod is taken out of maintenance automatically
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// Time the pod exited maintenance
+	ExitedAt *metav1.Time `json:"exitedAt,omitempty"`
+	// True if the pod was taken out of maintenance because the request expired and the request is still in the spec
+	Expired bool `json:"expired,omitempty"`
 }
 
 // KeyspaceName is the name of a Cassandra keyspace
@@ -366,7 +652,87 @@ type SystemKeyspaceDC struct {
 // CassandraClusterStatus defines the observed state of CassandraCluster
 type CassandraClusterStatus struct {
 	MaintenanceState []Maintenance `json:"maintenanceState,omitempty"`
-	Ready            bool          `json:"ready,omitempty"`
+	// The last maintenance of each pod
+	MaintenancePods []PodMaintenance      `json:"maintenancePods,omitempty"`
+	Ready           bool                  `json:"ready,omitempty"`
+	RollingRestart  *RollingRestartStatus `json:"rollingRestart,omitempty"`
+	// A change held back by the restart policy
+	PendingRestart *PendingRestart `json:"pendingRestart,omitempty"`
+	// Disruptive operations waiting for the next maintenance window
+	QueuedOperations []QueuedOperation `json:"queuedOperations,omitempty"`
+	// The state of the runtime settings of each node
+	RuntimeSettings []NodeRuntimeSettings `json:"runtimeSettings,omitempty"`
+	// The logger levels applied to each node
+	LoggingLevels []NodeLoggingLevels `json:"loggingLevels,omitempty"`
+}
+
+type RuntimeSettingsState string
+
+const (
+	// RuntimeSettingsApplied means the node runs with the settings of the spec
+	RuntimeSettingsApplied RuntimeSettingsState = "Applied"
+	// RuntimeSettingsDrifted means some settings of the node differ from the spec and couldn't be applied
+	RuntimeSettingsDrifted RuntimeSettingsState = "Drifted"
+)
+
+// NodeRuntimeSettings is the state of the runtime settings of a node
+type NodeRuntimeSettings struct {
+	Pod   PodName              `json:"pod"`
+	State RuntimeSettingsState `json:"state"`
+	// The settings that differ from the spec
+	Drifted []string `json:"drifted,omitempty"`
+	// The settings last applied to the node
+	Applied RuntimeSettings `json:"applied,omitempty"`
+	// Time the settings were last applied
+	AppliedAt *metav1.Time `json:"appliedAt,omitempty"`
+	// Start time of the Cassandra container the settings were last applied to. A restarted node runs with the settings of cassandra.yaml.
+	NodeStartedAt *metav1.Time `json:"nodeStartedAt,omitempty"`
+}
+
+type NodeLoggingLevels struct {
+	Pod PodName `json:"pod"`
+	// The levels of the loggers last applied to the node
+	Loggers map[string]LoggerLevel `json:"loggers,omitempty"`
+	// Start time of the Cassandra container the levels were last applied to
+	NodeStartedAt *metav1.Time `json:"nodeStartedAt,omitempty"`
+}
+
+type QueuedOperationType string
+
+const (
+	QueuedOperationDecommission        QueuedOperationType = "Decommission"
+	QueuedOperationRestart             QueuedOperationType = "Restart"
+	QueuedOperationKeyspaceReplication QueuedOperationType = "KeyspaceReplication"
+	QueuedOperationRepair              QueuedOperationType = "Repair"
+	QueuedOperationCertificateRotation QueuedOperationType = "CertificateRotation"
+)
+
+// QueuedOperation is a disruptive operation held back until a maintenance window starts
+type QueuedOperation struct {
+	Type QueuedOperationType `json:"type"`
+	// The pod, keyspace, ConfigMap or secret the operation is for
+	Target string `json:"target"`
+	// Time the operation was first queued
+	Since metav1.Time `json:"since"`
+	// The start of the next maintenance window
+	EarliestStartTime *metav1.Time `json:"earliestStartTime,omitempty"`
+}
+
+// PendingRestart is a change of the Cassandra pods held back by the restart policy
+type PendingRestart struct {
+	// Time the change was first held back
+	Since metav1.Time `json:"since"`
+	// The earliest time the change is rolled out without an approval
+	EarliestStartTime *metav1.Time       `json:"earliestStartTime,omitempty"`
+	DCs               []PendingRestartDC `json:"dcs"`
+}
+
+type PendingRestartDC struct {
+	Name string `json:"name"`
+	// The configs that changed, e.g. cassandra.yaml or jvm.options
+	Changes []string `json:"changes,omitempty"`
+	// The diff of the statefulset
+	Diff string `json:"diff,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -416,3 +782,136 @@ type NetworkPolicyRule struct {
 func init() {
 	SchemeBuilder.Register(&CassandraCluster{}, &CassandraClusterList{})
 }
+
+func (in *CommitLogArchiving) StorageProvider() StorageProvider {
+	return storageProvider(in.StorageLocation)
+}
+
+// Prefixes of the JVM options setting the heap sizes
+var (
+	JVMMaxHeapSizeOptions     = []string{"-Xmx", "-XX:MaxHeapSize="}
+	JVMInitialHeapSizeOptions = []string{"-Xms", "-XX:InitialHeapSize="}
+	JVMNewSizeOptions         = []string{"-Xmn", "-XX:NewSize=", "-XX:MaxNewSize="}
+)
+
+// JVMOption returns the value of the last option with one of the prefixes, e.g. `4G` for `-Xmx4G`
+func JVMOption(options []string, prefixes []string) (string, bool) {
+	value, found := "", false
+	for _, option := range options {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(option, prefix) {
+				value, found = strings.TrimPrefix(option, prefix), true
+			}
+		}
+	}
+
+	return value, found
+}
+
+// ParseJVMSize parses a size the way the JVM does, e.g. `4G`, `512m` or `1073741824`
+func ParseJVMSize(size string) (int64, error) {
+	number := size
+	multiplier := int64(1)
+	if len(size) > 0 {
+		switch size[len(size)-1] {
+		case 'k', 'K':
+			multiplier = 1 << 10
+		case 'm', 'M':
+			multiplier = 1 << 20
+		case 'g', 'G':
+			multiplier = 1 << 30
+		case 't', 'T':
+			multiplier = 1 << 40
+		}
+	}
+	if multiplier > 1 {
+		number = size[:len(size)-1]
+	}
+
+	value, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+
+	return value * multiplier, nil
+}
+
+// ExpiresAt returns the time the request expires for a pod that entered maintenance at enteredAt. Returns nil if it doesn't expire.
+func (in *Maintenance) ExpiresAt(enteredAt time.Time) *metav1.Time {
+	if in.Until != nil {
+		return in.Until.DeepCopy()
+	}
+
+	if in.Duration != nil {
+		return &metav1.Time{Time: enteredAt.Add(in.Duration.Duration)}
+	}
+
+	return nil
+}
+
+// PodMaintenanceRequest returns the maintenance request for the pod of the DC, or nil if the pod isn't requested to be in maintenance.
+// An entry without pods requests the whole DC.
+func (in *CassandraCluster) PodMaintenanceRequest(dcName, podName string) *Maintenance {
+	for i, entry := range in.Spec.Maintenance {
+		if entry.DC != dcName {
+			continue
+		}
+
+		if len(entry.Pods) == 0 {
+			return &in.Spec.Maintenance[i]
+		}
+
+		for _, pod := range entry.Pods {
+			if string(pod) == podName {
+				return &in.Spec.Maintenance[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// PodMaintenanceStatus returns the record of the last maintenance of the pod
+func (in *CassandraCluster) PodMaintenanceStatus(podName string) *PodMaintenance {
+	for i, record := range in.Status.MaintenancePods {
+		if string(record.Pod) == podName {
+			return &in.Status.MaintenancePods[i]
+		}
+	}
+
+	return nil
+}
+
+// MaintenanceRequested returns true if the pod is requested to be in maintenance and the request hasn't expired
+func (in *CassandraCluster) MaintenanceRequested(dcName, podName string, now time.Time) bool {
+	request := in.PodMaintenanceRequest(dcName, podName)
+	if request == nil {
+		return false
+	}
+
+	if request.Until != nil && !now.Before(request.Until.Time) {
+		return false
+	}
+
+	record := in.PodMaintenanceStatus(podName)
+	if record == nil {
+		return true
+	}
+
+	if record.ExitedAt == nil {
+		expiresAt := request.ExpiresAt(record.EnteredAt.Time)
+		return expiresAt == nil || now.Before(expiresAt.Time)
+	}
+
+	// an expired request stays expired until it's changed
+	return !record.Expired || !sameMaintenanceRequest(request, record)
+}
+
+func sameMaintenanceRequest(request *Maintenance, record *PodMaintenance) bool {
+	expiresAt := request.ExpiresAt(record.EnteredAt.Time)
+	if (expiresAt == nil) != (record.ExpiresAt == nil) || (expiresAt != nil && !expiresAt.Equal(record.ExpiresAt)) {
+		return false
+	}
+
+	return request.Reason == record.Reason && request.RequestedBy == record.RequestedBy
+}
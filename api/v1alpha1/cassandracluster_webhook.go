@@ -18,15 +18,23 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/ibm/cassandra-operator/controllers/cassandraconfig"
 	"github.com/ibm/cassandra-operator/controllers/util"
+	"github.com/robfig/cron/v3"
 
 	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/yaml"
@@ -98,6 +106,10 @@ func validateClusterCreateUpdate(cc *CassandraCluster, ccOld *CassandraCluster)
 		errors = append(errors, err...)
 	}
 
+	if err = validateIcarus(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
 	if err = validateIngress(cc); err != nil {
 		errors = append(errors, err...)
 	}
@@ -106,6 +118,22 @@ func validateClusterCreateUpdate(cc *CassandraCluster, ccOld *CassandraCluster)
 		errors = append(errors, err...)
 	}
 
+	if err = validateRollingRestart(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
+	if err = validateMaintenance(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
+	if err = validateMaintenanceWindows(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
+	if err = validatePodDisruptionBudget(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
 	return
 }
 
@@ -167,12 +195,10 @@ func generalValidation(cc *CassandraCluster) (errors []error) {
 }
 
 func validateCassandra(cc *CassandraCluster) (errors []error) {
-	if len(cc.Spec.Cassandra.ConfigOverrides) > 0 {
-		err := yaml.Unmarshal([]byte(cc.Spec.Cassandra.ConfigOverrides), map[string]interface{}{})
-		if err != nil {
-			errors = append(errors, fmt.Errorf("cassandra config override should be a string with valid YAML: %s", err.Error()))
-		}
-	}
+	errors = append(errors, validateCassandraConfig(cc)...)
+	errors = append(errors, validateRuntimeSettings(cc)...)
+	errors = append(errors, validateJVMHeap(cc)...)
+	errors = append(errors, validateLogging(cc)...)
 
 	if cc.Spec.Cassandra.Monitoring.ServiceMonitor.ScrapeInterval != "" {
 		if _, err := time.ParseDuration(cc.Spec.Cassandra.Monitoring.ServiceMonitor.ScrapeInterval); err != nil {
@@ -180,6 +206,160 @@ func validateCassandra(cc *CassandraCluster) (errors []error) {
 		}
 	}
 
+	if archiving := cc.Spec.Cassandra.CommitLogArchiving; archiving != nil {
+		if err := validateStorageLocation(archiving.StorageLocation); err != nil {
+			errors = append(errors, fmt.Errorf("cassandra.commitLogArchiving.storageLocation is invalid: %s", err.Error()))
+		}
+
+		if StorageSecretRequired(archiving.StorageProvider()) && len(archiving.SecretName) == 0 {
+			errors = append(errors, fmt.Errorf("cassandra.commitLogArchiving.secretName must be set for the %q storage provider", archiving.StorageProvider()))
+		}
+
+		if archiving.StorageProvider() == StorageProviderFile && cc.Spec.Icarus.BackupVolume == nil {
+			errors = append(errors, fmt.Errorf("cassandra.commitLogArchiving with the file storage provider requires icarus.backupVolume to be set"))
+		}
+
+		if archiving.UploadInterval != nil && archiving.UploadInterval.Duration < time.Minute {
+			errors = append(errors, fmt.Errorf("cassandra.commitLogArchiving.uploadInterval must be at least 1m"))
+		}
+	}
+
+	return
+}
+
+func validateCassandraConfig(cc *CassandraCluster) (errors []error) {
+	// the version is unknown if the image is not set as the default image is known only by the operator
+	version, _ := cassandraconfig.ParseImageVersion(cc.Spec.Cassandra.Image)
+
+	typedSettings := cc.Spec.Cassandra.Config.Settings()
+	if cfg := cc.Spec.Cassandra.Config; cfg != nil {
+		durations := []struct {
+			field string
+			value *metav1.Duration
+		}{
+			{"readRequestTimeout", cfg.ReadRequestTimeout},
+			{"writeRequestTimeout", cfg.WriteRequestTimeout},
+			{"rangeRequestTimeout", cfg.RangeRequestTimeout},
+			{"requestTimeout", cfg.RequestTimeout},
+			{"maxHintWindow", cfg.MaxHintWindow},
+		}
+		for _, duration := range durations {
+			if duration.value != nil && duration.value.Duration < time.Millisecond {
+				errors = append(errors, fmt.Errorf("cassandra.config.%s must be at least 1ms", duration.field))
+			}
+		}
+	}
+
+	if len(cc.Spec.Cassandra.ConfigOverrides) == 0 {
+		return
+	}
+
+	overrides := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(cc.Spec.Cassandra.ConfigOverrides), &overrides)
+	if err != nil {
+		return append(errors, fmt.Errorf("cassandra config override should be a string with valid YAML: %s", err.Error()))
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := cassandraconfig.CheckKey(key, version); err != nil {
+			errors = append(errors, fmt.Errorf("cassandra.configOverrides: %s", err.Error()))
+			continue
+		}
+
+		if _, typed := typedSettings[cassandraconfig.LegacyName(key)]; typed {
+			errors = append(errors, fmt.Errorf("cassandra.configOverrides: `%s` is already set in cassandra.config", key))
+		}
+	}
+
+	return
+}
+
+// validateJVMHeap rejects heap sizes that don't fit into the memory of the cassandra container, as the container would be OOMKilled
+// validateRuntimeSettings rejects runtime settings that conflict with the same setting in cassandra.config,
+// as the nodes would switch between both values on every restart
+func validateRuntimeSettings(cc *CassandraCluster) (errors []error) {
+	runtimeSettings, cfg := cc.Spec.Cassandra.RuntimeSettings, cc.Spec.Cassandra.Config
+	if runtimeSettings == nil || cfg == nil {
+		return nil
+	}
+
+	settings := []struct {
+		runtimeField string
+		runtimeValue *int32
+		configField  string
+		configValue  *int32
+	}{
+		{"compactionThroughputMBPerSec", runtimeSettings.CompactionThroughputMBPerSec, "compactionThroughputMBPerSec", cfg.CompactionThroughputMBPerSec},
+		{"streamThroughputMegabitsPerSec", runtimeSettings.StreamThroughputMegabitsPerSec, "streamThroughputOutboundMegabitsPerSec", cfg.StreamThroughputOutboundMegabitsPerSec},
+		{"concurrentCompactors", runtimeSettings.ConcurrentCompactors, "concurrentCompactors", cfg.ConcurrentCompactors},
+	}
+	for _, setting := range settings {
+		if setting.runtimeValue != nil && setting.configValue != nil && *setting.runtimeValue != *setting.configValue {
+			errors = append(errors, fmt.Errorf("cassandra.runtimeSettings.%s (%d) conflicts with cassandra.config.%s (%d)",
+				setting.runtimeField, *setting.runtimeValue, setting.configField, *setting.configValue))
+		}
+	}
+
+	return
+}
+
+func validateJVMHeap(cc *CassandraCluster) (errors []error) {
+	limit, hasLimit := cc.Spec.Cassandra.Resources.Limits[v1.ResourceMemory]
+	var heapOptions []string
+	heapOptions = append(heapOptions, JVMMaxHeapSizeOptions...)
+	heapOptions = append(heapOptions, JVMInitialHeapSizeOptions...)
+	heapOptions = append(heapOptions, JVMNewSizeOptions...)
+	for _, option := range cc.Spec.Cassandra.JVMOptions {
+		for _, prefix := range heapOptions {
+			if !strings.HasPrefix(option, prefix) {
+				continue
+			}
+
+			size, err := ParseJVMSize(strings.TrimPrefix(option, prefix))
+			if err != nil {
+				errors = append(errors, fmt.Errorf("cassandra.jvmOptions: %s has an %s", option, err.Error()))
+				continue
+			}
+
+			if hasLimit && size > limit.Value() {
+				errors = append(errors, fmt.Errorf("cassandra.jvmOptions: %s exceeds the memory limit (%s) of the cassandra container", option, limit.String()))
+			}
+		}
+	}
+
+	return
+}
+
+var percentageRegexp = regexp.MustCompile(`^(100|[1-9]?[0-9])%$`)
+
+// loggerNameRegexp matches Java package and class names
+var loggerNameRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+func validateLogging(cc *CassandraCluster) (errors []error) {
+	logging := cc.Spec.Cassandra.Logging
+	if logging == nil {
+		return nil
+	}
+
+	for logger := range logging.Loggers {
+		if strings.EqualFold(logger, "ROOT") {
+			errors = append(errors, fmt.Errorf("cassandra.logging.loggers: the root level is set by cassandra.logLevel"))
+		} else if !loggerNameRegexp.MatchString(logger) {
+			errors = append(errors, fmt.Errorf("cassandra.logging.loggers: %q is not a valid logger name", logger))
+		}
+	}
+
+	version, versionKnown := cassandraconfig.ParseImageVersion(cc.Spec.Cassandra.Image)
+	if logging.AuditLog && versionKnown && !version.AtLeast(cassandraconfig.V4_0) {
+		errors = append(errors, fmt.Errorf("cassandra.logging.auditLog requires Cassandra %s or later", cassandraconfig.V4_0))
+	}
+
 	return
 }
 
@@ -239,6 +419,15 @@ func validateProber(cc *CassandraCluster) (errors []error) {
 	return
 }
 
+func validateIcarus(cc *CassandraCluster) (errors []error) {
+	backupVolume := cc.Spec.Icarus.BackupVolume
+	if backupVolume != nil && (backupVolume.PersistentVolumeClaim == nil) == (backupVolume.NFS == nil) {
+		errors = append(errors, fmt.Errorf("exactly one of icarus.backupVolume.persistentVolumeClaim and icarus.backupVolume.nfs must be set"))
+	}
+
+	return
+}
+
 func validateIngress(cc *CassandraCluster) (errors []error) {
 	if len(cc.Spec.Ingress.Domain) > 0 {
 		if len(cc.Spec.Ingress.Secret) == 0 {
@@ -319,3 +508,113 @@ func validateNetworkPolicies(cc *CassandraCluster) (errors []error) {
 
 	return errors
 }
+
+func validateRollingRestart(cc *CassandraCluster) (errors []error) {
+	if cc.Spec.RollingRestart == nil {
+		return nil
+	}
+
+	dcNames := make([]string, 0, len(cc.Spec.DCs))
+	for _, dc := range cc.Spec.DCs {
+		dcNames = append(dcNames, dc.Name)
+	}
+
+	for _, dcName := range cc.Spec.RollingRestart.DCs {
+		if !util.Contains(dcNames, dcName) {
+			errors = append(errors, fmt.Errorf("rollingRestart.dcs: DC %q is not defined in the cluster", dcName))
+		}
+	}
+
+	for _, pod := range cc.Spec.RollingRestart.Pods {
+		found := false
+		for _, dcName := range dcNames {
+			if strings.HasPrefix(string(pod), cc.Name+"-cassandra-"+dcName+"-") {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			errors = append(errors, fmt.Errorf("rollingRestart.pods: pod %q is not a Cassandra pod of the cluster", pod))
+		}
+	}
+
+	return
+}
+
+func validateMaintenance(cc *CassandraCluster) (errors []error) {
+	replicas := make(map[string]int32, len(cc.Spec.DCs))
+	for _, dc := range cc.Spec.DCs {
+		if dc.Replicas != nil {
+			replicas[dc.Name] = *dc.Replicas
+		}
+	}
+
+	for i, entry := range cc.Spec.Maintenance {
+		dcReplicas, found := replicas[entry.DC]
+		if !found {
+			errors = append(errors, fmt.Errorf("maintenance[%d].dc: DC %q is not defined in the cluster", i, entry.DC))
+			continue
+		}
+
+		podPrefix := cc.Name + "-cassandra-" + entry.DC + "-"
+		for _, pod := range entry.Pods {
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(string(pod), podPrefix))
+			if !strings.HasPrefix(string(pod), podPrefix) || err != nil || ordinal < 0 || ordinal >= int(dcReplicas) {
+				errors = append(errors, fmt.Errorf("maintenance[%d].pods: pod %q doesn't exist in DC %q", i, pod, entry.DC))
+			}
+		}
+
+		if entry.Until != nil && entry.Duration != nil {
+			errors = append(errors, fmt.Errorf("maintenance[%d]: either `until` or `duration` should be set", i))
+		}
+
+		if entry.Duration != nil && entry.Duration.Duration <= 0 {
+			errors = append(errors, fmt.Errorf("maintenance[%d].duration should be a positive duration", i))
+		}
+	}
+
+	return
+}
+
+func validateMaintenanceWindows(cc *CassandraCluster) (errors []error) {
+	for i, window := range cc.Spec.MaintenanceWindows {
+		if _, err := ParseMaintenanceWindow(window); err != nil {
+			errors = append(errors, fmt.Errorf("maintenanceWindows[%d]: invalid schedule: %s", i, err.Error()))
+		}
+
+		if window.Duration.Duration <= 0 {
+			errors = append(errors, fmt.Errorf("maintenanceWindows[%d].duration should be a positive duration", i))
+		}
+	}
+
+	if cc.Spec.RestartPolicy != nil && cc.Spec.RestartPolicy.Type == RestartPolicyMaintenanceWindow && len(cc.Spec.MaintenanceWindows) == 0 {
+		errors = append(errors, fmt.Errorf("maintenanceWindows must be set for the %s restart policy", RestartPolicyMaintenanceWindow))
+	}
+
+	return
+}
+
+func validatePodDisruptionBudget(cc *CassandraCluster) (errors []error) {
+	maxUnavailable := cc.Spec.PodDisruptionBudget.MaxUnavailable
+	if maxUnavailable == nil {
+		return nil
+	}
+
+	if maxUnavailable.Type == intstr.String && !percentageRegexp.MatchString(maxUnavailable.StrVal) {
+		errors = append(errors, fmt.Errorf("podDisruptionBudget.maxUnavailable should be a number or a percentage, e.g. 10%%"))
+	} else if maxUnavailable.Type == intstr.Int && maxUnavailable.IntVal < 0 {
+		errors = append(errors, fmt.Errorf("podDisruptionBudget.maxUnavailable can't be negative"))
+	}
+
+	return
+}
+
+// ParseMaintenanceWindow parses the cron expression of the window in its time zone
+func ParseMaintenanceWindow(window MaintenanceWindow) (cron.Schedule, error) {
+	if len(window.TimeZone) != 0 {
+		return cron.ParseStandard("CRON_TZ=" + window.TimeZone + " " + window.Schedule)
+	}
+
+	return cron.ParseStandard(window.Schedule)
+}
@@ -2,19 +2,35 @@ package v1alpha1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+const (
+	// CassandraRestoreLabel is set on the jobs created by a CassandraRestore
+	CassandraRestoreLabel = "cassandra-restore"
+	// RestoreFenceAnnotation is set on a CassandraCluster fenced by a running restore. Holds the name of the CassandraRestore.
+	RestoreFenceAnnotation = "db.ibm.com/restore-fence"
+	// RestoreFencePausedRepairSchedulesAnnotation holds the comma separated IDs of the repair schedules paused by the restore fence
+	RestoreFencePausedRepairSchedulesAnnotation = "db.ibm.com/restore-fence-paused-repair-schedules"
+	// VolumeSnapshotRestoreAnnotation is set on the PVCs provisioned from VolumeSnapshots. Holds the name of the CassandraRestore.
+	VolumeSnapshotRestoreAnnotation = "db.ibm.com/volume-snapshot-restore"
+	// ConditionTypePreflightChecksPassed is true once the cluster is ready for the restore to start
+	ConditionTypePreflightChecksPassed = "PreflightChecksPassed"
+)
+
 type CassandraRestoreSpec struct {
 	CassandraCluster string `json:"cassandraCluster"`
 	CassandraBackup  string `json:"cassandraBackup,omitempty"`
+	// Restores a backup listed in a CassandraBackupCatalog instead of a CassandraBackup.
+	// The storage location and secret are taken from the catalog.
+	BackupCatalog *BackupCatalogReference `json:"backupCatalog,omitempty"`
 	// example: gcp://myBucket
 	// location of SSTables
 	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name
-	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph' or 'oracle'.
+	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph', 'oracle' or 'file'.
 	// If empty, the value is retrieved from the CassandraBackup spec
 	StorageLocation string `json:"storageLocation,omitempty"`
 	// Name of the snapshot tag to restore. Can be used to manually set the snapshot tag. Retrieved from CassandraBackup if not specified
 	SnapshotTag string `json:"snapshotTag,omitempty"`
 	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
-	// The secret from the backup spec is used when empty
+	// The secret from the backup spec is used when empty. Not used by the 'file' storage provider.
 	SecretName string `json:"secretName,omitempty"`
 	// number of threads used for download, there might be at most so many downloading threads at any given time,
 	// when not set, it defaults to 10
@@ -50,6 +66,7 @@ type CassandraRestoreSpec struct {
 	// Do not check the existence of a bucket.
 	// Some storage providers (e.g. S3) requires a special permissions to be able to list buckets or query their existence which might not be allowed.
 	// This flag will skip that check. Keep in mind that if that bucket does not exist, the whole backup operation will fail.
+	// The operator doesn't verify the storage credentials against the bucket either.
 	SkipBucketVerification bool  `json:"skipBucketVerification,omitempty"`
 	Retry                  Retry `json:"retry,omitempty"`
 	// Map of key and values where keys and values are in format "keyspace.table", if key is "ks1.tb1" and value is "ks1.tb2",
@@ -68,6 +85,45 @@ type CassandraRestoreSpec struct {
 	// There might be cases when we want to restore a table for which its CQL schema has not changed,
 	// but it has changed for other table / keyspace but a schema for that node has changed by doing that.
 	ExactSchemaVersion bool `json:"exactSchemaVersion,omitempty"`
+	// Restores the cluster to the state it had at the given time.
+	// The latest completed CassandraBackup of the whole cluster started before that time is restored, unless cassandraBackup or snapshotTag is set.
+	// The commit logs archived since the backup are replayed afterwards up to the given time, which restarts the Cassandra pods.
+	// Requires commit log archiving to be enabled in the CassandraCluster.
+	RestorePointInTime *metav1.Time `json:"restorePointInTime,omitempty"`
+	// How the SSTables are restored. Hardlinks (default) restores each node from the backup of the node it replaces,
+	// which requires the same cluster name, DC names and topology as the backed up cluster.
+	// Load streams the SSTables of each backed up node into the cluster with sstableloader,
+	// so the backup can be restored into a cluster with a different name, DC names or number of nodes.
+	// VolumeSnapshot provisions the volumes of the cluster from the VolumeSnapshots of a backup taken in the VolumeSnapshot mode.
+	// The restore has to be created before the cluster, since the volumes can't be provisioned once the cluster is running.
+	// +kubebuilder:validation:Enum=Hardlinks;Load;VolumeSnapshot
+	Mode RestoreMode `json:"mode,omitempty"`
+	// Map of source DC names to target DC names, e.g. {"dc1": "staging"} restores the nodes of DC dc1 of the backup into DC staging.
+	// Only DCs in the map are restored. Can be used with the Load mode only.
+	// When empty, each DC of the backup is restored into the DC with the same name.
+	DCMapping map[string]string `json:"dcMapping,omitempty"`
+	// Fences the cluster while the restore is running: Reaper repair schedules are paused
+	// and client CQL connections allowed by .spec.networkPolicies of the CassandraCluster are blocked.
+	// CQL is only blocked if network policies are enabled in the CassandraCluster.
+	Fence bool `json:"fence,omitempty"`
+	// Cancels the restore if it's running. The load jobs are deleted and the restore is CANCELLING until all nodes have stopped their Icarus operations, then CANCELLED.
+	// A cancelled restore can't be resumed, create a new CassandraRestore to restore the backup again.
+	Cancel bool `json:"cancel,omitempty"`
+	// Decrypts the backup data with a key from a secret. The encryption of the CassandraBackup is used when empty.
+	// Must be set for encrypted backups restored from a catalog or by storageLocation and snapshotTag.
+	// Setting it for a CassandraBackup that isn't encrypted fails the restore.
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+}
+
+type RestoreMode string
+
+// BackupCatalogReference references a backup in a CassandraBackupCatalog
+type BackupCatalogReference struct {
+	// Name of the CassandraBackupCatalog
+	Name string `json:"name"`
+	// The snapshot tag of the backup. If the catalog lists several backups with the tag,
+	// the latest one is restored, unless .spec.schemaVersion is set.
+	SnapshotTag string `json:"snapshotTag"`
 }
 
 type RestoreImport struct {
@@ -84,6 +140,29 @@ type CassandraRestoreStatus struct {
 	State    string         `json:"state,omitempty"`
 	Progress int            `json:"progress,omitempty"`
 	Errors   []RestoreError `json:"errors,omitempty"`
+	// The Cassandra pod that coordinates the restore in Icarus
+	Coordinator string `json:"coordinator,omitempty"`
+	// The time the restore was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the restore has completed or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// The restoration phase the restore is in
+	Phase string `json:"phase,omitempty"`
+	// The state of the restore on each node
+	Nodes []NodeProgress `json:"nodes,omitempty"`
+	// Bytes downloaded by all nodes so far
+	DownloadedBytes int64 `json:"downloadedBytes,omitempty"`
+	// Bytes all nodes have to download in total
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// Conditions of the restore. Can be PreflightChecksPassed, Progressing, Complete or Failed.
+	// None of Progressing, Complete and Failed is true once the restore is cancelled.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The CassandraBackup chosen for a point-in-time restore
+	CassandraBackup string `json:"cassandraBackup,omitempty"`
+	// The phase of the commit log replay of a point-in-time restore. Can be Downloading, Replaying or Completed.
+	CommitLogReplay string `json:"commitLogReplay,omitempty"`
+	// The time the Cassandra pods were restarted to replay the commit logs
+	CommitLogReplayStartTime *metav1.Time `json:"commitLogReplayStartTime,omitempty"`
 }
 
 type RestoreError struct {
@@ -93,6 +172,16 @@ type RestoreError struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cassandraCluster`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=`.status.progress`
+// +kubebuilder:printcolumn:name="Downloaded",type=integer,JSONPath=`.status.downloadedBytes`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalBytes`,priority=1
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,priority=1
+// +kubebuilder:printcolumn:name="Coordinator",type=string,JSONPath=`.status.coordinator`,priority=1
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+// +kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.completionTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // CassandraRestore is the Schema for the CassandraRestores API
 type CassandraRestore struct {
@@ -116,6 +205,18 @@ func init() {
 	SchemeBuilder.Register(&CassandraRestore{}, &CassandraRestoreList{})
 }
 
+const (
+	RestoreModeHardlinks      RestoreMode = "Hardlinks"
+	RestoreModeLoad           RestoreMode = "Load"
+	RestoreModeVolumeSnapshot RestoreMode = "VolumeSnapshot"
+)
+
+const (
+	CommitLogReplayDownloading = "Downloading"
+	CommitLogReplayReplaying   = "Replaying"
+	CommitLogReplayCompleted   = "Completed"
+)
+
 func (in *CassandraRestore) StorageProvider() StorageProvider {
 	return storageProvider(in.Spec.StorageLocation)
 }
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -50,7 +51,12 @@ func (cr *CassandraRestore) ValidateUpdate(old runtime.Object) error {
 		return fmt.Errorf("old cassandra cluster object: (%s) is not of type CassandraRestore", cbOld.Name)
 	}
 
-	return kerrors.NewAggregate(validateRestoreCreateUpdate(cr))
+	verrors := validateRestoreCreateUpdate(cr)
+	if cbOld.Spec.Cancel && !cr.Spec.Cancel {
+		verrors = append(verrors, errors.New(".spec.cancel can't be unset, a cancelled restore can't be resumed"))
+	}
+
+	return kerrors.NewAggregate(verrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -60,9 +66,15 @@ func (cr *CassandraRestore) ValidateDelete() error {
 }
 
 func validateRestoreCreateUpdate(cr *CassandraRestore) (verrors []error) {
-	if len(cr.Spec.CassandraBackup) == 0 {
-		if len(cr.Spec.StorageLocation) == 0 || len(cr.Spec.SnapshotTag) == 0 || len(cr.Spec.SecretName) == 0 {
-			verrors = append(verrors, errors.New(".spec.storageLocation, .spec.snapshotTag and .spec.secretName should be set if .spec.cassandraBackup is not set"))
+	// the backup of a point-in-time restore is chosen by the operator if it's not set
+	pointInTimeBackup := cr.Spec.RestorePointInTime != nil && len(cr.Spec.CassandraBackup) == 0 && len(cr.Spec.SnapshotTag) == 0
+	if cr.Spec.BackupCatalog != nil {
+		verrors = append(verrors, validateRestoreBackupCatalog(cr)...)
+	} else if len(cr.Spec.CassandraBackup) == 0 && !pointInTimeBackup {
+		secretRequired := StorageSecretRequired(cr.StorageProvider())
+		if len(cr.Spec.StorageLocation) == 0 || len(cr.Spec.SnapshotTag) == 0 || (secretRequired && len(cr.Spec.SecretName) == 0) {
+			verrors = append(verrors, errors.New(".spec.storageLocation, .spec.snapshotTag and .spec.secretName should be set if .spec.cassandraBackup is not set. "+
+				".spec.secretName is not needed for the file storage provider"))
 		} else {
 			if err := validateStorageLocation(cr.Spec.StorageLocation); err != nil {
 				verrors = append(verrors, err)
@@ -70,5 +82,95 @@ func validateRestoreCreateUpdate(cr *CassandraRestore) (verrors []error) {
 		}
 	}
 
+	if cr.Spec.RestorePointInTime != nil && (len(cr.Spec.DC) != 0 || len(cr.Spec.Entities) != 0 || len(cr.Spec.Rename) != 0) {
+		verrors = append(verrors, errors.New(".spec.dc, .spec.entities and .spec.rename can't be used with .spec.restorePointInTime, "+
+			"since commit logs are replayed for the whole cluster"))
+	}
+
+	if err := validateEncryption(cr.Spec.Encryption); err != nil {
+		verrors = append(verrors, err)
+	}
+
+	verrors = append(verrors, validateRestoreMode(cr)...)
+
+	return verrors
+}
+
+func validateRestoreMode(cr *CassandraRestore) (verrors []error) {
+	if cr.Spec.Mode == RestoreModeVolumeSnapshot {
+		return validateVolumeSnapshotRestore(cr)
+	}
+
+	if cr.Spec.Mode != RestoreModeLoad {
+		if len(cr.Spec.DCMapping) != 0 {
+			verrors = append(verrors, errors.New(".spec.dcMapping can be used with the Load mode only, "+
+				"the Hardlinks mode restores each DC into the DC with the same name"))
+		}
+		return verrors
+	}
+
+	// the nodes to load the SSTables of are taken from the status of the backup or from the catalog
+	if len(cr.Spec.CassandraBackup) == 0 && cr.Spec.BackupCatalog == nil {
+		verrors = append(verrors, errors.New(".spec.cassandraBackup or .spec.backupCatalog must be set for the Load mode"))
+	}
+
+	if len(cr.Spec.DC) != 0 {
+		verrors = append(verrors, errors.New(".spec.dc can't be used with the Load mode, use .spec.dcMapping to choose the DCs to restore"))
+	}
+
+	if cr.Spec.RestorePointInTime != nil || len(cr.Spec.Rename) != 0 {
+		verrors = append(verrors, errors.New(".spec.restorePointInTime and .spec.rename can't be used with the Load mode"))
+	}
+
+	sourceDCs := make([]string, 0, len(cr.Spec.DCMapping))
+	for sourceDC := range cr.Spec.DCMapping {
+		sourceDCs = append(sourceDCs, sourceDC)
+	}
+	sort.Strings(sourceDCs)
+
+	for _, sourceDC := range sourceDCs {
+		if len(sourceDC) == 0 || len(cr.Spec.DCMapping[sourceDC]) == 0 {
+			verrors = append(verrors, fmt.Errorf(".spec.dcMapping contains an empty DC name: %q: %q", sourceDC, cr.Spec.DCMapping[sourceDC]))
+		}
+	}
+
+	return verrors
+}
+
+func validateVolumeSnapshotRestore(cr *CassandraRestore) (verrors []error) {
+	// the snapshots are taken from the status of the backup
+	if len(cr.Spec.CassandraBackup) == 0 {
+		verrors = append(verrors, errors.New(".spec.cassandraBackup must be set for the VolumeSnapshot mode"))
+	}
+
+	if cr.Spec.BackupCatalog != nil || len(cr.Spec.StorageLocation) != 0 || len(cr.Spec.SnapshotTag) != 0 {
+		verrors = append(verrors, errors.New(".spec.backupCatalog, .spec.storageLocation and .spec.snapshotTag can't be used with the VolumeSnapshot mode"))
+	}
+
+	// whole volumes are restored into a cluster that isn't running yet
+	if len(cr.Spec.DC) != 0 || len(cr.Spec.Entities) != 0 || len(cr.Spec.Rename) != 0 || len(cr.Spec.DCMapping) != 0 ||
+		cr.Spec.RestorePointInTime != nil || cr.Spec.Fence || cr.Spec.Encryption != nil {
+		verrors = append(verrors, errors.New(".spec.dc, .spec.entities, .spec.rename, .spec.dcMapping, .spec.restorePointInTime, .spec.fence and .spec.encryption "+
+			"can't be used with the VolumeSnapshot mode"))
+	}
+
+	return verrors
+}
+
+func validateRestoreBackupCatalog(cr *CassandraRestore) (verrors []error) {
+	if len(cr.Spec.BackupCatalog.Name) == 0 || len(cr.Spec.BackupCatalog.SnapshotTag) == 0 {
+		verrors = append(verrors, errors.New(".spec.backupCatalog.name and .spec.backupCatalog.snapshotTag should be set"))
+	}
+
+	if len(cr.Spec.CassandraBackup) != 0 || len(cr.Spec.SnapshotTag) != 0 || cr.Spec.RestorePointInTime != nil {
+		verrors = append(verrors, errors.New(".spec.cassandraBackup, .spec.snapshotTag and .spec.restorePointInTime can't be used with .spec.backupCatalog"))
+	}
+
+	if len(cr.Spec.StorageLocation) != 0 {
+		if err := validateStorageLocation(cr.Spec.StorageLocation); err != nil {
+			verrors = append(verrors, err)
+		}
+	}
+
 	return verrors
 }
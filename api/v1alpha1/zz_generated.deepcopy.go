@@ -22,9 +22,10 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -47,6 +48,36 @@ func (in *AutoScheduling) DeepCopy() *AutoScheduling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCatalogReference) DeepCopyInto(out *BackupCatalogReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCatalogReference.
+func (in *BackupCatalogReference) DeepCopy() *BackupCatalogReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCatalogReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryption) DeepCopyInto(out *BackupEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryption.
+func (in *BackupEncryption) DeepCopy() *BackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupError) DeepCopyInto(out *BackupError) {
 	*out = *in
@@ -62,6 +93,41 @@ func (in *BackupError) DeepCopy() *BackupError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVolumeSnapshot) DeepCopyInto(out *BackupVolumeSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVolumeSnapshot.
+func (in *BackupVolumeSnapshot) DeepCopy() *BackupVolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CATLSSecret) DeepCopyInto(out *CATLSSecret) {
 	*out = *in
@@ -105,6 +171,26 @@ func (in *Cassandra) DeepCopyInto(out *Cassandra) {
 		}
 	}
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(CassandraConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeSettings != nil {
+		in, out := &in.RuntimeSettings, &out.RuntimeSettings
+		*out = new(RuntimeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(CassandraLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommitLogArchiving != nil {
+		in, out := &in.CommitLogArchiving, &out.CommitLogArchiving
+		*out = new(CommitLogArchiving)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cassandra.
@@ -144,6 +230,122 @@ func (in *CassandraBackup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalog) DeepCopyInto(out *CassandraBackupCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalog.
+func (in *CassandraBackupCatalog) DeepCopy() *CassandraBackupCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraBackupCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalogList) DeepCopyInto(out *CassandraBackupCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraBackupCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalogList.
+func (in *CassandraBackupCatalogList) DeepCopy() *CassandraBackupCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraBackupCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalogSpec) DeepCopyInto(out *CassandraBackupCatalogSpec) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalogSpec.
+func (in *CassandraBackupCatalogSpec) DeepCopy() *CassandraBackupCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalogStatus) DeepCopyInto(out *CassandraBackupCatalogStatus) {
+	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]CatalogBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncStartTime != nil {
+		in, out := &in.SyncStartTime, &out.SyncStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalogStatus.
+func (in *CassandraBackupCatalogStatus) DeepCopy() *CassandraBackupCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraBackupList) DeepCopyInto(out *CassandraBackupList) {
 	*out = *in
@@ -176,6 +378,132 @@ func (in *CassandraBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupSchedule) DeepCopyInto(out *CassandraBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupSchedule.
+func (in *CassandraBackupSchedule) DeepCopy() *CassandraBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupScheduleList) DeepCopyInto(out *CassandraBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupScheduleList.
+func (in *CassandraBackupScheduleList) DeepCopy() *CassandraBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupScheduleSpec) DeepCopyInto(out *CassandraBackupScheduleSpec) {
+	*out = *in
+	if in.SuccessfulBackupsHistoryLimit != nil {
+		in, out := &in.SuccessfulBackupsHistoryLimit, &out.SuccessfulBackupsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedBackupsHistoryLimit != nil {
+		in, out := &in.FailedBackupsHistoryLimit, &out.FailedBackupsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupScheduleSpec.
+func (in *CassandraBackupScheduleSpec) DeepCopy() *CassandraBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupScheduleStatus) DeepCopyInto(out *CassandraBackupScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupScheduleStatus.
+func (in *CassandraBackupScheduleStatus) DeepCopy() *CassandraBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraBackupSpec) DeepCopyInto(out *CassandraBackupSpec) {
 	*out = *in
@@ -185,6 +513,16 @@ func (in *CassandraBackupSpec) DeepCopyInto(out *CassandraBackupSpec) {
 		**out = **in
 	}
 	out.Retry = in.Retry
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupSpec.
@@ -205,6 +543,33 @@ func (in *CassandraBackupStatus) DeepCopyInto(out *CassandraBackupStatus) {
 		*out = make([]BackupError, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeProgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = make([]BackupVolumeSnapshot, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupStatus.
@@ -316,6 +681,22 @@ func (in *CassandraClusterSpec) DeepCopyInto(out *CassandraClusterSpec) {
 	in.HostPort.DeepCopyInto(&out.HostPort)
 	in.Encryption.DeepCopyInto(&out.Encryption)
 	in.NetworkPolicies.DeepCopyInto(&out.NetworkPolicies)
+	if in.RollingRestart != nil {
+		in, out := &in.RollingRestart, &out.RollingRestart
+		*out = new(RollingRestart)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(RestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraClusterSpec.
@@ -338,6 +719,44 @@ func (in *CassandraClusterStatus) DeepCopyInto(out *CassandraClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenancePods != nil {
+		in, out := &in.MaintenancePods, &out.MaintenancePods
+		*out = make([]PodMaintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollingRestart != nil {
+		in, out := &in.RollingRestart, &out.RollingRestart
+		*out = new(RollingRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingRestart != nil {
+		in, out := &in.PendingRestart, &out.PendingRestart
+		*out = new(PendingRestart)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueuedOperations != nil {
+		in, out := &in.QueuedOperations, &out.QueuedOperations
+		*out = make([]QueuedOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeSettings != nil {
+		in, out := &in.RuntimeSettings, &out.RuntimeSettings
+		*out = make([]NodeRuntimeSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LoggingLevels != nil {
+		in, out := &in.LoggingLevels, &out.LoggingLevels
+		*out = make([]NodeLoggingLevels, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraClusterStatus.
@@ -350,6 +769,123 @@ func (in *CassandraClusterStatus) DeepCopy() *CassandraClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraConfig) DeepCopyInto(out *CassandraConfig) {
+	*out = *in
+	if in.ConcurrentReads != nil {
+		in, out := &in.ConcurrentReads, &out.ConcurrentReads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentWrites != nil {
+		in, out := &in.ConcurrentWrites, &out.ConcurrentWrites
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentCounterWrites != nil {
+		in, out := &in.ConcurrentCounterWrites, &out.ConcurrentCounterWrites
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentCompactors != nil {
+		in, out := &in.ConcurrentCompactors, &out.ConcurrentCompactors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CompactionThroughputMBPerSec != nil {
+		in, out := &in.CompactionThroughputMBPerSec, &out.CompactionThroughputMBPerSec
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StreamThroughputOutboundMegabitsPerSec != nil {
+		in, out := &in.StreamThroughputOutboundMegabitsPerSec, &out.StreamThroughputOutboundMegabitsPerSec
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadRequestTimeout != nil {
+		in, out := &in.ReadRequestTimeout, &out.ReadRequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WriteRequestTimeout != nil {
+		in, out := &in.WriteRequestTimeout, &out.WriteRequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RangeRequestTimeout != nil {
+		in, out := &in.RangeRequestTimeout, &out.RangeRequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HintedHandoffEnabled != nil {
+		in, out := &in.HintedHandoffEnabled, &out.HintedHandoffEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxHintWindow != nil {
+		in, out := &in.MaxHintWindow, &out.MaxHintWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeyCacheSizeMB != nil {
+		in, out := &in.KeyCacheSizeMB, &out.KeyCacheSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AutoSnapshot != nil {
+		in, out := &in.AutoSnapshot, &out.AutoSnapshot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaterializedViewsEnabled != nil {
+		in, out := &in.MaterializedViewsEnabled, &out.MaterializedViewsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserDefinedFunctionsEnabled != nil {
+		in, out := &in.UserDefinedFunctionsEnabled, &out.UserDefinedFunctionsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraConfig.
+func (in *CassandraConfig) DeepCopy() *CassandraConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraLogging) DeepCopyInto(out *CassandraLogging) {
+	*out = *in
+	if in.Loggers != nil {
+		in, out := &in.Loggers, &out.Loggers
+		*out = make(map[string]LoggerLevel, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraLogging.
+func (in *CassandraLogging) DeepCopy() *CassandraLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraRestore) DeepCopyInto(out *CassandraRestore) {
 	*out = *in
@@ -412,6 +948,11 @@ func (in *CassandraRestoreList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraRestoreSpec) DeepCopyInto(out *CassandraRestoreSpec) {
 	*out = *in
+	if in.BackupCatalog != nil {
+		in, out := &in.BackupCatalog, &out.BackupCatalog
+		*out = new(BackupCatalogReference)
+		**out = **in
+	}
 	out.Import = in.Import
 	out.Retry = in.Retry
 	if in.Rename != nil {
@@ -421,34 +962,122 @@ func (in *CassandraRestoreSpec) DeepCopyInto(out *CassandraRestoreSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RestorePointInTime != nil {
+		in, out := &in.RestorePointInTime, &out.RestorePointInTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DCMapping != nil {
+		in, out := &in.DCMapping, &out.DCMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreSpec.
+func (in *CassandraRestoreSpec) DeepCopy() *CassandraRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRestoreStatus) DeepCopyInto(out *CassandraRestoreStatus) {
+	*out = *in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]RestoreError, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeProgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommitLogReplayStartTime != nil {
+		in, out := &in.CommitLogReplayStartTime, &out.CommitLogReplayStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreStatus.
+func (in *CassandraRestoreStatus) DeepCopy() *CassandraRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogBackup) DeepCopyInto(out *CatalogBackup) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.DCs != nil {
+		in, out := &in.DCs, &out.DCs
+		*out = make([]CatalogDC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreSpec.
-func (in *CassandraRestoreSpec) DeepCopy() *CassandraRestoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogBackup.
+func (in *CatalogBackup) DeepCopy() *CatalogBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraRestoreSpec)
+	out := new(CatalogBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CassandraRestoreStatus) DeepCopyInto(out *CassandraRestoreStatus) {
+func (in *CatalogDC) DeepCopyInto(out *CatalogDC) {
 	*out = *in
-	if in.Errors != nil {
-		in, out := &in.Errors, &out.Errors
-		*out = make([]RestoreError, len(*in))
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreStatus.
-func (in *CassandraRestoreStatus) DeepCopy() *CassandraRestoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogDC.
+func (in *CatalogDC) DeepCopy() *CatalogDC {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraRestoreStatus)
+	out := new(CatalogDC)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -480,6 +1109,26 @@ func (in *ClientEncryption) DeepCopy() *ClientEncryption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitLogArchiving) DeepCopyInto(out *CommitLogArchiving) {
+	*out = *in
+	if in.UploadInterval != nil {
+		in, out := &in.UploadInterval, &out.UploadInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitLogArchiving.
+func (in *CommitLogArchiving) DeepCopy() *CommitLogArchiving {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitLogArchiving)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DC) DeepCopyInto(out *DC) {
 	*out = *in
@@ -490,12 +1139,12 @@ func (in *DC) DeepCopyInto(out *DC) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -595,6 +1244,11 @@ func (in *HostPort) DeepCopy() *HostPort {
 func (in *Icarus) DeepCopyInto(out *Icarus) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.BackupVolume != nil {
+		in, out := &in.BackupVolume, &out.BackupVolume
+		*out = new(IcarusBackupVolume)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Icarus.
@@ -607,6 +1261,31 @@ func (in *Icarus) DeepCopy() *Icarus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IcarusBackupVolume) DeepCopyInto(out *IcarusBackupVolume) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.NFS != nil {
+		in, out := &in.NFS, &out.NFS
+		*out = new(corev1.NFSVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IcarusBackupVolume.
+func (in *IcarusBackupVolume) DeepCopy() *IcarusBackupVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(IcarusBackupVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ingress) DeepCopyInto(out *Ingress) {
 	*out = *in
@@ -658,6 +1337,15 @@ func (in *Maintenance) DeepCopyInto(out *Maintenance) {
 		*out = make([]PodName, len(*in))
 		copy(*out, *in)
 	}
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maintenance.
@@ -670,6 +1358,22 @@ func (in *Maintenance) DeepCopy() *Maintenance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedRegion) DeepCopyInto(out *ManagedRegion) {
 	*out = *in
@@ -752,12 +1456,12 @@ func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
 	*out = *in
 	if in.PodSelector != nil {
 		in, out := &in.PodSelector, &out.PodSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.NamespaceSelector != nil {
 		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Ports != nil {
@@ -777,6 +1481,84 @@ func (in *NetworkPolicyRule) DeepCopy() *NetworkPolicyRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLoggingLevels) DeepCopyInto(out *NodeLoggingLevels) {
+	*out = *in
+	if in.Loggers != nil {
+		in, out := &in.Loggers, &out.Loggers
+		*out = make(map[string]LoggerLevel, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeStartedAt != nil {
+		in, out := &in.NodeStartedAt, &out.NodeStartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLoggingLevels.
+func (in *NodeLoggingLevels) DeepCopy() *NodeLoggingLevels {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLoggingLevels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProgress) DeepCopyInto(out *NodeProgress) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProgress.
+func (in *NodeProgress) DeepCopy() *NodeProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRuntimeSettings) DeepCopyInto(out *NodeRuntimeSettings) {
+	*out = *in
+	if in.Drifted != nil {
+		in, out := &in.Drifted, &out.Drifted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Applied.DeepCopyInto(&out.Applied)
+	if in.AppliedAt != nil {
+		in, out := &in.AppliedAt, &out.AppliedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeStartedAt != nil {
+		in, out := &in.NodeStartedAt, &out.NodeStartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRuntimeSettings.
+func (in *NodeRuntimeSettings) DeepCopy() *NodeRuntimeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRuntimeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeTLSSecret) DeepCopyInto(out *NodeTLSSecret) {
 	*out = *in
@@ -792,6 +1574,53 @@ func (in *NodeTLSSecret) DeepCopy() *NodeTLSSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRestart) DeepCopyInto(out *PendingRestart) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.EarliestStartTime != nil {
+		in, out := &in.EarliestStartTime, &out.EarliestStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DCs != nil {
+		in, out := &in.DCs, &out.DCs
+		*out = make([]PendingRestartDC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRestart.
+func (in *PendingRestart) DeepCopy() *PendingRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRestartDC) DeepCopyInto(out *PendingRestartDC) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRestartDC.
+func (in *PendingRestartDC) DeepCopy() *PendingRestartDC {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRestartDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Persistence) DeepCopyInto(out *Persistence) {
 	*out = *in
@@ -823,6 +1652,55 @@ func (in *Persistence) DeepCopy() *Persistence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMaintenance) DeepCopyInto(out *PodMaintenance) {
+	*out = *in
+	in.EnteredAt.DeepCopyInto(&out.EnteredAt)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitedAt != nil {
+		in, out := &in.ExitedAt, &out.ExitedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMaintenance.
+func (in *PodMaintenance) DeepCopy() *PodMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Prober) DeepCopyInto(out *Prober) {
 	*out = *in
@@ -831,7 +1709,7 @@ func (in *Prober) DeepCopyInto(out *Prober) {
 	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -845,7 +1723,7 @@ func (in *Prober) DeepCopyInto(out *Prober) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -860,12 +1738,32 @@ func (in *Prober) DeepCopy() *Prober {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueuedOperation) DeepCopyInto(out *QueuedOperation) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.EarliestStartTime != nil {
+		in, out := &in.EarliestStartTime, &out.EarliestStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueuedOperation.
+func (in *QueuedOperation) DeepCopy() *QueuedOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(QueuedOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reaper) DeepCopyInto(out *Reaper) {
 	*out = *in
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -950,6 +1848,25 @@ func (in *RepairSchedules) DeepCopy() *RepairSchedules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartPolicy) DeepCopyInto(out *RestartPolicy) {
+	*out = *in
+	if in.ApprovedAt != nil {
+		in, out := &in.ApprovedAt, &out.ApprovedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartPolicy.
+func (in *RestartPolicy) DeepCopy() *RestartPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreError) DeepCopyInto(out *RestoreError) {
 	*out = *in
@@ -995,6 +1912,105 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingRestart) DeepCopyInto(out *RollingRestart) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	if in.DCs != nil {
+		in, out := &in.DCs, &out.DCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PodName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingRestart.
+func (in *RollingRestart) DeepCopy() *RollingRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingRestartStatus) DeepCopyInto(out *RollingRestartStatus) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	if in.PendingPods != nil {
+		in, out := &in.PendingPods, &out.PendingPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentPodRestartTime != nil {
+		in, out := &in.CurrentPodRestartTime, &out.CurrentPodRestartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestartedPods != nil {
+		in, out := &in.RestartedPods, &out.RestartedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingRestartStatus.
+func (in *RollingRestartStatus) DeepCopy() *RollingRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeSettings) DeepCopyInto(out *RuntimeSettings) {
+	*out = *in
+	if in.CompactionThroughputMBPerSec != nil {
+		in, out := &in.CompactionThroughputMBPerSec, &out.CompactionThroughputMBPerSec
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StreamThroughputMegabitsPerSec != nil {
+		in, out := &in.StreamThroughputMegabitsPerSec, &out.StreamThroughputMegabitsPerSec
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentCompactors != nil {
+		in, out := &in.ConcurrentCompactors, &out.ConcurrentCompactors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HintedHandoffThrottleKB != nil {
+		in, out := &in.HintedHandoffThrottleKB, &out.HintedHandoffThrottleKB
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSettings.
+func (in *RuntimeSettings) DeepCopy() *RuntimeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerEncryption) DeepCopyInto(out *ServerEncryption) {
 	*out = *in
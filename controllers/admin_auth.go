@@ -175,7 +175,7 @@ func (r *CassandraClusterReconciler) handleAdminRoleChange(ctx context.Context,
 
 	var cqlClientTestCon cql.CqlClient
 	err = r.doWithRetry(func() error {
-		cqlClientTestCon, err = r.CqlClient(newCassandraConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
+		cqlClientTestCon, err = r.CqlClient(cql.NewClusterConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
 		if err != nil {
 			return err
 		}
@@ -203,7 +203,7 @@ func (r *CassandraClusterReconciler) handleAdminRoleChange(ctx context.Context,
 }
 
 func (r *CassandraClusterReconciler) updateAdminRoleInCassandra(cc *dbv1alpha1.CassandraCluster, auth credentials) error {
-	cqlClient, err := r.CqlClient(newCassandraConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
 	if err == nil {
 		r.Log.Info("Admin role has been already updated by a different region")
 		cqlClient.CloseSession()
@@ -212,7 +212,7 @@ func (r *CassandraClusterReconciler) updateAdminRoleInCassandra(cc *dbv1alpha1.C
 	}
 
 	r.Log.Info("Establishing cql session with role " + auth.activeRole)
-	cqlClient, err = r.CqlClient(newCassandraConfig(cc, auth.activeRole, auth.activePassword, r.Log))
+	cqlClient, err = r.CqlClient(cql.NewClusterConfig(cc, auth.activeRole, auth.activePassword, r.Log))
 	if err != nil {
 		return errors.Wrap(err, "Could not log in with existing credentials")
 	}
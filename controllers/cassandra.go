@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/util"
@@ -21,16 +22,27 @@ var (
 )
 
 func (r *CassandraClusterReconciler) reconcileCassandra(ctx context.Context, cc *dbv1alpha1.CassandraCluster, restartChecksum checksumContainer) error {
+	now := time.Now()
+	allowRestart := restartAllowed(cc, now)
+	var pendingDCs []dbv1alpha1.PendingRestartDC
 	for _, dc := range cc.Spec.DCs {
 		err := r.reconcileDCService(ctx, cc, dc)
 		if err != nil {
 			return errors.Wrapf(err, "failed to reconcile dc %q", dc.Name)
 		}
 
-		err = r.reconcileDCStatefulSet(ctx, cc, dc, restartChecksum)
+		pendingRestart, err := r.reconcileDCStatefulSet(ctx, cc, dc, restartChecksum, allowRestart)
 		if err != nil {
 			return errors.Wrapf(err, "failed to reconcile dc %q", dc.Name)
 		}
+
+		if pendingRestart != nil {
+			pendingDCs = append(pendingDCs, *pendingRestart)
+		}
+	}
+
+	if err := r.reconcilePendingRestart(ctx, cc, pendingDCs, now); err != nil {
+		return err
 	}
 
 	if err := r.reconcileCassandraPodLabels(ctx, cc); err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cassandraconfig"
 	"github.com/ibm/cassandra-operator/controllers/labels"
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/util"
@@ -41,6 +42,12 @@ func (r *CassandraClusterReconciler) reconcileCassandraConfigMap(ctx context.Con
 		return errors.Wrap(err, "can't unmarshal 'cassandra.yaml'")
 	}
 
+	for key, value := range cc.Spec.Cassandra.Config.Settings() {
+		cassandraYaml[key] = value
+	}
+
+	version, versionKnown := cassandraconfig.ParseImageVersion(cc.Spec.Cassandra.Image)
+
 	// override user provided configs
 	if len(cc.Spec.Cassandra.ConfigOverrides) > 0 {
 		overrides := make(map[string]interface{})
@@ -51,11 +58,21 @@ func (r *CassandraClusterReconciler) reconcileCassandraConfigMap(ctx context.Con
 			r.Events.Warning(cc, events.CassandraConfigInvalid, errMsg)
 		} else {
 			for key, value := range overrides {
+				// the webhook can't check the settings against the default image, so they are checked here
+				if versionKnown && !cassandraconfig.Translatable(key, version) {
+					errMsg := fmt.Sprintf("Ignoring Cassandra config override: %s", cassandraconfig.CheckKey(key, version))
+					r.Log.Warn(errMsg)
+					r.Events.Warning(cc, events.CassandraConfigInvalid, errMsg)
+					continue
+				}
 				cassandraYaml[key] = value
 			}
 		}
 	}
 
+	// the default config and the typed settings use the names known before Cassandra 4.1
+	cassandraYaml = cassandraconfig.Translate(cassandraYaml, version)
+
 	if cc.Spec.Cassandra.Persistence.Enabled && cc.Spec.Cassandra.Persistence.CommitLogVolume {
 		cassandraYaml["commitlog_directory"] = cassandraCommitLogDir
 	}
@@ -112,14 +129,49 @@ func (r *CassandraClusterReconciler) reconcileCassandraConfigMap(ctx context.Con
 	restartChecksum["cassandra.yaml"] = string(cassandraYamlBytes) //to restart cassandra pods on change
 	data["cassandra.yaml"] = string(cassandraYamlBytes)
 
+	derivedOptions, useCMS := derivedJVMOptions(cc)
+	if useCMS {
+		data["jvm.options"] = withoutG1Options(data["jvm.options"])
+	}
+
+	if len(derivedOptions) > 0 {
+		data["jvm.options"] += "\n\n### OPTIONS DERIVED FROM THE CONTAINER RESOURCES\n\n\n"
+		data["jvm.options"] += strings.Join(derivedOptions, "\n")
+		data["jvm.options"] += "\n"
+	}
+
+	logging := cc.Spec.Cassandra.Logging
+	if logging != nil && logging.GCLog {
+		data["jvm.options"] += "\n\n### GC LOGGING\n\n\n"
+		data["jvm.options"] += strings.Join(gcLogOptions(cc), "\n")
+		data["jvm.options"] += "\n"
+	}
+
 	if len(cc.Spec.Cassandra.JVMOptions) > 0 {
 		data["jvm.options"] += "\n\n### OVERRIDES PROVIDED BY THE USER\n\n\n"
 		data["jvm.options"] += strings.Join(cc.Spec.Cassandra.JVMOptions, "\n")
 		data["jvm.options"] += "\n"
+	}
 
+	if data["jvm.options"] != operatorCM.Data["jvm.options"] {
 		restartChecksum["jvm.options"] = data["jvm.options"] //to restart cassandra pods on change
 	}
 
+	if logging != nil {
+		data["logback.xml"] = logbackConfig(operatorCM.Data["logback.xml"], logging, true)
+		// the levels of the loggers are applied through JMX
+		if restartConfig := logbackConfig(operatorCM.Data["logback.xml"], logging, false); restartConfig != operatorCM.Data["logback.xml"] {
+			restartChecksum["logback.xml"] = restartConfig //to restart cassandra pods on change
+		}
+	}
+
+	if cc.Spec.Cassandra.CommitLogArchiving != nil {
+		data[commitLogArchivingPropertiesFile] = commitLogArchivingProperties(cc)
+		data[commitLogArchiveScriptFile] = commitLogArchiveScript(cc)
+
+		restartChecksum[commitLogArchivingPropertiesFile] = data[commitLogArchivingPropertiesFile] //to restart cassandra pods on change
+	}
+
 	desiredCM.Data = data
 
 	if err := controllerutil.SetControllerReference(cc, desiredCM, r.Scheme); err != nil {
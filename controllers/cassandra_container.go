@@ -97,7 +97,10 @@ func cassandraContainer(cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, resta
 					Command: []string{
 						"bash",
 						"-c",
-						"source /home/cassandra/.bashrc && nodetool drain",
+						// clients and the other nodes stop sending requests to the node before it's drained.
+						// Nodes already drained by the operator during a rolling restart are not drained again.
+						"source /home/cassandra/.bashrc && if timeout 30 nodetool netstats | grep -q 'Mode: DRAINED'; then exit 0; fi; " +
+							"timeout 30 nodetool disablebinary; timeout 30 nodetool disablegossip; nodetool drain",
 					},
 				},
 			},
@@ -226,6 +229,13 @@ else
 fi`,
 	)
 
+	if cc.Spec.Cassandra.CommitLogArchiving != nil {
+		if _, restoring := commitLogRestorePointInTime(cc); !restoring {
+			// remove the commit logs left by a completed point-in-time restore
+			args = append(args, "rm -rf "+dbv1alpha1.CommitLogRestoreDir)
+		}
+	}
+
 	if cc.Spec.Encryption.Client.Enabled {
 		args = append(args,
 			"mkdir -p /home/cassandra/.cassandra/",
@@ -5,6 +5,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
+const icarusBackupVolumeName = "icarus-backups"
+
 func icarusContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 	container := v1.Container{
 		Name:            "icarus",
@@ -67,7 +69,28 @@ func icarusContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 		container.VolumeMounts = append(container.VolumeMounts, cassandraClientTLSVolumeMount())
 	}
 
+	if cc.Spec.Icarus.BackupVolume != nil {
+		container.VolumeMounts = append(container.VolumeMounts, icarusBackupVolumeMount())
+	}
+
 	container.Ports = append(container.Ports, icarusPort)
 
 	return container
 }
+
+func icarusBackupVolume(cc *dbv1alpha1.CassandraCluster) v1.Volume {
+	return v1.Volume{
+		Name: icarusBackupVolumeName,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: cc.Spec.Icarus.BackupVolume.PersistentVolumeClaim,
+			NFS:                   cc.Spec.Icarus.BackupVolume.NFS,
+		},
+	}
+}
+
+func icarusBackupVolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      icarusBackupVolumeName,
+		MountPath: dbv1alpha1.IcarusBackupVolumeMountPath,
+	}
+}
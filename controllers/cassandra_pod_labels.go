@@ -8,6 +8,8 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/util"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *CassandraClusterReconciler) reconcileCassandraPodLabels(ctx context.Context, cc *v1alpha1.CassandraCluster) error {
@@ -53,3 +55,38 @@ func (r *CassandraClusterReconciler) reconcileCassandraPodLabels(ctx context.Con
 
 	return nil
 }
+
+// reconcileCassandraPodRackLabels labels the pods with the zone of their node if zones are used as racks.
+// The pods of the list are patched in place.
+func (r *CassandraClusterReconciler) reconcileCassandraPodRackLabels(ctx context.Context, cc *v1alpha1.CassandraCluster, podList *v1.PodList, nodeList *v1.NodeList) error {
+	if !cc.Spec.Cassandra.ZonesAsRacks {
+		return nil
+	}
+
+	for i, pod := range podList.Items {
+		if len(pod.Spec.NodeName) == 0 {
+			continue
+		}
+
+		node, found := getNodeByName(nodeList.Items, pod.Spec.NodeName)
+		if !found {
+			continue
+		}
+
+		rack := node.Labels[v1.LabelTopologyZone]
+		if len(rack) == 0 || pod.Labels[v1alpha1.CassandraClusterRack] == rack {
+			continue
+		}
+
+		patch := client.MergeFrom(podList.Items[i].DeepCopy())
+		if podList.Items[i].Labels == nil {
+			podList.Items[i].Labels = make(map[string]string)
+		}
+		podList.Items[i].Labels[v1alpha1.CassandraClusterRack] = rack
+		if err := r.Patch(ctx, &podList.Items[i], patch); err != nil {
+			return errors.Wrapf(err, "can't update labels for pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/labels"
 	"github.com/ibm/cassandra-operator/controllers/names"
@@ -23,7 +24,10 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-var errDCDecommissionBlocked = errors.New("DC decommission blocked")
+var (
+	errDCDecommissionBlocked = errors.New("DC decommission blocked")
+	errDecommissionQueued    = errors.New("decommission queued until the next maintenance window")
+)
 
 func (r *CassandraClusterReconciler) reconcileCassandraScaling(ctx context.Context, cc *dbv1alpha1.CassandraCluster, podList *v1.PodList, nodesList *v1.NodeList, allDCs []dbv1alpha1.DC, adminRoleSecret *v1.Secret) (bool, error) {
 	broadcastAddresses, err := getBroadcastAddresses(cc, podList.Items, nodesList.Items)
@@ -79,6 +83,9 @@ func (r *CassandraClusterReconciler) reconcileCassandraScaling(ctx context.Conte
 		decommissionPodName := sts.Name + "-" + strconv.Itoa(int(oldReplicas)-1)
 		r.Log.Debugf("handling decommission for node %s", decommissionPodName)
 		err = r.handlePodDecommission(ctx, cc, sts, broadcastAddresses, decommissionPodName, podList)
+		if err == errDecommissionQueued {
+			continue
+		}
 		if err != nil {
 			return false, errors.Wrap(err, "failed to handle pod decommission")
 		}
@@ -91,6 +98,9 @@ func (r *CassandraClusterReconciler) reconcileCassandraScaling(ctx context.Conte
 	}
 
 	err = r.handleDCsDecommission(ctx, cc, stsToDecommissionNames, stsToDecommission, allDCs, adminRoleSecret, broadcastAddresses, podList)
+	if errors.Cause(err) == errDecommissionQueued {
+		return false, nil
+	}
 	if err != nil {
 		return false, errors.Wrap(err, "failed to decommission DCs")
 	}
@@ -106,7 +116,7 @@ func (r *CassandraClusterReconciler) handleDCsDecommission(ctx context.Context,
 	if err != nil {
 		return err
 	}
-	cqlClient, err := r.CqlClient(newCassandraConfig(cc, cassandraOperatorAdminRole, cassandraOperatorAdminPassword, r.Log))
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, cassandraOperatorAdminRole, cassandraOperatorAdminPassword, r.Log))
 	if err != nil {
 		return errors.Wrap(err, "can't create cql client")
 	}
@@ -176,7 +186,7 @@ func (r *CassandraClusterReconciler) handleDCsDecommission(ctx context.Context,
 }
 
 func (r *CassandraClusterReconciler) handlePodDecommission(ctx context.Context, cc *dbv1alpha1.CassandraCluster, sts appsv1.StatefulSet, broadcastAddresses map[string]string, decommissionPodName string, podList *v1.PodList) error {
-	jobName := "pod-decommission-" + decommissionPodName
+	jobName := names.PodDecommissionJob(decommissionPodName)
 	if r.Jobs.Exists(jobName) && r.Jobs.IsRunning(jobName) {
 		r.Log.Infof("decommission in progress, waiting to finish")
 		return nil
@@ -203,7 +213,7 @@ func (r *CassandraClusterReconciler) handlePodDecommission(ctx context.Context,
 	if err != nil {
 		r.Log.Warn("can't extract secret data")
 	}
-	nctl := r.NodectlClient(jolokiaURL(cc).String(), roleName, rolePassword, r.Log)
+	nctl := r.NodectlClient(nodectl.JolokiaURL(cc), roleName, rolePassword, r.Log)
 
 	broadcastIP := broadcastAddresses[decommissionPod.Name]
 	r.Log.Debugf("checking operation mode for node %s", decommissionPod.Name)
@@ -239,6 +249,14 @@ func (r *CassandraClusterReconciler) handlePodDecommission(ctx context.Context,
 		return nil
 	}
 
+	allowed, err := r.maintenanceAllowed(ctx, cc, dbv1alpha1.QueuedOperationDecommission, decommissionPod.Name)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errDecommissionQueued
+	}
+
 	r.Log.Infof("starting decommision of node %s/%s", decommissionPod.Namespace, decommissionPod.Name)
 	err = r.Jobs.Run(jobName, cc, func() error {
 		decommissionCtx := context.Background() //reconcile context may cancel the job sooner that needed
@@ -21,12 +21,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func (r *CassandraClusterReconciler) reconcileDCStatefulSet(ctx context.Context, cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, restartChecksum checksumContainer) error {
+// reconcileDCStatefulSet creates or updates the statefulset of the DC. If restartAllowed is false, changes of the pod template
+// are held back and returned as a pending restart, unless the operator itself changes the pod template.
+func (r *CassandraClusterReconciler) reconcileDCStatefulSet(ctx context.Context, cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, restartChecksum checksumContainer, restartAllowed bool) (*dbv1alpha1.PendingRestartDC, error) {
 	var err error
 
 	if cc.Spec.Encryption.Server.InternodeEncryption != dbv1alpha1.InternodeEncryptionNone {
 		if _, err := r.reconcileNodeTLSSecret(ctx, cc, restartChecksum, serverNode); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -34,7 +36,7 @@ func (r *CassandraClusterReconciler) reconcileDCStatefulSet(ctx context.Context,
 	if cc.Spec.Encryption.Client.Enabled {
 		clientTLSSecret, err = r.reconcileNodeTLSSecret(ctx, cc, restartChecksum, clientNode)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -46,20 +48,22 @@ func (r *CassandraClusterReconciler) reconcileDCStatefulSet(ctx context.Context,
 	desiredSts := cassandraStatefulSet(cc, dc, restartChecksum, clientTLSSecret)
 
 	if err = controllerutil.SetControllerReference(cc, desiredSts, r.Scheme); err != nil {
-		return errors.Wrap(err, "Cannot set controller reference")
+		return nil, errors.Wrap(err, "Cannot set controller reference")
 	}
 
+	var pendingRestart *dbv1alpha1.PendingRestartDC
 	actualSts := &appsv1.StatefulSet{}
 	err = r.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, dc.Name), Namespace: cc.Namespace}, actualSts)
 	if err != nil && apierrors.IsNotFound(err) {
 		r.Log.Infof("Creating cassandra statefulset for DC %q", dc.Name)
 		err = r.Create(ctx, desiredSts)
 		if err != nil {
-			return errors.Wrap(err, "Failed to create statefulset")
+			return nil, errors.Wrap(err, "Failed to create statefulset")
 		}
 	} else if err != nil {
-		return errors.Wrap(err, "Failed to get statefulset")
+		return nil, errors.Wrap(err, "Failed to get statefulset")
 	} else {
+		restartChecksums := desiredSts.Annotations[annotationRestartChecksums]
 		desiredSts.Annotations = actualSts.Annotations
 		// the pod selector is immutable once set, so always enforce the same as existing
 		desiredSts.Spec.Selector = actualSts.Spec.Selector
@@ -68,20 +72,37 @@ func (r *CassandraClusterReconciler) reconcileDCStatefulSet(ctx context.Context,
 		desiredSts.Spec.Template.Annotations = util.MergeMap(actualSts.Spec.Template.Annotations, desiredSts.Spec.Template.Annotations)
 		// scaling is handled by the scaling logic
 		desiredSts.Spec.Replicas = actualSts.Spec.Replicas
+
+		// the statefulset restarts all pods if the pod template changes
+		heldSts := desiredSts.DeepCopy()
+		heldSts.Spec.Template = actualSts.Spec.Template
+		if !restartAllowed && !operatorRestart(cc, actualSts) && !compare.EqualStatefulSet(heldSts, desiredSts) {
+			pendingRestart = &dbv1alpha1.PendingRestartDC{
+				Name:    dc.Name,
+				Changes: changedChecksums(actualSts.Annotations[annotationRestartChecksums], restartChecksums),
+				Diff:    truncateDiff(compare.DiffStatefulSet(heldSts, desiredSts)),
+			}
+			r.Log.Infof("Restart of DC %q is held back by the restart policy", dc.Name)
+			desiredSts = heldSts
+		} else {
+			desiredSts.Annotations = statefulSetAnnotations(cc, actualSts.Annotations, restartChecksums)
+		}
+
 		if !compare.EqualStatefulSet(desiredSts, actualSts) {
 			r.Log.Info("Updating cassandra statefulset")
 			r.Log.Debug(compare.DiffStatefulSet(actualSts, desiredSts))
 			actualSts.Spec = desiredSts.Spec
 			actualSts.Labels = desiredSts.Labels
+			actualSts.Annotations = desiredSts.Annotations
 			if err = r.Update(ctx, actualSts); err != nil {
-				return errors.Wrap(err, "failed to update statefulset")
+				return nil, errors.Wrap(err, "failed to update statefulset")
 			}
 		} else {
 			r.Log.Debugf("No updates to cassandra statefulset")
 		}
 	}
 
-	return nil
+	return pendingRestart, nil
 }
 
 func cassandraStatefulSet(cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, restartChecksum checksumContainer, clientTLSSecret *v1.Secret) *appsv1.StatefulSet {
@@ -94,9 +115,10 @@ func cassandraStatefulSet(cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, res
 	}
 	desiredSts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      names.DC(cc.Name, dc.Name),
-			Namespace: cc.Namespace,
-			Labels:    stsLabels,
+			Name:        names.DC(cc.Name, dc.Name),
+			Namespace:   cc.Namespace,
+			Labels:      stsLabels,
+			Annotations: statefulSetAnnotations(cc, nil, restartChecksum.checksums()),
 		},
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName:         names.DCService(cc.Name, dc.Name),
@@ -168,6 +190,10 @@ func cassandraStatefulSet(cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, res
 		desiredSts.Spec.Template.Spec.Volumes = append(desiredSts.Spec.Template.Spec.Volumes, cassandraClientTLSVolume(cc))
 	}
 
+	if cc.Spec.Icarus.BackupVolume != nil {
+		desiredSts.Spec.Template.Spec.Volumes = append(desiredSts.Spec.Template.Spec.Volumes, icarusBackupVolume(cc))
+	}
+
 	if cc.Spec.TopologySpreadByZone != nil && *cc.Spec.TopologySpreadByZone {
 		desiredSts.Spec.Template.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{
 			{
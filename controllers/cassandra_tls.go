@@ -323,6 +323,14 @@ func (r *CassandraClusterReconciler) handleNodeSecret(ctx context.Context, cc *d
 	}
 
 	if actualTLSCA.Annotations[dbv1alpha1.CassandraClusterChecksum] != util.Sha1(fmt.Sprintf("%v", actualTLSCA.Data)) {
+		allowed, err := r.maintenanceAllowed(ctx, cc, dbv1alpha1.QueuedOperationCertificateRotation, nodeTLSSecret.Name)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return nil
+		}
+
 		r.Log.Infof("TLS CA Secret data has changed `%s`. Applying new config to the cluster.", actualTLSCA.Name)
 
 		desiredTLSNodeSecret, err := genNodeSecret(cc, nodeTLSSecret, caTLSSecret, actualTLSCA)
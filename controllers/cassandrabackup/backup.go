@@ -10,7 +10,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, ic icarus.Icarus, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, ic icarus.Icarus, cb *v1alpha1.CassandraBackup,
+	cc *v1alpha1.CassandraCluster, coordinatorPod string) (ctrl.Result, error) {
 	existingBackups, err := ic.Backups(ctx)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -25,10 +26,12 @@ func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, ic icar
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{}, r.reconcileFailedBackup(ctx, ic, icarusBackup, cc, cb)
+		return ctrl.Result{}, r.reconcileFailedBackup(ctx, ic, icarusBackup, cc, cb, coordinatorPod)
 	}
 
-	// create if not found or found, but it's a new backup with the same tag (e.g. incremental backup)
+	// create if not found or found, but it's a new backup with the same tag (e.g. incremental backup).
+	// If the coordinator has changed, the new coordinator doesn't know about the running backup,
+	// so the backup is sent again. Files that have been uploaded already are skipped by Icarus.
 	if !relatedIcarusBackupFound || (relatedIcarusBackupFound && len(cb.Status.State) == 0) {
 		icarusBackup, err = ic.Backup(ctx, createBackupRequest(cc, cb))
 		if err != nil {
@@ -38,7 +41,12 @@ func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, ic icar
 		r.Log.Debugf("Backup request sent")
 	}
 
-	err = r.reconcileStatus(ctx, cb, icarusBackup)
+	nodes, err := r.nodesProgress(ctx, cc, cb, icarusBackup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.reconcileStatus(ctx, cb, coordinatorPod, icarusBackup, nodes)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -51,13 +59,9 @@ func (r *CassandraBackupReconciler) findRelatedBackup(cb *v1alpha1.CassandraBack
 		return icarus.Backup{}, false
 	}
 
-	tagName := cb.Spec.SnapshotTag
-	if len(tagName) == 0 {
-		tagName = cb.Name
-	}
 	var relatedBackups []icarus.Backup //there may be several related backups if we retried a failed one
 	for _, item := range icarusBackups {
-		if !strings.Contains(item.SnapshotTag, tagName+"-"+item.SchemaVersion) && tagName != item.SnapshotTag {
+		if !snapshotTagMatches(cb, item) {
 			continue
 		}
 
@@ -103,7 +107,7 @@ func (r *CassandraBackupReconciler) findRelatedBackup(cb *v1alpha1.CassandraBack
 }
 
 func (r *CassandraBackupReconciler) reconcileFailedBackup(ctx context.Context, ic icarus.Icarus, existingBackup icarus.Backup,
-	cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup) error {
+	cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup, coordinatorPod string) error {
 	newBackupRequest := createBackupRequest(cc, cb)
 	if r.backupConfigChanged(existingBackup, newBackupRequest) {
 		r.Log.Info("Detected a configuration change for backup %s/%s, sending a new backup request", cb.Namespace, cb.Name)
@@ -113,7 +117,7 @@ func (r *CassandraBackupReconciler) reconcileFailedBackup(ctx context.Context, i
 		}
 
 		cb.Status = v1alpha1.CassandraBackupStatus{} // reset status since we're restarting backup in Icarus
-		err = r.reconcileStatus(ctx, cb, icarusBackup)
+		err = r.reconcileStatus(ctx, cb, coordinatorPod, icarusBackup, nil)
 		if err != nil {
 			return err
 		}
@@ -124,3 +128,14 @@ func (r *CassandraBackupReconciler) reconcileFailedBackup(ctx context.Context, i
 
 	return nil
 }
+
+// snapshotTagMatches returns true if the Icarus backup has been created with the snapshot tag of the CassandraBackup.
+// Icarus appends the schema version and the time of the backup to the tag.
+func snapshotTagMatches(cb *v1alpha1.CassandraBackup, icarusBackup icarus.Backup) bool {
+	tagName := cb.Spec.SnapshotTag
+	if len(tagName) == 0 {
+		tagName = cb.Name
+	}
+
+	return strings.Contains(icarusBackup.SnapshotTag, tagName+"-"+icarusBackup.SchemaVersion) || tagName == icarusBackup.SnapshotTag
+}
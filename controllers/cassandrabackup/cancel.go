@@ -0,0 +1,182 @@
+package cassandrabackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// CancelFinalizer blocks the removal of a running CassandraBackup until its Icarus operations are cancelled
+const CancelFinalizer = "db.ibm.com/cancel-backup"
+
+// reconcileCancel cancels the backup operations on all nodes. The backup stays in the CANCELLING state until every node
+// has confirmed that its operations have stopped, including the nodes that are not ready. The snapshots taken for the backup
+// are then cleared on all nodes and the backup is marked as cancelled.
+func (r *CassandraBackupReconciler) reconcileCancel(ctx context.Context, cb *v1alpha1.CassandraBackup) (ctrl.Result, error) {
+	if cb.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+		// the snapshots are taken by the CSI driver and can't be stopped, they are kept or deleted according to the deletion policy
+		return ctrl.Result{}, r.completeCancel(ctx, cb)
+	}
+
+	cc := &v1alpha1.CassandraCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: cb.Spec.CassandraCluster, Namespace: cb.Namespace}, cc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			r.Log.Infof("Cluster %s of backup %s/%s not found, there's nothing to cancel", cb.Spec.CassandraCluster, cb.Namespace, cb.Name)
+			return ctrl.Result{}, r.completeCancel(ctx, cb)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err = r.setCancelling(ctx, cb); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	snapshotTags := make(map[string]bool)
+	if len(cb.Status.SnapshotTag) != 0 {
+		snapshotTags[cb.Status.SnapshotTag] = true
+	}
+
+	running := false
+	for i, pod := range pods {
+		ic := r.IcarusClient(coordinator.URL(cc, &pods[i]))
+		icarusBackups, err := ic.Backups(ctx)
+		if err != nil {
+			r.Log.Warnf("Failed to get backups from pod %s, waiting for the pod to confirm the cancellation: %s", pod.Name, err.Error())
+			running = true
+			continue
+		}
+
+		for _, icarusBackup := range icarusBackups {
+			if !backupOperation(cb, icarusBackup) {
+				continue
+			}
+
+			snapshotTags[icarusBackup.SnapshotTag] = true
+			if icarus.Finished(icarusBackup.State) {
+				continue
+			}
+
+			running = true
+			r.Log.Infof("Cancelling backup operation %s on pod %s", icarusBackup.ID, pod.Name)
+			err = ic.Cancel(ctx, icarusBackup.ID)
+			if err != nil {
+				r.Log.Warnf("Failed to cancel backup operation %s on pod %s: %s", icarusBackup.ID, pod.Name, err.Error())
+			}
+		}
+	}
+
+	if running {
+		r.Log.Infof("Waiting for the operations of backup %s/%s to stop", cb.Namespace, cb.Name)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	cleared, err := r.clearSnapshots(ctx, cc, pods, snapshotTags)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !cleared {
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	return ctrl.Result{}, r.completeCancel(ctx, cb)
+}
+
+// setCancelling moves the backup to the CANCELLING state
+func (r *CassandraBackupReconciler) setCancelling(ctx context.Context, cb *v1alpha1.CassandraBackup) error {
+	if cb.Status.State == icarus.StateCancelling {
+		return nil
+	}
+
+	r.Log.Infof("Cancelling backup %s/%s", cb.Namespace, cb.Name)
+	cb.Status.State = icarus.StateCancelling
+	icarus.SetConditions(&cb.Status.Conditions, "Backup", cb.Status.State, cb.Generation)
+	return errors.Wrap(r.Status().Update(ctx, cb), "failed to update backup status")
+}
+
+// clearSnapshots removes the snapshots of the cancelled backup from all nodes, as Icarus leaves them behind if an operation
+// is stopped before it could clean up. Returns false if a snapshot couldn't be cleared.
+func (r *CassandraBackupReconciler) clearSnapshots(ctx context.Context, cc *v1alpha1.CassandraCluster, pods []v1.Pod, snapshotTags map[string]bool) (bool, error) {
+	if len(snapshotTags) == 0 {
+		return true, nil
+	}
+
+	nctl, err := r.nodectlClient(ctx, cc)
+	if err != nil {
+		return false, err
+	}
+
+	tags := make([]string, 0, len(snapshotTags))
+	for tag := range snapshotTags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	cleared := true
+	for _, pod := range pods {
+		for _, tag := range tags {
+			r.Log.Debugf("Clearing snapshot %s of node %s", tag, pod.Name)
+			err = nctl.ClearSnapshot(ctx, pod.Status.PodIP, tag)
+			if err != nil {
+				r.Log.Warnf("Failed to clear snapshot %s of node %s, trying again in %s: %s", tag, pod.Name, r.Cfg.RetryDelay, err.Error())
+				cleared = false
+			}
+		}
+	}
+
+	return cleared, nil
+}
+
+// completeCancel moves the backup to the CANCELLED state and releases the cancel finalizer
+func (r *CassandraBackupReconciler) completeCancel(ctx context.Context, cb *v1alpha1.CassandraBackup) error {
+	if cb.Status.State != icarus.StateCancelled {
+		cb.Status.State = icarus.StateCancelled
+		cb.Status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		icarus.SetConditions(&cb.Status.Conditions, "Backup", cb.Status.State, cb.Generation)
+		err := r.Status().Update(ctx, cb)
+		if err != nil {
+			return errors.Wrap(err, "failed to update backup status")
+		}
+
+		msg := fmt.Sprintf("Backup %s cancelled", cb.Name)
+		r.Log.Info(msg)
+		r.Events.Normal(cb, events.EventBackupCancelled, msg)
+	}
+
+	if !controllerutil.ContainsFinalizer(cb, CancelFinalizer) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(cb, CancelFinalizer)
+	return errors.Wrap(r.Update(ctx, cb), "failed to remove finalizer")
+}
+
+// backupOperation returns true if the Icarus operation belongs to the backup, either as the coordinator or as a node operation
+func backupOperation(cb *v1alpha1.CassandraBackup, icarusBackup icarus.Backup) bool {
+	if len(cb.Status.SnapshotTag) != 0 && icarusBackup.SnapshotTag == cb.Status.SnapshotTag {
+		return true
+	}
+
+	return snapshotTagMatches(cb, icarusBackup)
+}
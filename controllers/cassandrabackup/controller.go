@@ -11,9 +11,11 @@ import (
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
 	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
-	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +24,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -29,15 +32,19 @@ import (
 // CassandraBackupReconciler reconciles a CassandraCluster object
 type CassandraBackupReconciler struct {
 	client.Client
-	Log          *zap.SugaredLogger
-	Scheme       *runtime.Scheme
-	Cfg          config.Config
-	Events       *events.EventRecorder
-	IcarusClient func(coordinatorPodURL string) icarus.Icarus
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	IcarusClient  func(coordinatorPodURL string) icarus.Icarus
+	NodectlClient func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl
+	StorageClient func(secret *v1.Secret, insecure bool) storage.Storage
 }
 
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
 
 func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	cb := &v1alpha1.CassandraBackup{}
@@ -49,11 +56,32 @@ func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	if cb.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(cb, CancelFinalizer) {
+			return r.handleReconcileErr(r.reconcileCancel(ctx, cb))
+		}
+		return r.reconcileDeletion(ctx, cb)
+	}
+
+	err = r.reconcileFinalizer(ctx, cb)
+	if err != nil {
+		return r.handleReconcileErr(ctrl.Result{}, err)
+	}
+
 	if cb.Status.State == icarus.StateCompleted {
 		r.Log.Debugf("Backup %v is compeleted", cb.Name)
+		return r.reconcileExpiration(ctx, cb)
+	}
+
+	if cb.Status.State == icarus.StateCancelled {
+		r.Log.Debugf("Backup %v is cancelled", cb.Name)
 		return ctrl.Result{}, nil
 	}
 
+	if cb.Spec.Cancel && !icarus.Finished(cb.Status.State) {
+		return r.handleReconcileErr(r.reconcileCancel(ctx, cb))
+	}
+
 	cc := &v1alpha1.CassandraCluster{}
 	err = r.Get(ctx, types.NamespacedName{Name: cb.Spec.CassandraCluster, Namespace: cb.Namespace}, cc)
 	if err != nil {
@@ -71,35 +99,90 @@ func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	storageCredentials := &v1.Secret{}
-	err = r.Get(ctx, types.NamespacedName{Name: cb.Spec.SecretName, Namespace: cb.Namespace}, storageCredentials)
-	if err != nil {
-		if kerrors.IsNotFound(err) {
-			errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Storage credentials secret %q not found.", cb.Spec.CassandraCluster, cb.Spec.SecretName)
+	if cb.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+		return r.handleReconcileErr(r.reconcileVolumeSnapshotBackup(ctx, cb, cc))
+	}
+
+	if cb.StorageProvider() == v1alpha1.StorageProviderFile && cc.Spec.Icarus.BackupVolume == nil {
+		errMsg := fmt.Sprintf("Failed to create backup for cluster %q. The file storage provider requires .spec.icarus.backupVolume to be set in the cluster.", cb.Spec.CassandraCluster)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventBackupVolumeNotConfigured, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	var storageCredentials *v1.Secret
+	if v1alpha1.StorageSecretRequired(cb.StorageProvider()) {
+		storageCredentials = &v1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Name: cb.Spec.SecretName, Namespace: cb.Namespace}, storageCredentials)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Storage credentials secret %q not found.", cb.Spec.CassandraCluster, cb.Spec.SecretName)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		err = v1alpha1.ValidateStorageSecret(r.Log, storageCredentials, cb.StorageProvider())
+		if err != nil {
+			errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", cb.Spec.SecretName, err.Error())
 			r.Log.Warn(errMsg)
 			r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
 			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 		}
+	}
+
+	if cb.Spec.Encryption != nil {
+		encryptionSecret := &v1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Name: cb.Spec.Encryption.SecretName, Namespace: cb.Namespace}, encryptionSecret)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Encryption secret %q not found.", cb.Spec.CassandraCluster, cb.Spec.Encryption.SecretName)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cb, events.EventEncryptionSecretNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+
+			return ctrl.Result{}, err
+		}
 
-		return ctrl.Result{}, err
+		err = v1alpha1.ValidateEncryptionSecret(encryptionSecret, cb.Spec.Encryption)
+		if err != nil {
+			errMsg := fmt.Sprintf("Encryption secret %q is invalid: %s", cb.Spec.Encryption.SecretName, err.Error())
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cb, events.EventEncryptionSecretInvalid, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+	}
+
+	if storageCredentials != nil {
+		verified, err := r.verifyStorage(ctx, cb, storageCredentials)
+		if err != nil {
+			return r.handleReconcileErr(ctrl.Result{}, err)
+		}
+
+		if !verified {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
 	}
 
-	err = v1alpha1.ValidateStorageSecret(r.Log, storageCredentials, cb.StorageProvider())
+	coordinatorPod, err := r.coordinatorPod(ctx, cb, cc)
 	if err != nil {
-		errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", cb.Spec.SecretName, err.Error())
-		r.Log.Warn(errMsg)
-		r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
+		return ctrl.Result{}, err
+	}
+
+	if coordinatorPod == nil {
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	dcName := cc.Spec.DCs[0].Name
-	svc := names.DC(cc.Name, dcName)
-	//always use the same pod as the coordinator as only that pod has the global request info
-	coordinatorPodURL := fmt.Sprintf("http://%s-0.%s.%s.svc.cluster.local:%d", svc, svc, cc.Namespace, v1alpha1.IcarusPort)
+	ic := r.IcarusClient(coordinator.URL(cc, coordinatorPod))
 
-	ic := r.IcarusClient(coordinatorPodURL)
+	return r.handleReconcileErr(r.reconcileBackup(ctx, ic, cb, cc, coordinatorPod.Name))
+}
 
-	res, err := r.reconcileBackup(ctx, ic, cb, cc)
+func (r *CassandraBackupReconciler) handleReconcileErr(res ctrl.Result, err error) (ctrl.Result, error) {
 	if err != nil {
 		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
 			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
@@ -113,6 +196,29 @@ func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	return res, nil
 }
 
+// coordinatorPod returns the pod to send the backup requests to. Returns nil if no pod is available.
+func (r *CassandraBackupReconciler) coordinatorPod(ctx context.Context, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (*v1.Pod, error) {
+	coordinatorPod, err := coordinator.Pod(ctx, r.Client, cc, cb.Status.Coordinator)
+	if err != nil {
+		return nil, err
+	}
+
+	if coordinatorPod == nil {
+		errMsg := fmt.Sprintf("No ready Cassandra pod is available to coordinate the backup. Trying again in %s...", r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventCoordinatorNotAvailable, errMsg)
+		return nil, nil
+	}
+
+	if len(cb.Status.Coordinator) != 0 && cb.Status.Coordinator != coordinatorPod.Name {
+		msg := fmt.Sprintf("Coordinator %s is not available, moving the backup to %s", cb.Status.Coordinator, coordinatorPod.Name)
+		r.Log.Info(msg)
+		r.Events.Normal(cb, events.EventCoordinatorChanged, msg)
+	}
+
+	return coordinatorPod, nil
+}
+
 func SetupCassandraBackupReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		Named("cassandrabackup").
@@ -0,0 +1,182 @@
+package cassandrabackup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BackupFinalizer blocks the removal of a CassandraBackup until its data is removed from the storage
+const BackupFinalizer = "db.ibm.com/remove-backup"
+
+// reconcileFinalizer adds the finalizers needed by the backup and removes the ones that are not needed anymore.
+// The cancel finalizer is kept while the backup is running, so that deleting the backup cancels it.
+func (r *CassandraBackupReconciler) reconcileFinalizer(ctx context.Context, cb *v1alpha1.CassandraBackup) error {
+	finalizers := []struct {
+		name     string
+		required bool
+	}{
+		{name: BackupFinalizer, required: cb.Spec.DeletionPolicy == v1alpha1.DeletionPolicyDelete},
+		{name: CancelFinalizer, required: !icarus.Finished(cb.Status.State)},
+	}
+
+	changed := false
+	for _, finalizer := range finalizers {
+		hasFinalizer := controllerutil.ContainsFinalizer(cb, finalizer.name)
+		if finalizer.required && !hasFinalizer {
+			controllerutil.AddFinalizer(cb, finalizer.name)
+			changed = true
+		}
+
+		if !finalizer.required && hasFinalizer {
+			controllerutil.RemoveFinalizer(cb, finalizer.name)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return errors.Wrap(r.Update(ctx, cb), "failed to update finalizers")
+}
+
+// reconcileExpiration deletes a completed backup once it's older than maxAge
+func (r *CassandraBackupReconciler) reconcileExpiration(ctx context.Context, cb *v1alpha1.CassandraBackup) (ctrl.Result, error) {
+	if cb.Spec.MaxAge == nil {
+		return ctrl.Result{}, nil
+	}
+
+	expiresIn := time.Until(cb.CreationTimestamp.Add(cb.Spec.MaxAge.Duration))
+	if expiresIn > 0 {
+		return ctrl.Result{RequeueAfter: expiresIn}, nil
+	}
+
+	r.Log.Infof("Backup %s/%s is older than %s, deleting it", cb.Namespace, cb.Name, cb.Spec.MaxAge.Duration)
+	err := r.Delete(ctx, cb)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return ctrl.Result{}, errors.Wrap(err, "failed to delete expired backup")
+	}
+
+	r.Events.Normal(cb, events.EventBackupExpired, fmt.Sprintf("backup is older than %s", cb.Spec.MaxAge.Duration))
+	return ctrl.Result{}, nil
+}
+
+// reconcileDeletion removes the backup from the storage before the finalizer is removed
+func (r *CassandraBackupReconciler) reconcileDeletion(ctx context.Context, cb *v1alpha1.CassandraBackup) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cb, BackupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if cb.Spec.DeletionPolicy == v1alpha1.DeletionPolicyDelete && cb.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+		err := r.deleteVolumeSnapshots(ctx, cb)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.Log.Infof("Volume snapshots of backup %s/%s have been deleted", cb.Namespace, cb.Name)
+		r.Events.Normal(cb, events.EventBackupRemoved, fmt.Sprintf("%d volume snapshots deleted", len(cb.Status.VolumeSnapshots)))
+		return ctrl.Result{}, r.removeFinalizer(ctx, cb)
+	}
+
+	if cb.Spec.DeletionPolicy != v1alpha1.DeletionPolicyDelete || len(cb.Status.SnapshotTag) == 0 {
+		r.Log.Infof("Backup %s/%s has no data to remove from the storage", cb.Namespace, cb.Name)
+		return ctrl.Result{}, r.removeFinalizer(ctx, cb)
+	}
+
+	cc := &v1alpha1.CassandraCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: cb.Spec.CassandraCluster, Namespace: cb.Namespace}, cc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("Can't remove backup %q from the storage. Cluster %q not found. The backup data is retained.", cb.Status.SnapshotTag, cb.Spec.CassandraCluster)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cb, events.EventBackupRemovalFailed, errMsg)
+			return ctrl.Result{}, r.removeFinalizer(ctx, cb)
+		}
+		return ctrl.Result{}, err
+	}
+
+	coordinatorPod, err := r.coordinatorPod(ctx, cb, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if coordinatorPod == nil {
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if cb.Status.Coordinator != coordinatorPod.Name {
+		cb.Status.Coordinator = coordinatorPod.Name
+		err = r.Status().Update(ctx, cb)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to update coordinator")
+		}
+	}
+
+	ic := r.IcarusClient(coordinator.URL(cc, coordinatorPod))
+	removals, err := ic.RemoveBackups(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	removal, found := findRelatedRemoval(cb, removals)
+	if found && removal.State == icarus.StateCompleted {
+		r.Log.Infof("Backup %s/%s has been removed from the storage", cb.Namespace, cb.Name)
+		r.Events.Normal(cb, events.EventBackupRemoved, fmt.Sprintf("backup %q removed from the storage", cb.Status.SnapshotTag))
+		return ctrl.Result{}, r.removeFinalizer(ctx, cb)
+	}
+
+	if found && removal.State == icarus.StateFailed {
+		errMsg := fmt.Sprintf("Failed to remove backup %q from the storage: %v. Retrying...", cb.Status.SnapshotTag, removal.Errors)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventBackupRemovalFailed, errMsg)
+	}
+
+	if !found || removal.State == icarus.StateFailed {
+		_, err = ic.RemoveBackup(ctx, createRemoveBackupRequest(cc, cb))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.Log.Debugf("Remove backup request sent")
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+func (r *CassandraBackupReconciler) removeFinalizer(ctx context.Context, cb *v1alpha1.CassandraBackup) error {
+	controllerutil.RemoveFinalizer(cb, BackupFinalizer)
+	return errors.Wrap(r.Update(ctx, cb), "failed to remove finalizer")
+}
+
+// findRelatedRemoval returns the most recent remove request for the backup
+func findRelatedRemoval(cb *v1alpha1.CassandraBackup, removals []icarus.RemoveBackup) (icarus.RemoveBackup, bool) {
+	var relatedRemoval icarus.RemoveBackup
+	found := false
+	for _, removal := range removals {
+		if removal.BackupName != cb.Status.SnapshotTag || !removal.GlobalRequest {
+			continue
+		}
+
+		if found && removal.CreationTime < relatedRemoval.CreationTime {
+			continue
+		}
+
+		relatedRemoval = removal
+		found = true
+	}
+
+	return relatedRemoval, found
+}
@@ -10,12 +10,7 @@ import (
 )
 
 func createBackupRequest(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup) icarus.BackupRequest {
-	storageLocation := backup.Spec.StorageLocation
-	if storageLocation[len(storageLocation):] != "/" {
-		storageLocation += "/"
-	}
-
-	storageLocation = fmt.Sprintf("%s%s/%s/1", storageLocation, cc.Name, cc.Spec.DCs[0].Name)
+	storageLocation := backupStorageLocation(cc, backup)
 	tagName := backup.Spec.SnapshotTag
 	if len(tagName) == 0 {
 		tagName = backup.Name
@@ -38,6 +33,7 @@ func createBackupRequest(cc *v1alpha1.CassandraCluster, backup *v1alpha1.Cassand
 		CreateMissingBucket:    backup.Spec.CreateMissingBucket,
 		SkipBucketVerification: backup.Spec.SkipBucketVerification,
 		SkipRefreshing:         backup.Spec.SkipRefreshing,
+		Encryption:             icarus.NewEncryption(backup.Spec.Encryption),
 		Retry: icarus.Retry{
 			Interval:    backup.Spec.Retry.Interval,
 			Strategy:    backup.Spec.Retry.Strategy,
@@ -80,6 +76,29 @@ func createBackupRequest(cc *v1alpha1.CassandraCluster, backup *v1alpha1.Cassand
 	return backupRequest
 }
 
+func createRemoveBackupRequest(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup) icarus.RemoveBackupRequest {
+	return icarus.RemoveBackupRequest{
+		Type:                   "remove-backup",
+		StorageLocation:        backupStorageLocation(cc, backup),
+		BackupName:             backup.Status.SnapshotTag,
+		GlobalRequest:          true,
+		ResolveNodes:           true,
+		K8sNamespace:           backup.Namespace,
+		K8sSecretName:          backup.Spec.SecretName,
+		Insecure:               backup.Spec.Insecure,
+		SkipBucketVerification: backup.Spec.SkipBucketVerification,
+	}
+}
+
+func backupStorageLocation(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup) string {
+	storageLocation := v1alpha1.IcarusStorageLocation(backup.Spec.StorageLocation)
+	if storageLocation[len(storageLocation):] != "/" {
+		storageLocation += "/"
+	}
+
+	return fmt.Sprintf("%s%s/%s/1", storageLocation, cc.Name, cc.Spec.DCs[0].Name)
+}
+
 func (r *CassandraBackupReconciler) backupConfigChanged(existingBackup icarus.Backup, backupReq icarus.BackupRequest) bool {
 	oldReq := icarus.BackupRequest{
 		Type:                   "backup",
@@ -100,6 +119,7 @@ func (r *CassandraBackupReconciler) backupConfigChanged(existingBackup icarus.Ba
 		CreateMissingBucket:    existingBackup.CreateMissingBucket,
 		SkipRefreshing:         existingBackup.SkipRefreshing,
 		SkipBucketVerification: existingBackup.SkipBucketVerification,
+		Encryption:             existingBackup.Encryption,
 		Retry: icarus.Retry{
 			Interval:    existingBackup.Retry.Interval,
 			MaxAttempts: existingBackup.Retry.MaxAttempts,
@@ -0,0 +1,98 @@
+package cassandrabackup
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+)
+
+// nodesProgress returns the state of the backup on each node.
+// Nodes that are not ready or can't be reached keep their last known state.
+func (r *CassandraBackupReconciler) nodesProgress(ctx context.Context, cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup,
+	icarusBackup icarus.Backup) ([]v1alpha1.NodeProgress, error) {
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	lastKnownNodes := make(map[string]v1alpha1.NodeProgress, len(cb.Status.Nodes))
+	for _, node := range cb.Status.Nodes {
+		lastKnownNodes[node.Name] = node
+	}
+
+	var nodePods []v1.Pod
+	for _, pod := range pods {
+		if len(cb.Spec.DC) == 0 || pod.Labels[v1alpha1.CassandraClusterDC] == cb.Spec.DC {
+			nodePods = append(nodePods, pod)
+		}
+	}
+
+	// the nodes are queried concurrently, each request is bounded by a timeout
+	nodeBackups := make([][]icarus.Backup, len(nodePods))
+	var wg sync.WaitGroup
+	for i := range nodePods {
+		if !coordinator.Ready(nodePods[i]) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reqCtx, cancel := context.WithTimeout(ctx, coordinator.NodeRequestTimeout)
+			defer cancel()
+			backups, err := r.IcarusClient(coordinator.URL(cc, &nodePods[i])).Backups(reqCtx)
+			if err != nil {
+				r.Log.Debugf("Failed to get backup progress from pod %s: %s", nodePods[i].Name, err.Error())
+				return
+			}
+			nodeBackups[i] = backups
+		}(i)
+	}
+	wg.Wait()
+
+	var nodes []v1alpha1.NodeProgress
+	for i, pod := range nodePods {
+		node, nodeKnown := lastKnownNodes[pod.Name]
+		if nodeBackup, found := findNodeBackup(nodeBackups[i], icarusBackup.SnapshotTag); found {
+			node = v1alpha1.NodeProgress{
+				Name:             pod.Name,
+				State:            nodeBackup.State,
+				Progress:         int(nodeBackup.Progress * 100),
+				StartTime:        icarus.ParseTime(nodeBackup.StartTime),
+				CompletionTime:   icarus.ParseTime(nodeBackup.CompletionTime),
+				TransferredBytes: nodeBackup.TransferredBytes,
+				TotalBytes:       nodeBackup.TotalBytes,
+			}
+			nodeKnown = true
+		}
+
+		if nodeKnown {
+			nodes = append(nodes, node)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes, nil
+}
+
+// findNodeBackup returns the local backup of a node that was started by the coordinator
+func findNodeBackup(icarusBackups []icarus.Backup, snapshotTag string) (icarus.Backup, bool) {
+	for i, item := range icarusBackups {
+		if item.GlobalRequest || item.SnapshotTag != snapshotTag {
+			continue
+		}
+
+		return icarusBackups[i], true
+	}
+
+	return icarus.Backup{}, false
+}
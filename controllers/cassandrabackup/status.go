@@ -8,10 +8,22 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/icarus"
 )
 
-func (r *CassandraBackupReconciler) reconcileStatus(ctx context.Context, cb *v1alpha1.CassandraBackup, relatedIcarusBackup icarus.Backup) error {
+func (r *CassandraBackupReconciler) reconcileStatus(ctx context.Context, cb *v1alpha1.CassandraBackup, coordinatorPod string,
+	relatedIcarusBackup icarus.Backup, nodes []v1alpha1.NodeProgress) error {
 	backupStatus := cb.DeepCopy()
+	backupStatus.Status.Coordinator = coordinatorPod
 	//found, update state
 	backupStatus.Status.Progress = int(relatedIcarusBackup.Progress * 100)
+	backupStatus.Status.SnapshotTag = relatedIcarusBackup.SnapshotTag
+	backupStatus.Status.SchemaVersion = relatedIcarusBackup.SchemaVersion
+	backupStatus.Status.StartTime = icarus.ParseTime(relatedIcarusBackup.StartTime)
+	backupStatus.Status.CompletionTime = icarus.ParseTime(relatedIcarusBackup.CompletionTime)
+	backupStatus.Status.Nodes = nodes
+	backupStatus.Status.UploadedBytes, backupStatus.Status.TotalBytes = 0, 0
+	for _, node := range nodes {
+		backupStatus.Status.UploadedBytes += node.TransferredBytes
+		backupStatus.Status.TotalBytes += node.TotalBytes
+	}
 	if cb.Status.State != relatedIcarusBackup.State {
 		backupStatus.Status.State = relatedIcarusBackup.State
 		if relatedIcarusBackup.State == icarus.StateFailed {
@@ -27,6 +39,8 @@ func (r *CassandraBackupReconciler) reconcileStatus(ctx context.Context, cb *v1a
 		}
 	}
 
+	icarus.SetConditions(&backupStatus.Status.Conditions, "Backup", backupStatus.Status.State, cb.Generation)
+
 	if !cmp.Equal(cb.Status, backupStatus.Status) {
 		r.Log.Info("Updating backup status")
 		r.Log.Debugf(cmp.Diff(cb.Status, backupStatus.Status))
@@ -0,0 +1,52 @@
+package cassandrabackup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+)
+
+// verifyStorage checks the storage credentials against the bucket before the backup is started.
+// Returns false if the storage provider rejected the request.
+func (r *CassandraBackupReconciler) verifyStorage(ctx context.Context, cb *v1alpha1.CassandraBackup, storageCredentials *v1.Secret) (bool, error) {
+	if cb.Spec.SkipBucketVerification || storage.Verified(cb.Status.Conditions, cb.Generation) {
+		return true, nil
+	}
+
+	err := r.StorageClient(storageCredentials, cb.Spec.Insecure).Verify(ctx, cb.StorageProvider(), cb.Spec.StorageLocation)
+	if storage.IsBucketNotFound(err) && cb.Spec.CreateMissingBucket {
+		r.Log.Infof("Bucket of storage location %s not found, it will be created by Icarus", cb.Spec.StorageLocation)
+		err = nil
+	}
+
+	backupStatus := cb.DeepCopy()
+	storage.SetCondition(&backupStatus.Status.Conditions, err, cb.Generation)
+	if !cmp.Equal(cb.Status, backupStatus.Status) {
+		updateErr := r.Status().Update(ctx, backupStatus)
+		if updateErr != nil {
+			return false, updateErr
+		}
+
+		backupStatus.DeepCopyInto(cb)
+	}
+
+	if err == storage.ErrNotVerifiable {
+		r.Log.Debugf("Storage credentials of backup %s/%s can't be verified: %s", cb.Namespace, cb.Name, err.Error())
+		return true, nil
+	}
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Storage verification failed: %s", cb.Spec.CassandraCluster, err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventStorageVerificationFailed, errMsg)
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,360 @@
+package cassandrabackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var volumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// reconcileVolumeSnapshotBackup backs up the cluster by taking a VolumeSnapshot of each Cassandra volume.
+// The snapshots are taken once all nodes are flushed and tracked until they are ready to use.
+func (r *CassandraBackupReconciler) reconcileVolumeSnapshotBackup(ctx context.Context, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+	if cb.Status.State == icarus.StateFailed {
+		r.Log.Infof("Backup %s/%s has failed. Recreate the CassandraBackup resource to start a new backup attempt", cb.Namespace, cb.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if len(cb.Status.VolumeSnapshots) == 0 {
+		return r.takeVolumeSnapshots(ctx, cb, cc)
+	}
+
+	return r.reconcileVolumeSnapshotStatus(ctx, cb)
+}
+
+func (r *CassandraBackupReconciler) takeVolumeSnapshots(ctx context.Context, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+	if !cc.Spec.Cassandra.Persistence.Enabled {
+		errMsg := fmt.Sprintf("Failed to create backup for cluster %q. The VolumeSnapshot mode requires persistence to be enabled in the cluster.", cc.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventVolumeSnapshotFailed, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the snapshots of all nodes are taken together, so that they hold the data of the same point in time
+	if !allNodesReady(cc, pods) {
+		r.Log.Warnf("Not all Cassandra pods of cluster %s/%s are ready. Not taking the volume snapshots, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	nctl, err := r.nodectlClient(ctx, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// all nodes are flushed before the first snapshot is taken, so that the snapshots hold the same writes
+	flushErrors := make([]error, len(pods))
+	var wg sync.WaitGroup
+	for i := range pods {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.Log.Debugf("Flushing node %s", pods[i].Name)
+			flushErrors[i] = nctl.Flush(ctx, pods[i].Status.PodIP)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, flushErr := range flushErrors {
+		if flushErr != nil {
+			errMsg := fmt.Sprintf("Failed to flush node %s: %s. Trying again in %s...", pods[i].Name, flushErr.Error(), r.Cfg.RetryDelay)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cb, events.EventNodeFlushFailed, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+	}
+
+	// the snapshots are created concurrently to keep the time between the first and the last one short
+	snapshots := backupVolumeSnapshots(cb, cc, pods)
+	createErrors := make([]error, len(snapshots))
+	for i := range snapshots {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := r.Create(ctx, newVolumeSnapshot(cb, cc, snapshots[i]))
+			if err != nil && !kerrors.IsAlreadyExists(err) {
+				createErrors[i] = err
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, createErr := range createErrors {
+		if createErr == nil {
+			continue
+		}
+
+		if meta.IsNoMatchError(createErr) {
+			errMsg := "Failed to create backup. The VolumeSnapshot kind is not found, the CSI snapshot controller needs to be installed first."
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cb, events.EventVolumeSnapshotFailed, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		return ctrl.Result{}, errors.Wrapf(createErr, "failed to create volume snapshot %s", snapshots[i].Name)
+	}
+
+	r.Log.Infof("Volume snapshots of backup %s/%s created", cb.Namespace, cb.Name)
+	cb.Status.State = icarus.StateRunning
+	cb.Status.StartTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	cb.Status.VolumeSnapshots = snapshots
+	cb.Status.Nodes = snapshotNodesProgress(snapshots, nil, cb.Status.StartTime, nil)
+	icarus.SetConditions(&cb.Status.Conditions, "Backup", cb.Status.State, cb.Generation)
+	err = r.Status().Update(ctx, cb)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to update backup status")
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+// reconcileVolumeSnapshotStatus updates the backup status from the state of its VolumeSnapshots.
+// The backup fails if any of the snapshots has failed.
+func (r *CassandraBackupReconciler) reconcileVolumeSnapshotStatus(ctx context.Context, cb *v1alpha1.CassandraBackup) (ctrl.Result, error) {
+	newStatus := cb.Status.DeepCopy()
+	snapshotErrors := make(map[string]string)
+	for i, snapshot := range newStatus.VolumeSnapshots {
+		volumeSnapshot := &unstructured.Unstructured{}
+		volumeSnapshot.SetGroupVersionKind(volumeSnapshotGVK)
+		err := r.Get(ctx, types.NamespacedName{Name: snapshot.Name, Namespace: cb.Namespace}, volumeSnapshot)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				snapshotErrors[snapshot.Name] = fmt.Sprintf("VolumeSnapshot %s not found", snapshot.Name)
+				continue
+			}
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get volume snapshot %s", snapshot.Name)
+		}
+
+		readyToUse, restoreSize, errMsg := volumeSnapshotState(volumeSnapshot)
+		newStatus.VolumeSnapshots[i].ReadyToUse = readyToUse
+		newStatus.VolumeSnapshots[i].RestoreSize = restoreSize
+		if len(errMsg) != 0 {
+			snapshotErrors[snapshot.Name] = errMsg
+		}
+	}
+
+	ready := 0
+	for _, snapshot := range newStatus.VolumeSnapshots {
+		if snapshot.ReadyToUse {
+			ready++
+		}
+	}
+	newStatus.Progress = ready * 100 / len(newStatus.VolumeSnapshots)
+
+	now := &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	if len(snapshotErrors) != 0 {
+		newStatus.State = icarus.StateFailed
+		newStatus.Errors = nil
+		for _, snapshot := range newStatus.VolumeSnapshots {
+			if errMsg, failed := snapshotErrors[snapshot.Name]; failed {
+				newStatus.Errors = append(newStatus.Errors, v1alpha1.BackupError{Source: snapshot.Pod, Message: errMsg})
+			}
+		}
+	} else if ready == len(newStatus.VolumeSnapshots) {
+		newStatus.State = icarus.StateCompleted
+	}
+
+	var completionTime *metav1.Time
+	if icarus.Finished(newStatus.State) {
+		newStatus.CompletionTime = now
+		completionTime = now
+	}
+	newStatus.Nodes = snapshotNodesProgress(newStatus.VolumeSnapshots, snapshotErrors, newStatus.StartTime, completionTime)
+	icarus.SetConditions(&newStatus.Conditions, "Backup", newStatus.State, cb.Generation)
+
+	if newStatus.State == icarus.StateFailed && cb.Status.State != icarus.StateFailed {
+		errMsg := fmt.Sprintf("Backup %s failed, not all volume snapshots could be taken: %v", cb.Name, newStatus.Errors)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventVolumeSnapshotFailed, errMsg)
+	}
+
+	if !cmp.Equal(cb.Status, *newStatus) {
+		r.Log.Info("Updating backup status")
+		r.Log.Debugf(cmp.Diff(cb.Status, *newStatus))
+		cb.Status = *newStatus
+		err := r.Status().Update(ctx, cb)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to update backup status")
+		}
+	}
+
+	if icarus.Finished(newStatus.State) {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+// deleteVolumeSnapshots deletes the VolumeSnapshots taken by the backup
+func (r *CassandraBackupReconciler) deleteVolumeSnapshots(ctx context.Context, cb *v1alpha1.CassandraBackup) error {
+	for _, snapshot := range cb.Status.VolumeSnapshots {
+		volumeSnapshot := &unstructured.Unstructured{}
+		volumeSnapshot.SetGroupVersionKind(volumeSnapshotGVK)
+		volumeSnapshot.SetName(snapshot.Name)
+		volumeSnapshot.SetNamespace(cb.Namespace)
+		err := r.Delete(ctx, volumeSnapshot)
+		if err != nil && !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return errors.Wrapf(err, "failed to delete volume snapshot %s", snapshot.Name)
+		}
+	}
+
+	return nil
+}
+
+// nodectlClient returns a client to send JMX requests to the Cassandra nodes with the admin role credentials
+func (r *CassandraBackupReconciler) nodectlClient(ctx context.Context, cc *v1alpha1.CassandraCluster) (nodectl.Nodectl, error) {
+	adminSecret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: cc.Spec.AdminRoleSecretName, Namespace: cc.Namespace}, adminSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get admin secret %s", cc.Spec.AdminRoleSecretName)
+	}
+
+	roleName := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminRole])
+	rolePassword := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminPassword])
+	return r.NodectlClient(nodectl.JolokiaURL(cc), roleName, rolePassword, r.Log), nil
+}
+
+func allNodesReady(cc *v1alpha1.CassandraCluster, pods []v1.Pod) bool {
+	nodes := 0
+	for _, dc := range cc.Spec.DCs {
+		if dc.Replicas != nil {
+			nodes += int(*dc.Replicas)
+		}
+	}
+
+	if len(pods) != nodes {
+		return false
+	}
+
+	for _, pod := range pods {
+		if !coordinator.Ready(pod) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// backupVolumeSnapshots returns a snapshot for each volume claim template of each pod, ordered by pod name
+func backupVolumeSnapshots(cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, pods []v1.Pod) []v1alpha1.BackupVolumeSnapshot {
+	volumes := []string{"data"}
+	if cc.Spec.Cassandra.Persistence.CommitLogVolume {
+		volumes = append(volumes, "commitlog")
+	}
+
+	sortedPods := make([]v1.Pod, len(pods))
+	copy(sortedPods, pods)
+	sort.Slice(sortedPods, func(i, j int) bool {
+		return sortedPods[i].Name < sortedPods[j].Name
+	})
+
+	snapshots := make([]v1alpha1.BackupVolumeSnapshot, 0, len(pods)*len(volumes))
+	for _, pod := range sortedPods {
+		for _, volume := range volumes {
+			pvc := volume + "-" + pod.Name // the PVC name given by the StatefulSet
+			snapshots = append(snapshots, v1alpha1.BackupVolumeSnapshot{
+				Name:   cb.Name + "-" + pvc,
+				Volume: volume,
+				PVC:    pvc,
+				Pod:    pod.Name,
+				DC:     pod.Labels[v1alpha1.CassandraClusterDC],
+			})
+		}
+	}
+
+	return snapshots
+}
+
+func newVolumeSnapshot(cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, snapshot v1alpha1.BackupVolumeSnapshot) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": snapshot.PVC,
+		},
+	}
+	if len(cb.Spec.VolumeSnapshotClassName) != 0 {
+		spec["volumeSnapshotClassName"] = cb.Spec.VolumeSnapshotClassName
+	}
+
+	volumeSnapshot := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	volumeSnapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	volumeSnapshot.SetName(snapshot.Name)
+	volumeSnapshot.SetNamespace(cb.Namespace)
+	volumeSnapshot.SetLabels(map[string]string{
+		v1alpha1.CassandraClusterInstance: cc.Name,
+		v1alpha1.CassandraClusterDC:       snapshot.DC,
+		v1alpha1.CassandraBackupLabel:     cb.Name,
+	})
+
+	return volumeSnapshot
+}
+
+// volumeSnapshotState returns the readiness, the restore size and the error message reported in the status of the VolumeSnapshot
+func volumeSnapshotState(volumeSnapshot *unstructured.Unstructured) (readyToUse bool, restoreSize string, errMsg string) {
+	readyToUse, _, _ = unstructured.NestedBool(volumeSnapshot.Object, "status", "readyToUse")
+	restoreSize, _, _ = unstructured.NestedString(volumeSnapshot.Object, "status", "restoreSize")
+	errMsg, _, _ = unstructured.NestedString(volumeSnapshot.Object, "status", "error", "message")
+	return readyToUse, restoreSize, strings.TrimSpace(errMsg)
+}
+
+// snapshotNodesProgress returns the state of the backup on each node. A node is completed once the snapshots of all its volumes are ready.
+func snapshotNodesProgress(snapshots []v1alpha1.BackupVolumeSnapshot, snapshotErrors map[string]string,
+	startTime, completionTime *metav1.Time) []v1alpha1.NodeProgress {
+	var nodes []v1alpha1.NodeProgress
+	for _, snapshot := range snapshots {
+		if len(nodes) == 0 || nodes[len(nodes)-1].Name != snapshot.Pod {
+			nodes = append(nodes, v1alpha1.NodeProgress{
+				Name:           snapshot.Pod,
+				State:          icarus.StateCompleted,
+				Progress:       100,
+				StartTime:      startTime,
+				CompletionTime: completionTime,
+			})
+		}
+
+		node := &nodes[len(nodes)-1]
+		if _, failed := snapshotErrors[snapshot.Name]; failed {
+			node.State = icarus.StateFailed
+			node.Progress = 0
+		} else if !snapshot.ReadyToUse && node.State != icarus.StateFailed {
+			node.State = icarus.StateRunning
+			node.Progress = 0
+		}
+	}
+
+	for i := range nodes {
+		if nodes[i].State == icarus.StateRunning {
+			nodes[i].CompletionTime = nil
+		}
+	}
+
+	return nodes
+}
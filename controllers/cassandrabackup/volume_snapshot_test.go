@@ -0,0 +1,84 @@
+package cassandrabackup
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBackupVolumeSnapshots(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cb := &v1alpha1.CassandraBackup{ObjectMeta: metav1.ObjectMeta{Name: "daily"}}
+	cc := &v1alpha1.CassandraCluster{Spec: v1alpha1.CassandraClusterSpec{Cassandra: &v1alpha1.Cassandra{}}}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-cassandra-dc1-1", Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc1"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-cassandra-dc1-0", Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc1"}}},
+	}
+
+	g.Expect(backupVolumeSnapshots(cb, cc, pods)).To(Equal([]v1alpha1.BackupVolumeSnapshot{
+		{Name: "daily-data-test-cassandra-dc1-0", Volume: "data", PVC: "data-test-cassandra-dc1-0", Pod: "test-cassandra-dc1-0", DC: "dc1"},
+		{Name: "daily-data-test-cassandra-dc1-1", Volume: "data", PVC: "data-test-cassandra-dc1-1", Pod: "test-cassandra-dc1-1", DC: "dc1"},
+	}))
+
+	cc.Spec.Cassandra.Persistence.CommitLogVolume = true
+	snapshots := backupVolumeSnapshots(cb, cc, pods)
+	g.Expect(snapshots).To(HaveLen(4))
+	g.Expect(snapshots[1].Name).To(Equal("daily-commitlog-test-cassandra-dc1-0"))
+	g.Expect(snapshots[1].Pod).To(Equal("test-cassandra-dc1-0"))
+}
+
+func TestAllNodesReady(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{Spec: v1alpha1.CassandraClusterSpec{DCs: []v1alpha1.DC{{Name: "dc1", Replicas: proto.Int32(2)}}}}
+	readyPod := v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "cassandra", Ready: true}}}}
+	notReadyPod := v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "cassandra", Ready: false}}}}
+
+	g.Expect(allNodesReady(cc, []v1.Pod{readyPod, readyPod})).To(BeTrue())
+	g.Expect(allNodesReady(cc, []v1.Pod{readyPod})).To(BeFalse())
+	g.Expect(allNodesReady(cc, []v1.Pod{readyPod, notReadyPod})).To(BeFalse())
+}
+
+func TestVolumeSnapshotState(t *testing.T) {
+	g := NewGomegaWithT(t)
+	volumeSnapshot := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	readyToUse, restoreSize, errMsg := volumeSnapshotState(volumeSnapshot)
+	g.Expect(readyToUse).To(BeFalse())
+	g.Expect(restoreSize).To(BeEmpty())
+	g.Expect(errMsg).To(BeEmpty())
+
+	volumeSnapshot.Object["status"] = map[string]interface{}{
+		"readyToUse":  true,
+		"restoreSize": "10Gi",
+		"error":       map[string]interface{}{"message": " failed to snapshot \n"},
+	}
+	readyToUse, restoreSize, errMsg = volumeSnapshotState(volumeSnapshot)
+	g.Expect(readyToUse).To(BeTrue())
+	g.Expect(restoreSize).To(Equal("10Gi"))
+	g.Expect(errMsg).To(Equal("failed to snapshot"))
+}
+
+func TestSnapshotNodesProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	startTime := &metav1.Time{}
+	completionTime := &metav1.Time{}
+	snapshots := []v1alpha1.BackupVolumeSnapshot{
+		{Name: "data-pod-0", Pod: "pod-0", ReadyToUse: true},
+		{Name: "commitlog-pod-0", Pod: "pod-0", ReadyToUse: true},
+		{Name: "data-pod-1", Pod: "pod-1", ReadyToUse: true},
+		{Name: "commitlog-pod-1", Pod: "pod-1"},
+		{Name: "data-pod-2", Pod: "pod-2"},
+	}
+
+	nodes := snapshotNodesProgress(snapshots, map[string]string{"data-pod-2": "failed"}, startTime, completionTime)
+	g.Expect(nodes).To(Equal([]v1alpha1.NodeProgress{
+		{Name: "pod-0", State: icarus.StateCompleted, Progress: 100, StartTime: startTime, CompletionTime: completionTime},
+		{Name: "pod-1", State: icarus.StateRunning, StartTime: startTime},
+		{Name: "pod-2", State: icarus.StateFailed, StartTime: startTime, CompletionTime: completionTime},
+	}))
+}
@@ -0,0 +1,126 @@
+package cassandrabackupcatalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CassandraBackupCatalogReconciler reconciles a CassandraBackupCatalog object
+type CassandraBackupCatalogReconciler struct {
+	client.Client
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	IcarusClient  func(coordinatorPodURL string) icarus.Icarus
+	StorageClient func(secret *v1.Secret, insecure bool) storage.Storage
+}
+
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackupcatalogs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackupcatalogs/status,verbs=get;update;patch
+
+func (r *CassandraBackupCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cbc := &v1alpha1.CassandraBackupCatalog{}
+	err := r.Get(ctx, req.NamespacedName, cbc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	cc := &v1alpha1.CassandraCluster{}
+	err = r.Get(ctx, types.NamespacedName{Name: cbc.Spec.CassandraCluster, Namespace: cbc.Namespace}, cc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("Failed to sync backup catalog. Cluster %q not found.", cbc.Spec.CassandraCluster)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cbc, events.EventCassandraClusterNotFound, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !cc.Status.Ready {
+		r.Log.Warnf("CassandraCluster %s/%s is not ready. Not syncing backup catalog, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if cbc.StorageProvider() == v1alpha1.StorageProviderFile && cc.Spec.Icarus.BackupVolume == nil {
+		errMsg := fmt.Sprintf("Failed to sync backup catalog. The file storage provider requires .spec.icarus.backupVolume to be set in cluster %q.", cc.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cbc, events.EventBackupVolumeNotConfigured, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	var storageCredentials *v1.Secret
+	if v1alpha1.StorageSecretRequired(cbc.StorageProvider()) {
+		storageCredentials = &v1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Name: cbc.Spec.SecretName, Namespace: cbc.Namespace}, storageCredentials)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				errMsg := fmt.Sprintf("Failed to sync backup catalog. Storage credentials secret %q not found.", cbc.Spec.SecretName)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cbc, events.EventStorageCredentialsSecretNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	coordinatorPod, err := coordinator.Pod(ctx, r.Client, cc, cbc.Status.Coordinator)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if coordinatorPod == nil {
+		errMsg := fmt.Sprintf("No ready Cassandra pod is available to list the backups. Trying again in %s...", r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cbc, events.EventCoordinatorNotAvailable, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	ic := r.IcarusClient(coordinator.URL(cc, coordinatorPod))
+	res, err := r.reconcileSync(ctx, ic, cbc, cc, coordinatorPod.Name, storageCredentials)
+	if err != nil {
+		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
+			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
+			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
+		}
+
+		r.Log.Errorf("%+v", err)
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+func SetupCassandraBackupCatalogReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("cassandrabackupcatalog").
+		For(&v1alpha1.CassandraBackupCatalog{})
+
+	return builder.Complete(r)
+}
@@ -0,0 +1,332 @@
+package cassandrabackupcatalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const defaultRefreshInterval = time.Hour
+
+// catalogNode is a backed up node whose backups are listed
+type catalogNode struct {
+	Name            string
+	DC              string
+	StorageLocation string
+}
+
+// nodeReport holds the backups listed for a node
+type nodeReport struct {
+	Node      string
+	DC        string
+	Manifests []icarus.ManifestReport
+}
+
+// reconcileSync lists the backups of each node in the storage and updates the catalog once all nodes are listed.
+// A sync is started when the refresh interval has passed or the spec has changed.
+func (r *CassandraBackupCatalogReconciler) reconcileSync(ctx context.Context, ic icarus.Icarus, cbc *v1alpha1.CassandraBackupCatalog,
+	cc *v1alpha1.CassandraCluster, coordinatorPod string, storageCredentials *v1.Secret) (ctrl.Result, error) {
+	now := time.Now().Truncate(time.Second)
+	refreshInterval := defaultRefreshInterval
+	if cbc.Spec.RefreshInterval != nil {
+		refreshInterval = cbc.Spec.RefreshInterval.Duration
+	}
+
+	newStatus := cbc.Status.DeepCopy()
+	// the new coordinator doesn't know about the list operations, so the sync is started over
+	if newStatus.SyncStartTime == nil || newStatus.Coordinator != coordinatorPod {
+		if newStatus.SyncStartTime == nil && !syncDue(cbc, refreshInterval, now) {
+			return ctrl.Result{RequeueAfter: newStatus.LastSyncTime.Add(refreshInterval).Sub(now)}, nil
+		}
+
+		r.Log.Infof("Syncing backup catalog %s/%s with storage location %s", cbc.Namespace, cbc.Name, cbc.Spec.StorageLocation)
+		newStatus.SyncStartTime = &metav1.Time{Time: now}
+		newStatus.Coordinator = coordinatorPod
+		newStatus.ObservedGeneration = cbc.Generation
+	}
+
+	nodes, err := r.catalogNodes(ctx, cbc, cc, storageCredentials)
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed to list the backed up nodes in storage location %s: %s. Trying again in %s...", cbc.Spec.StorageLocation, err.Error(), r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cbc, events.EventBackupCatalogSyncFailed, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, r.reconcileStatus(ctx, cbc, *newStatus)
+	}
+
+	lists, err := ic.Lists(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to get list operations")
+	}
+
+	var reports []nodeReport
+	var failures []string
+	finished := true
+	for _, node := range nodes {
+		list, found := findList(lists, node.StorageLocation, newStatus.SyncStartTime.Time)
+		if !found {
+			_, err = ic.List(ctx, icarus.ListRequest{
+				StorageLocation:        node.StorageLocation,
+				K8sNamespace:           cbc.Namespace,
+				K8sSecretName:          cbc.Spec.SecretName,
+				Insecure:               cbc.Spec.Insecure,
+				SkipBucketVerification: cbc.Spec.SkipBucketVerification,
+			})
+			if err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to list the backups of node %s", node.Name)
+			}
+
+			finished = false
+			continue
+		}
+
+		switch list.State {
+		case icarus.StateCompleted:
+			reports = append(reports, nodeReport{Node: node.Name, DC: node.DC, Manifests: list.Response.Reports})
+		case icarus.StateFailed, icarus.StateCancelled:
+			failures = append(failures, fmt.Sprintf("%s: %s", node.Name, listErrors(list)))
+		default:
+			finished = false
+		}
+	}
+
+	if !finished {
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, r.reconcileStatus(ctx, cbc, *newStatus)
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SyncSucceeded",
+		ObservedGeneration: newStatus.ObservedGeneration,
+	}
+	if len(failures) == 0 {
+		newStatus.Backups = catalogBackups(reports)
+		condition.Message = fmt.Sprintf("Found %d backups", len(newStatus.Backups))
+	} else {
+		// the backups of the previous sync are kept, since an incomplete listing would report wrong topologies
+		errMsg := fmt.Sprintf("Failed to list the backups of %d nodes: %s", len(failures), strings.Join(failures, "; "))
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cbc, events.EventBackupCatalogSyncFailed, errMsg)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SyncFailed"
+		condition.Message = errMsg
+	}
+
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+	newStatus.LastSyncTime = &metav1.Time{Time: now}
+	newStatus.SyncStartTime = nil
+	err = r.reconcileStatus(ctx, cbc, *newStatus)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: refreshInterval}, nil
+}
+
+func (r *CassandraBackupCatalogReconciler) reconcileStatus(ctx context.Context, cbc *v1alpha1.CassandraBackupCatalog, newStatus v1alpha1.CassandraBackupCatalogStatus) error {
+	if !cmp.Equal(cbc.Status, newStatus) {
+		r.Log.Info("Updating backup catalog status")
+		r.Log.Debugf(cmp.Diff(cbc.Status, newStatus))
+		cbc.Status = newStatus
+		err := r.Status().Update(ctx, cbc)
+		if err != nil {
+			return errors.Wrap(err, "failed to update backup catalog status")
+		}
+	}
+
+	return nil
+}
+
+func syncDue(cbc *v1alpha1.CassandraBackupCatalog, refreshInterval time.Duration, now time.Time) bool {
+	if cbc.Status.LastSyncTime == nil || cbc.Status.ObservedGeneration != cbc.Generation {
+		return true
+	}
+
+	return !now.Before(cbc.Status.LastSyncTime.Add(refreshInterval))
+}
+
+// catalogNodes returns the backed up nodes of the source cluster, listed from the DC and node directories in the storage.
+// If the storage can't be listed by the operator, e.g. for the file storage provider, the nodes are derived from the pods instead.
+func (r *CassandraBackupCatalogReconciler) catalogNodes(ctx context.Context, cbc *v1alpha1.CassandraBackupCatalog, cc *v1alpha1.CassandraCluster,
+	storageCredentials *v1.Secret) ([]catalogNode, error) {
+	if storageCredentials != nil {
+		nodes, err := storageNodes(ctx, r.StorageClient(storageCredentials, cbc.Spec.Insecure), cbc)
+		if err != storage.ErrNotVerifiable {
+			return nodes, err
+		}
+	}
+
+	r.Log.Debugf("Storage location %s can't be listed by the operator, listing the backups of the nodes of cluster %s", cbc.Spec.StorageLocation, cc.Name)
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	return podNodes(cbc, cc, pods), nil
+}
+
+// storageNodes lists the nodes with backups of the source cluster in the storage, which Icarus stores under `<cluster>/<dc>/<node>`
+func storageNodes(ctx context.Context, storageClient storage.Storage, cbc *v1alpha1.CassandraBackupCatalog) ([]catalogNode, error) {
+	sourceCluster := cbc.SourceClusterName()
+	clusterLocation := strings.TrimSuffix(cbc.Spec.StorageLocation, "/") + "/" + sourceCluster
+	dcs, err := storageClient.ListDirs(ctx, cbc.StorageProvider(), clusterLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []catalogNode
+	for _, dc := range dcs {
+		nodeNames, err := storageClient.ListDirs(ctx, cbc.StorageProvider(), clusterLocation+"/"+dc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, nodeName := range nodeNames {
+			nodes = append(nodes, catalogNode{
+				Name:            nodeName,
+				DC:              dc,
+				StorageLocation: icarus.NodeStorageLocation(cbc.Spec.StorageLocation, sourceCluster, dc, nodeName),
+			})
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes, nil
+}
+
+// podNodes returns the nodes of the source cluster assuming it has the topology of the cluster that lists the backups,
+// so each pod is renamed after the source cluster to find the backups of the node it replaces.
+func podNodes(cbc *v1alpha1.CassandraBackupCatalog, cc *v1alpha1.CassandraCluster, pods []v1.Pod) []catalogNode {
+	sourceCluster := cbc.SourceClusterName()
+	nodes := make([]catalogNode, 0, len(pods))
+	for _, pod := range pods {
+		dc := pod.Labels[v1alpha1.CassandraClusterDC]
+		if len(dc) == 0 {
+			continue
+		}
+
+		nodeName := names.DC(sourceCluster, dc) + strings.TrimPrefix(pod.Name, names.DC(cc.Name, dc))
+		nodes = append(nodes, catalogNode{
+			Name:            nodeName,
+			DC:              dc,
+			StorageLocation: icarus.NodeStorageLocation(cbc.Spec.StorageLocation, sourceCluster, dc, nodeName),
+		})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes
+}
+
+// findList returns the latest list operation for the storage location started since the sync has started
+func findList(lists []icarus.List, storageLocation string, syncStartTime time.Time) (icarus.List, bool) {
+	var latest icarus.List
+	var latestTime time.Time
+	found := false
+	for _, list := range lists {
+		if list.StorageLocation != storageLocation {
+			continue
+		}
+
+		creationTime := icarus.ParseTime(list.CreationTime)
+		if creationTime == nil || creationTime.Time.Before(syncStartTime) {
+			continue
+		}
+
+		if found && creationTime.Time.Before(latestTime) {
+			continue
+		}
+
+		latest = list
+		latestTime = creationTime.Time
+		found = true
+	}
+
+	return latest, found
+}
+
+func listErrors(list icarus.List) string {
+	if len(list.Errors) == 0 {
+		return "list operation " + strings.ToLower(list.State)
+	}
+
+	messages := make([]string, 0, len(list.Errors))
+	for _, listErr := range list.Errors {
+		messages = append(messages, listErr.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// catalogBackups groups the manifests of the nodes by backup. The backups are ordered by time.
+func catalogBackups(reports []nodeReport) []v1alpha1.CatalogBackup {
+	backups := make(map[string]*v1alpha1.CatalogBackup)
+	for _, report := range reports {
+		for _, manifest := range report.Manifests {
+			backup, exists := backups[manifest.Name]
+			if !exists {
+				backup = &v1alpha1.CatalogBackup{
+					Name:          manifest.Name,
+					SnapshotTag:   manifest.SnapshotTag(),
+					SchemaVersion: manifest.SchemaVersion,
+				}
+				if manifest.UnixTimestamp > 0 {
+					backup.Time = &metav1.Time{Time: time.UnixMilli(manifest.UnixTimestamp).UTC().Truncate(time.Second)}
+				}
+				backups[manifest.Name] = backup
+			}
+
+			backup.Size += manifest.Size
+			backup.Encrypted = backup.Encrypted || manifest.Encrypted
+			addNode(backup, report.DC, report.Node)
+		}
+	}
+
+	catalog := make([]v1alpha1.CatalogBackup, 0, len(backups))
+	for _, backup := range backups {
+		catalog = append(catalog, *backup)
+	}
+
+	sort.Slice(catalog, func(i, j int) bool {
+		if catalog[i].Time.Equal(catalog[j].Time) {
+			return catalog[i].Name < catalog[j].Name
+		}
+
+		return catalog[i].Time.Before(catalog[j].Time)
+	})
+
+	return catalog
+}
+
+func addNode(backup *v1alpha1.CatalogBackup, dcName, nodeName string) {
+	for i := range backup.DCs {
+		if backup.DCs[i].Name == dcName {
+			backup.DCs[i].Nodes = append(backup.DCs[i].Nodes, nodeName)
+			return
+		}
+	}
+
+	backup.DCs = append(backup.DCs, v1alpha1.CatalogDC{Name: dcName, Nodes: []string{nodeName}})
+}
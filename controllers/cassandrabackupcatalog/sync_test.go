@@ -0,0 +1,128 @@
+package cassandrabackupcatalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodNodes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "staging"}}
+	cbc := &v1alpha1.CassandraBackupCatalog{Spec: v1alpha1.CassandraBackupCatalogSpec{CassandraCluster: "staging", StorageLocation: "s3://bucket"}}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "staging-cassandra-dc2-rack1-0", Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc2"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "staging-cassandra-dc1-rack1-0", Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc1"}}},
+	}
+
+	g.Expect(podNodes(cbc, cc, pods)).To(Equal([]catalogNode{
+		{Name: "staging-cassandra-dc1-rack1-0", DC: "dc1", StorageLocation: "s3://bucket/staging/dc1/staging-cassandra-dc1-rack1-0"},
+		{Name: "staging-cassandra-dc2-rack1-0", DC: "dc2", StorageLocation: "s3://bucket/staging/dc2/staging-cassandra-dc2-rack1-0"},
+	}))
+
+	cbc.Spec.SourceCluster = "prod"
+	g.Expect(podNodes(cbc, cc, pods)[0]).To(Equal(
+		catalogNode{Name: "prod-cassandra-dc1-rack1-0", DC: "dc1", StorageLocation: "s3://bucket/prod/dc1/prod-cassandra-dc1-rack1-0"},
+	))
+}
+
+type fakeStorage struct {
+	storage.Storage
+	dirs map[string][]string
+}
+
+func (f fakeStorage) ListDirs(ctx context.Context, storageProvider v1alpha1.StorageProvider, storageLocation string) ([]string, error) {
+	return f.dirs[storageLocation], nil
+}
+
+func TestStorageNodes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cbc := &v1alpha1.CassandraBackupCatalog{Spec: v1alpha1.CassandraBackupCatalogSpec{CassandraCluster: "staging", SourceCluster: "prod", StorageLocation: "s3://bucket/"}}
+	storageClient := fakeStorage{dirs: map[string][]string{
+		"s3://bucket/prod":     {"dc1", "dc2"},
+		"s3://bucket/prod/dc1": {"prod-cassandra-dc1-1", "prod-cassandra-dc1-0"},
+		"s3://bucket/prod/dc2": {"prod-cassandra-dc2-0"},
+	}}
+
+	g.Expect(storageNodes(context.Background(), storageClient, cbc)).To(Equal([]catalogNode{
+		{Name: "prod-cassandra-dc1-0", DC: "dc1", StorageLocation: "s3://bucket/prod/dc1/prod-cassandra-dc1-0"},
+		{Name: "prod-cassandra-dc1-1", DC: "dc1", StorageLocation: "s3://bucket/prod/dc1/prod-cassandra-dc1-1"},
+		{Name: "prod-cassandra-dc2-0", DC: "dc2", StorageLocation: "s3://bucket/prod/dc2/prod-cassandra-dc2-0"},
+	}))
+}
+
+func TestFindList(t *testing.T) {
+	g := NewGomegaWithT(t)
+	syncStartTime := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	lists := []icarus.List{
+		{ID: "1", StorageLocation: "s3://bucket/prod/dc1/node-0", CreationTime: "2022-10-01T11:00:00Z"},
+		{ID: "2", StorageLocation: "s3://bucket/prod/dc1/node-0", CreationTime: "2022-10-01T12:05:00Z"},
+		{ID: "3", StorageLocation: "s3://bucket/prod/dc1/node-0", CreationTime: "2022-10-01T12:01:00Z"},
+		{ID: "4", StorageLocation: "s3://bucket/prod/dc1/node-1", CreationTime: "2022-10-01T11:30:00Z"},
+	}
+
+	list, found := findList(lists, "s3://bucket/prod/dc1/node-0", syncStartTime)
+	g.Expect(found).To(BeTrue())
+	g.Expect(list.ID).To(Equal("2"))
+
+	_, found = findList(lists, "s3://bucket/prod/dc1/node-1", syncStartTime)
+	g.Expect(found).To(BeFalse())
+}
+
+func TestCatalogBackups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	reports := []nodeReport{
+		{Node: "prod-cassandra-dc1-rack1-0", DC: "dc1", Manifests: []icarus.ManifestReport{
+			{Name: "weekly-schema2-1665000000000", SchemaVersion: "schema2", Size: 300, UnixTimestamp: 1665000000000, Encrypted: true},
+			{Name: "daily-schema1-1664000000000", SchemaVersion: "schema1", Size: 100, UnixTimestamp: 1664000000000},
+		}},
+		{Node: "prod-cassandra-dc2-rack1-0", DC: "dc2", Manifests: []icarus.ManifestReport{
+			{Name: "daily-schema1-1664000000000", SchemaVersion: "schema1", Size: 200, UnixTimestamp: 1664000000000},
+		}},
+	}
+
+	g.Expect(catalogBackups(reports)).To(Equal([]v1alpha1.CatalogBackup{
+		{
+			Name:          "daily-schema1-1664000000000",
+			SnapshotTag:   "daily",
+			SchemaVersion: "schema1",
+			Time:          &metav1.Time{Time: time.UnixMilli(1664000000000).UTC()},
+			Size:          300,
+			DCs: []v1alpha1.CatalogDC{
+				{Name: "dc1", Nodes: []string{"prod-cassandra-dc1-rack1-0"}},
+				{Name: "dc2", Nodes: []string{"prod-cassandra-dc2-rack1-0"}},
+			},
+		},
+		{
+			Name:          "weekly-schema2-1665000000000",
+			SnapshotTag:   "weekly",
+			SchemaVersion: "schema2",
+			Time:          &metav1.Time{Time: time.UnixMilli(1665000000000).UTC()},
+			Size:          300,
+			DCs:           []v1alpha1.CatalogDC{{Name: "dc1", Nodes: []string{"prod-cassandra-dc1-rack1-0"}}},
+			Encrypted:     true,
+		},
+	}))
+}
+
+func TestSyncDue(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	cbc := &v1alpha1.CassandraBackupCatalog{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+	g.Expect(syncDue(cbc, time.Hour, now)).To(BeTrue())
+
+	cbc.Status.LastSyncTime = &metav1.Time{Time: now.Add(-30 * time.Minute)}
+	cbc.Status.ObservedGeneration = 1
+	g.Expect(syncDue(cbc, time.Hour, now)).To(BeFalse())
+	g.Expect(syncDue(cbc, 30*time.Minute, now)).To(BeTrue())
+
+	cbc.Generation = 2
+	g.Expect(syncDue(cbc, time.Hour, now)).To(BeTrue())
+}
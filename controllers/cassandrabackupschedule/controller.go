@@ -0,0 +1,78 @@
+package cassandrabackupschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/events"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CassandraBackupScheduleReconciler reconciles a CassandraBackupSchedule object
+type CassandraBackupScheduleReconciler struct {
+	client.Client
+	Log    *zap.SugaredLogger
+	Scheme *runtime.Scheme
+	Cfg    config.Config
+	Events *events.EventRecorder
+	Now    func() time.Time
+}
+
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackups,verbs=get;list;watch;create;update;patch;delete
+
+func (r *CassandraBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cbs := &v1alpha1.CassandraBackupSchedule{}
+	err := r.Get(ctx, req.NamespacedName, cbs)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	sched, err := v1alpha1.ParseBackupSchedule(cbs.Spec.Schedule)
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed to parse schedule %q: %s", cbs.Spec.Schedule, err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cbs, events.EventBackupScheduleInvalid, errMsg)
+		return ctrl.Result{}, nil // wait for the schedule to be fixed
+	}
+
+	res, err := r.reconcileSchedule(ctx, cbs, sched)
+	if err != nil {
+		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
+			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
+			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
+		}
+
+		r.Log.Errorf("%+v", err)
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+func SetupCassandraBackupScheduleReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("cassandrabackupschedule").
+		For(&v1alpha1.CassandraBackupSchedule{}).
+		Owns(&v1alpha1.CassandraBackup{})
+
+	return builder.Complete(r)
+}
@@ -0,0 +1,74 @@
+package cassandrabackupschedule
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// groupBackups splits the backups created by the schedule into active, successful and failed ones, each sorted from oldest to newest
+func groupBackups(cbs *v1alpha1.CassandraBackupSchedule, backups []v1alpha1.CassandraBackup) (active, successful, failed []v1alpha1.CassandraBackup) {
+	sort.SliceStable(backups, func(i, j int) bool {
+		return scheduledAt(backups[i]).Before(scheduledAt(backups[j]))
+	})
+
+	for _, backup := range backups {
+		if !metav1.IsControlledBy(&backup, cbs) {
+			continue
+		}
+
+		if backup.DeletionTimestamp != nil {
+			continue
+		}
+
+		switch backup.Status.State {
+		case icarus.StateCompleted:
+			successful = append(successful, backup)
+		case icarus.StateFailed, icarus.StateCancelled:
+			failed = append(failed, backup)
+		default:
+			active = append(active, backup)
+		}
+	}
+
+	return active, successful, failed
+}
+
+// cleanupHistory removes the oldest backups so that at most `limit` backups are left
+func (r *CassandraBackupScheduleReconciler) cleanupHistory(ctx context.Context, backups []v1alpha1.CassandraBackup, limit int) error {
+	if len(backups) <= limit {
+		return nil
+	}
+
+	return r.deleteBackups(ctx, backups[:len(backups)-limit], "it exceeds the history limit")
+}
+
+func (r *CassandraBackupScheduleReconciler) deleteBackups(ctx context.Context, backups []v1alpha1.CassandraBackup, reason string) error {
+	for i := range backups {
+		r.Log.Infof("Removing backup %s/%s as %s", backups[i].Namespace, backups[i].Name, reason)
+		err := r.Delete(ctx, &backups[i])
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete backup %s", backups[i].Name)
+		}
+	}
+
+	return nil
+}
+
+func scheduledAt(backup v1alpha1.CassandraBackup) time.Time {
+	if value, ok := backup.Annotations[v1alpha1.CassandraBackupScheduledAtAnnotation]; ok {
+		if scheduledTime, err := time.Parse(time.RFC3339, value); err == nil {
+			return scheduledTime
+		}
+	}
+
+	return backup.CreationTimestamp.Time
+}
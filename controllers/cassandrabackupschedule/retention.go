@@ -0,0 +1,54 @@
+package cassandrabackupschedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+// expiredBackups returns the completed backups that are not kept by the retention rules.
+// The backups are expected to be sorted from oldest to newest.
+func expiredBackups(backups []v1alpha1.CassandraBackup, retention v1alpha1.BackupRetention, now time.Time) []v1alpha1.CassandraBackup {
+	keepRulesSet := retention.KeepLast > 0 || retention.KeepDaily > 0 || retention.KeepWeekly > 0 || retention.KeepMonthly > 0
+	kept := make(map[string]bool, len(backups))
+	if keepRulesSet {
+		keepNewest(backups, kept, int(retention.KeepLast), func(t time.Time) string {
+			return t.String()
+		})
+		keepNewest(backups, kept, int(retention.KeepDaily), func(t time.Time) string {
+			return t.Format("2006-01-02")
+		})
+		keepNewest(backups, kept, int(retention.KeepWeekly), func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-%d", year, week)
+		})
+		keepNewest(backups, kept, int(retention.KeepMonthly), func(t time.Time) string {
+			return t.Format("2006-01")
+		})
+	}
+
+	var expired []v1alpha1.CassandraBackup
+	for _, backup := range backups {
+		tooOld := retention.MaxAge != nil && now.Sub(scheduledAt(backup)) > retention.MaxAge.Duration
+		if tooOld || (keepRulesSet && !kept[backup.Name]) {
+			expired = append(expired, backup)
+		}
+	}
+
+	return expired
+}
+
+// keepNewest marks the newest backup of each of the `limit` most recent periods as kept
+func keepNewest(backups []v1alpha1.CassandraBackup, kept map[string]bool, limit int, period func(t time.Time) string) {
+	periods := make(map[string]bool, limit)
+	for i := len(backups) - 1; i >= 0 && len(periods) < limit; i-- {
+		key := period(scheduledAt(backups[i]).UTC())
+		if periods[key] {
+			continue
+		}
+
+		periods[key] = true
+		kept[backups[i].Name] = true
+	}
+}
@@ -0,0 +1,64 @@
+package cassandrabackupschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpiredBackups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC)
+	// two backups a day for 60 days, from oldest to newest
+	var backups []v1alpha1.CassandraBackup
+	for day := 59; day >= 0; day-- {
+		for _, hour := range []int{3, 15} {
+			scheduledTime := time.Date(2022, 6, 30, hour, 0, 0, 0, time.UTC).AddDate(0, 0, -day)
+			if scheduledTime.After(now) {
+				continue
+			}
+			backup := v1alpha1.CassandraBackup{}
+			backup.Name = "backup-" + scheduledTime.Format(backupNameTimeFormat)
+			backup.Annotations = map[string]string{v1alpha1.CassandraBackupScheduledAtAnnotation: scheduledTime.Format(time.RFC3339)}
+			backups = append(backups, backup)
+		}
+	}
+
+	g.Expect(expiredBackups(backups, v1alpha1.BackupRetention{}, now)).To(BeEmpty())
+
+	expired := expiredBackups(backups, v1alpha1.BackupRetention{KeepLast: 3}, now)
+	g.Expect(expired).To(HaveLen(len(backups) - 3))
+	g.Expect(backupNames(expired)).ToNot(ContainElements("backup-20220630-030000", "backup-20220629-150000", "backup-20220629-030000"))
+
+	kept := keptBackups(backups, expiredBackups(backups, v1alpha1.BackupRetention{KeepDaily: 2, KeepWeekly: 2, KeepMonthly: 2}, now))
+	g.Expect(kept).To(Equal([]string{
+		"backup-20220531-150000", // monthly
+		"backup-20220626-150000", // weekly, last backup on the previous ISO week's Sunday
+		"backup-20220629-150000", // daily
+		"backup-20220630-030000", // daily, weekly, monthly
+	}))
+
+	kept = keptBackups(backups, expiredBackups(backups, v1alpha1.BackupRetention{KeepMonthly: 2, MaxAge: &metav1.Duration{Duration: 7 * 24 * time.Hour}}, now))
+	g.Expect(kept).To(Equal([]string{"backup-20220630-030000"}))
+
+	expired = expiredBackups(backups, v1alpha1.BackupRetention{MaxAge: &metav1.Duration{Duration: 24 * time.Hour}}, now)
+	g.Expect(keptBackups(backups, expired)).To(Equal([]string{"backup-20220629-150000", "backup-20220630-030000"}))
+}
+
+func keptBackups(backups, expired []v1alpha1.CassandraBackup) []string {
+	expiredNames := make(map[string]bool)
+	for _, backup := range expired {
+		expiredNames[backup.Name] = true
+	}
+
+	var kept []string
+	for _, backup := range backups {
+		if !expiredNames[backup.Name] {
+			kept = append(kept, backup.Name)
+		}
+	}
+	return kept
+}
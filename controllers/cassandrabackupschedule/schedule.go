@@ -0,0 +1,183 @@
+package cassandrabackupschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const backupNameTimeFormat = "20060102-150405"
+
+func (r *CassandraBackupScheduleReconciler) reconcileSchedule(ctx context.Context, cbs *v1alpha1.CassandraBackupSchedule, sched cron.Schedule) (ctrl.Result, error) {
+	now := r.Now()
+	backupList := &v1alpha1.CassandraBackupList{}
+	err := r.List(ctx, backupList, client.InNamespace(cbs.Namespace), client.MatchingLabels{v1alpha1.CassandraBackupScheduleLabel: cbs.Name})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list backups")
+	}
+
+	active, successful, failed := groupBackups(cbs, backupList.Items)
+	newStatus := cbs.Status.DeepCopy()
+	if len(successful) > 0 && newStatus.LastSuccessfulBackup != successful[len(successful)-1].Name {
+		newStatus.LastSuccessfulBackup = successful[len(successful)-1].Name
+		newStatus.LastSuccessfulTime = &metav1.Time{Time: now}
+	}
+	if len(failed) > 0 && newStatus.LastFailedBackup != failed[len(failed)-1].Name {
+		newStatus.LastFailedBackup = failed[len(failed)-1].Name
+		newStatus.LastFailureTime = &metav1.Time{Time: now}
+	}
+
+	if cbs.Spec.Retention != nil {
+		err = r.deleteBackups(ctx, expiredBackups(successful, *cbs.Spec.Retention, now), "it's not kept by the retention rules")
+	} else {
+		err = r.cleanupHistory(ctx, successful, historyLimit(cbs.Spec.SuccessfulBackupsHistoryLimit, 3))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.cleanupHistory(ctx, failed, historyLimit(cbs.Spec.FailedBackupsHistoryLimit, 1))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	res := ctrl.Result{}
+	if cbs.Spec.Suspend {
+		r.Log.Debugf("Backup schedule %s/%s is suspended", cbs.Namespace, cbs.Name)
+		newStatus.NextScheduleTime = nil
+	} else {
+		earliestTime := cbs.CreationTimestamp.Time
+		if cbs.Status.LastScheduleTime != nil {
+			earliestTime = cbs.Status.LastScheduleTime.Time
+		}
+
+		scheduledTime, nextScheduleTime := scheduleTimes(sched, earliestTime, now)
+		if scheduledTime != nil {
+			active, err = r.runScheduledBackup(ctx, cbs, *scheduledTime, active)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			newStatus.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+		}
+
+		newStatus.NextScheduleTime = nil
+		if !nextScheduleTime.IsZero() {
+			newStatus.NextScheduleTime = &metav1.Time{Time: nextScheduleTime}
+			res.RequeueAfter = nextScheduleTime.Sub(now)
+		}
+	}
+
+	newStatus.Active = nil
+	for _, backup := range active {
+		newStatus.Active = append(newStatus.Active, backup.Name)
+	}
+
+	err = r.reconcileStatus(ctx, cbs, *newStatus)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+// runScheduledBackup creates the backup for the scheduled run according to the concurrency policy and returns the active backups
+func (r *CassandraBackupScheduleReconciler) runScheduledBackup(ctx context.Context, cbs *v1alpha1.CassandraBackupSchedule,
+	scheduledTime time.Time, active []v1alpha1.CassandraBackup) ([]v1alpha1.CassandraBackup, error) {
+	if len(active) > 0 {
+		switch cbs.Spec.ConcurrencyPolicy {
+		case v1alpha1.ConcurrencyPolicyAllow:
+		case v1alpha1.ConcurrencyPolicyReplace:
+			for i := range active {
+				r.Log.Infof("Replacing running backup %s/%s", active[i].Namespace, active[i].Name)
+				err := r.Delete(ctx, &active[i])
+				if err != nil && !kerrors.IsNotFound(err) {
+					return nil, errors.Wrapf(err, "failed to delete backup %s", active[i].Name)
+				}
+			}
+			active = nil
+		default:
+			msg := fmt.Sprintf("Skipping backup scheduled at %s. Previous backup %s is still running.", scheduledTime.Format(time.RFC3339), active[0].Name)
+			r.Log.Info(msg)
+			r.Events.Warning(cbs, events.EventBackupScheduleSkipped, msg)
+			return active, nil
+		}
+	}
+
+	backup, err := r.createBackup(ctx, cbs, scheduledTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(active, *backup), nil
+}
+
+func (r *CassandraBackupScheduleReconciler) createBackup(ctx context.Context, cbs *v1alpha1.CassandraBackupSchedule, scheduledTime time.Time) (*v1alpha1.CassandraBackup, error) {
+	timestamp := scheduledTime.UTC().Format(backupNameTimeFormat)
+	backup := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", cbs.Name, timestamp),
+			Namespace: cbs.Namespace,
+			Labels: map[string]string{
+				v1alpha1.CassandraBackupScheduleLabel: cbs.Name,
+			},
+			Annotations: map[string]string{
+				v1alpha1.CassandraBackupScheduledAtAnnotation: scheduledTime.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: *cbs.Spec.BackupTemplate.DeepCopy(),
+	}
+
+	// the snapshot tag defaults to the backup name which is unique already
+	if len(backup.Spec.SnapshotTag) != 0 {
+		backup.Spec.SnapshotTag = fmt.Sprintf("%s-%s", backup.Spec.SnapshotTag, timestamp)
+	}
+
+	if err := controllerutil.SetControllerReference(cbs, backup, r.Scheme); err != nil {
+		return nil, errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	r.Log.Infof("Creating backup %s/%s", backup.Namespace, backup.Name)
+	err := r.Create(ctx, backup)
+	if err != nil {
+		if kerrors.IsAlreadyExists(err) {
+			return backup, nil
+		}
+		return nil, errors.Wrapf(err, "failed to create backup %s", backup.Name)
+	}
+
+	r.Events.Normal(cbs, events.EventBackupScheduled, fmt.Sprintf("created backup %q", backup.Name))
+
+	return backup, nil
+}
+
+// scheduleTimes returns the most recent missed schedule time after earliestTime (if any) and the next schedule time after now
+func scheduleTimes(sched cron.Schedule, earliestTime, now time.Time) (*time.Time, time.Time) {
+	var scheduledTime *time.Time
+	// the schedule returns a zero time if it can't find a matching time, e.g. for `0 0 30 2 *`
+	for t := sched.Next(earliestTime); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		missedTime := t
+		scheduledTime = &missedTime
+	}
+
+	return scheduledTime, sched.Next(now)
+}
+
+func historyLimit(limit *int32, defaultLimit int) int {
+	if limit == nil {
+		return defaultLimit
+	}
+
+	return int(*limit)
+}
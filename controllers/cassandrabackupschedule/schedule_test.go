@@ -0,0 +1,84 @@
+package cassandrabackupschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScheduleTimes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sched, err := v1alpha1.ParseBackupSchedule("0 3 * * *")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	earliest := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	scheduledTime, next := scheduleTimes(sched, earliest, earliest.Add(time.Hour))
+	g.Expect(scheduledTime).To(BeNil())
+	g.Expect(next).To(Equal(time.Date(2022, 5, 2, 3, 0, 0, 0, time.UTC)))
+
+	// only the most recent missed run is returned
+	scheduledTime, next = scheduleTimes(sched, earliest, time.Date(2022, 5, 4, 5, 0, 0, 0, time.UTC))
+	g.Expect(scheduledTime).ToNot(BeNil())
+	g.Expect(*scheduledTime).To(Equal(time.Date(2022, 5, 4, 3, 0, 0, 0, time.UTC)))
+	g.Expect(next).To(Equal(time.Date(2022, 5, 5, 3, 0, 0, 0, time.UTC)))
+
+	sched, err = v1alpha1.ParseBackupSchedule("0 0 30 2 *")
+	g.Expect(err).ToNot(HaveOccurred())
+	scheduledTime, next = scheduleTimes(sched, earliest, earliest.Add(time.Hour))
+	g.Expect(scheduledTime).To(BeNil())
+	g.Expect(next.IsZero()).To(BeTrue())
+}
+
+func TestScheduleTimesWithTimezone(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sched, err := v1alpha1.ParseBackupSchedule("CRON_TZ=Europe/Berlin 0 3 * * *")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	earliest := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	_, next := scheduleTimes(sched, earliest, earliest)
+	g.Expect(next.UTC()).To(Equal(time.Date(2022, 5, 2, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestGroupBackups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cbs := &v1alpha1.CassandraBackupSchedule{}
+	cbs.Name = "daily"
+	cbs.UID = "schedule-uid"
+
+	backup := func(name, scheduledAt, state string) v1alpha1.CassandraBackup {
+		cb := v1alpha1.CassandraBackup{}
+		cb.Name = name
+		cb.Annotations = map[string]string{v1alpha1.CassandraBackupScheduledAtAnnotation: scheduledAt}
+		cb.Status.State = state
+		cb.OwnerReferences = []metav1.OwnerReference{{UID: cbs.UID, Controller: proto.Bool(true)}}
+		return cb
+	}
+
+	notOwned := backup("other", "2022-05-01T03:00:00Z", "COMPLETED")
+	notOwned.OwnerReferences = nil
+	active, successful, failed := groupBackups(cbs, []v1alpha1.CassandraBackup{
+		backup("daily-3", "2022-05-03T03:00:00Z", "COMPLETED"),
+		backup("daily-1", "2022-05-01T03:00:00Z", "COMPLETED"),
+		backup("daily-2", "2022-05-02T03:00:00Z", "FAILED"),
+		backup("daily-4", "2022-05-04T03:00:00Z", "RUNNING"),
+		backup("daily-5", "2022-05-05T03:00:00Z", ""),
+		notOwned,
+	})
+
+	g.Expect(backupNames(active)).To(Equal([]string{"daily-4", "daily-5"}))
+	g.Expect(backupNames(successful)).To(Equal([]string{"daily-1", "daily-3"}))
+	g.Expect(backupNames(failed)).To(Equal([]string{"daily-2"}))
+}
+
+func backupNames(backups []v1alpha1.CassandraBackup) []string {
+	var backupNames []string
+	for _, backup := range backups {
+		backupNames = append(backupNames, backup.Name)
+	}
+	return backupNames
+}
@@ -0,0 +1,22 @@
+package cassandrabackupschedule
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+func (r *CassandraBackupScheduleReconciler) reconcileStatus(ctx context.Context, cbs *v1alpha1.CassandraBackupSchedule, newStatus v1alpha1.CassandraBackupScheduleStatus) error {
+	if !cmp.Equal(cbs.Status, newStatus) {
+		r.Log.Info("Updating backup schedule status")
+		r.Log.Debugf(cmp.Diff(cbs.Status, newStatus))
+		cbs.Status = newStatus
+		err := r.Status().Update(ctx, cbs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
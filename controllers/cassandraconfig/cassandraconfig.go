@@ -0,0 +1,199 @@
+// Package cassandraconfig knows which cassandra.yaml settings each Cassandra version supports
+// and translates settings to the names used by a version.
+package cassandraconfig
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type rename struct {
+	name    string
+	convert func(value interface{}) interface{}
+}
+
+// schema is the set of settings known by a Cassandra version
+type schema struct {
+	version Version
+	keys    map[string]bool
+}
+
+var (
+	// oldest first
+	schemas    []schema
+	allKeys    = make(map[string]bool)
+	legacyKeys = make(map[string]string)
+	removed    = make(map[string]bool)
+)
+
+func init() {
+	keys3_11Set := toSet(keys3_11)
+
+	keys4_0Set := toSet(keys3_11)
+	for _, key := range removedIn4_0 {
+		delete(keys4_0Set, key)
+		removed[key] = true
+	}
+	for _, key := range addedIn4_0 {
+		keys4_0Set[key] = true
+	}
+
+	keys4_1Set := make(map[string]bool, len(keys4_0Set))
+	for key := range keys4_0Set {
+		if r, renamed := renamedIn4_1[key]; renamed {
+			keys4_1Set[r.name] = true
+			legacyKeys[r.name] = key
+			continue
+		}
+		keys4_1Set[key] = true
+	}
+	for _, key := range addedIn4_1 {
+		keys4_1Set[key] = true
+	}
+
+	schemas = []schema{
+		{version: V3_11, keys: keys3_11Set},
+		{version: V4_0, keys: keys4_0Set},
+		{version: V4_1, keys: keys4_1Set},
+	}
+
+	for _, s := range schemas {
+		for key := range s.keys {
+			allKeys[key] = true
+		}
+	}
+}
+
+// schemaFor returns the settings of the newest supported version not newer than the given one.
+// Versions older than 3.11 use the 3.11 settings.
+func schemaFor(v Version) schema {
+	for i := len(schemas) - 1; i > 0; i-- {
+		if v.AtLeast(schemas[i].version) {
+			return schemas[i]
+		}
+	}
+
+	return schemas[0]
+}
+
+// CheckKey returns an error if the setting is not known by the Cassandra version, including settings it renamed or removed.
+// If the version is unknown, only settings not known by any supported version are rejected.
+func CheckKey(key string, v Version) error {
+	if !v.Known() {
+		if !allKeys[key] {
+			return fmt.Errorf("`%s` is not a known cassandra.yaml setting", key)
+		}
+		return nil
+	}
+
+	if schemaFor(v).keys[key] {
+		return nil
+	}
+
+	if r, renamed := renamedIn4_1[key]; renamed && v.AtLeast(V4_1) {
+		return fmt.Errorf("`%s` was renamed to `%s` in Cassandra %s", key, r.name, V4_1)
+	}
+
+	if removed[key] && v.AtLeast(V4_0) {
+		return fmt.Errorf("`%s` was removed in Cassandra %s", key, V4_0)
+	}
+
+	return fmt.Errorf("`%s` is not a known cassandra.yaml setting for Cassandra %s", key, v)
+}
+
+// Translatable reports whether the setting is known by the Cassandra version, either directly or by a name Translate renames
+func Translatable(key string, v Version) bool {
+	s := schemaFor(v)
+	if s.keys[key] {
+		return true
+	}
+
+	r, renamed := renamedIn4_1[key]
+	return renamed && v.AtLeast(V4_1) && s.keys[r.name]
+}
+
+// LegacyName returns the name the setting had before Cassandra 4.1, so that both names of a setting can be compared
+func LegacyName(key string) string {
+	if legacyKey, exists := legacyKeys[key]; exists {
+		return legacyKey
+	}
+
+	return key
+}
+
+// Translate renames the settings to the names used by the Cassandra version, converting their values to values with units,
+// and drops the settings the version doesn't support anymore. A setting set with its new name takes precedence over its old name.
+func Translate(config map[string]interface{}, v Version) map[string]interface{} {
+	if !v.Known() {
+		return config
+	}
+
+	translated := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if removed[key] && v.AtLeast(V4_0) {
+			continue
+		}
+
+		r, renamed := renamedIn4_1[key]
+		if !renamed || !v.AtLeast(V4_1) {
+			translated[key] = value
+			continue
+		}
+
+		if _, newNameSet := config[r.name]; newNameSet {
+			continue
+		}
+
+		if r.convert != nil {
+			value = r.convert(value)
+		}
+		translated[r.name] = value
+	}
+
+	return translated
+}
+
+func withUnit(unit string) func(value interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		number, isNumber := toFloat(value)
+		if !isNumber {
+			return value // already has a unit or is not set
+		}
+
+		return strconv.FormatFloat(number, 'f', -1, 64) + unit
+	}
+}
+
+// megabitsToBytesPerSecond converts throughputs the same way Cassandra does for the old `*_megabits_per_sec` settings
+func megabitsToBytesPerSecond(value interface{}) interface{} {
+	number, isNumber := toFloat(value)
+	if !isNumber {
+		return value
+	}
+
+	return strconv.FormatFloat(number*125000, 'f', -1, 64) + "B/s"
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	}
+
+	return 0, false
+}
+
+func toSet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+
+	return set
+}
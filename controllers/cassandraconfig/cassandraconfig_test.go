@@ -0,0 +1,108 @@
+package cassandraconfig
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseImageVersion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	testCases := []struct {
+		image           string
+		expectedVersion Version
+		expectedOk      bool
+	}{
+		{image: "cassandra:4.1.3", expectedVersion: V4_1, expectedOk: true},
+		{image: "us.icr.io/cassandra-operator/cassandra:3.11.13-0.5.0", expectedVersion: V3_11, expectedOk: true},
+		{image: "registry:5000/cassandra:4.0.7@sha256:abc", expectedVersion: V4_0, expectedOk: true},
+		{image: "registry:5000/cassandra", expectedOk: false},
+		{image: "cassandra:latest", expectedOk: false},
+		{image: "cassandra/image", expectedOk: false},
+		{image: "", expectedOk: false},
+	}
+
+	for _, tc := range testCases {
+		version, ok := ParseImageVersion(tc.image)
+		g.Expect(ok).To(Equal(tc.expectedOk), tc.image)
+		g.Expect(version).To(Equal(tc.expectedVersion), tc.image)
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	testCases := []struct {
+		key           string
+		version       Version
+		expectedError string
+	}{
+		{key: "concurrent_reads", version: V3_11},
+		{key: "concurrent_reads", version: Version{Major: 5, Minor: 0}},
+		{key: "hinted_handoff_throttle_in_kb", version: V4_0},
+		{key: "hinted_handoff_throttle", version: V4_1},
+		{key: "hinted_handoff_throttle_in_kb", version: Version{}},
+		{key: "hinted_handoff_throttle", version: Version{}},
+		{key: "concurent_reads", version: Version{}, expectedError: "`concurent_reads` is not a known cassandra.yaml setting"},
+		{key: "hinted_handoff_throttle_in_kb", version: V4_1, expectedError: "`hinted_handoff_throttle_in_kb` was renamed to `hinted_handoff_throttle` in Cassandra 4.1"},
+		{key: "hinted_handoff_throttle", version: V3_11, expectedError: "`hinted_handoff_throttle` is not a known cassandra.yaml setting for Cassandra 3.11"},
+		{key: "start_rpc", version: V4_1, expectedError: "`start_rpc` was removed in Cassandra 4.0"},
+		{key: "audit_logging_options", version: V3_11, expectedError: "`audit_logging_options` is not a known cassandra.yaml setting for Cassandra 3.11"},
+	}
+
+	for _, tc := range testCases {
+		err := CheckKey(tc.key, tc.version)
+		if tc.expectedError == "" {
+			g.Expect(err).ToNot(HaveOccurred(), tc.key+" "+tc.version.String())
+		} else {
+			g.Expect(err).To(MatchError(tc.expectedError), tc.key+" "+tc.version.String())
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := map[string]interface{}{
+		"concurrent_reads":                            float64(32),
+		"max_hint_window_in_ms":                       float64(10800000),
+		"key_cache_size_in_mb":                        nil,
+		"stream_throughput_outbound_megabits_per_sec": float64(200),
+		"enable_materialized_views":                   true,
+		"commitlog_segment_size_in_mb":                int32(32),
+		"commitlog_segment_size":                      "64MiB",
+		"rpc_port":                                    float64(9160),
+	}
+
+	g.Expect(Translate(config, V3_11)).To(Equal(config))
+	g.Expect(Translate(config, Version{})).To(Equal(config))
+	g.Expect(Translate(config, V4_0)).To(Equal(map[string]interface{}{
+		"concurrent_reads":                            float64(32),
+		"max_hint_window_in_ms":                       float64(10800000),
+		"key_cache_size_in_mb":                        nil,
+		"stream_throughput_outbound_megabits_per_sec": float64(200),
+		"enable_materialized_views":                   true,
+		"commitlog_segment_size_in_mb":                int32(32),
+		"commitlog_segment_size":                      "64MiB",
+	}))
+	g.Expect(Translate(config, V4_1)).To(Equal(map[string]interface{}{
+		"concurrent_reads":           float64(32),
+		"max_hint_window":            "10800000ms",
+		"key_cache_size":             nil,
+		"stream_throughput_outbound": "25000000B/s",
+		"materialized_views_enabled": true,
+		"commitlog_segment_size":     "64MiB",
+	}))
+}
+
+func TestTranslatable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Translatable("max_hint_window_in_ms", V4_1)).To(BeTrue())
+	g.Expect(Translatable("max_hint_window", V4_1)).To(BeTrue())
+	g.Expect(Translatable("max_hint_window", V4_0)).To(BeFalse())
+	g.Expect(Translatable("rpc_port", V4_1)).To(BeFalse())
+	g.Expect(LegacyName("max_hint_window")).To(Equal("max_hint_window_in_ms"))
+	g.Expect(LegacyName("concurrent_reads")).To(Equal("concurrent_reads"))
+}
@@ -0,0 +1,398 @@
+package cassandraconfig
+
+// keys3_11 are the cassandra.yaml settings known by Cassandra 3.11
+var keys3_11 = []string{
+	"allocate_tokens_for_keyspace",
+	"authenticator",
+	"authorizer",
+	"auto_bootstrap",
+	"auto_snapshot",
+	"back_pressure_enabled",
+	"back_pressure_strategy",
+	"batch_size_fail_threshold_in_kb",
+	"batch_size_warn_threshold_in_kb",
+	"batchlog_replay_throttle_in_kb",
+	"broadcast_address",
+	"broadcast_rpc_address",
+	"buffer_pool_use_heap_if_exhausted",
+	"cas_contention_timeout_in_ms",
+	"cdc_enabled",
+	"cdc_free_space_check_interval_ms",
+	"cdc_raw_directory",
+	"cdc_total_space_in_mb",
+	"check_for_duplicate_rows_during_compaction",
+	"check_for_duplicate_rows_during_reads",
+	"client_encryption_options",
+	"cluster_name",
+	"column_index_cache_size_in_kb",
+	"column_index_size_in_kb",
+	"commit_failure_policy",
+	"commitlog_compression",
+	"commitlog_directory",
+	"commitlog_max_compression_buffers_in_pool",
+	"commitlog_periodic_queue_size",
+	"commitlog_segment_size_in_mb",
+	"commitlog_sync",
+	"commitlog_sync_batch_window_in_ms",
+	"commitlog_sync_period_in_ms",
+	"commitlog_total_space_in_mb",
+	"compaction_large_partition_warning_threshold_mb",
+	"compaction_throughput_mb_per_sec",
+	"concurrent_compactors",
+	"concurrent_counter_writes",
+	"concurrent_materialized_view_writes",
+	"concurrent_reads",
+	"concurrent_replicates",
+	"concurrent_writes",
+	"counter_cache_keys_to_save",
+	"counter_cache_save_period",
+	"counter_cache_size_in_mb",
+	"counter_write_request_timeout_in_ms",
+	"credentials_cache_max_entries",
+	"credentials_update_interval_in_ms",
+	"credentials_validity_in_ms",
+	"cross_node_timeout",
+	"data_file_directories",
+	"disk_access_mode",
+	"disk_failure_policy",
+	"disk_optimization_estimate_percentile",
+	"disk_optimization_page_cross_chance",
+	"disk_optimization_strategy",
+	"dynamic_snitch",
+	"dynamic_snitch_badness_threshold",
+	"dynamic_snitch_reset_interval_in_ms",
+	"dynamic_snitch_update_interval_in_ms",
+	"enable_materialized_views",
+	"enable_sasi_indexes",
+	"enable_scripted_user_defined_functions",
+	"enable_user_defined_functions",
+	"enable_user_defined_functions_threads",
+	"endpoint_snitch",
+	"file_cache_round_up",
+	"file_cache_size_in_mb",
+	"gc_log_threshold_in_ms",
+	"gc_warn_threshold_in_ms",
+	"hinted_handoff_disabled_datacenters",
+	"hinted_handoff_enabled",
+	"hinted_handoff_throttle_in_kb",
+	"hints_compression",
+	"hints_directory",
+	"hints_flush_period_in_ms",
+	"incremental_backups",
+	"index_summary_capacity_in_mb",
+	"index_summary_resize_interval_in_minutes",
+	"initial_token",
+	"inter_dc_stream_throughput_outbound_megabits_per_sec",
+	"inter_dc_tcp_nodelay",
+	"internode_authenticator",
+	"internode_compression",
+	"internode_recv_buff_size_in_bytes",
+	"internode_send_buff_size_in_bytes",
+	"key_cache_keys_to_save",
+	"key_cache_save_period",
+	"key_cache_size_in_mb",
+	"listen_address",
+	"listen_interface",
+	"listen_interface_prefer_ipv6",
+	"listen_on_broadcast_address",
+	"max_hint_window_in_ms",
+	"max_hints_delivery_threads",
+	"max_hints_file_size_in_mb",
+	"max_mutation_size_in_kb",
+	"max_value_size_in_mb",
+	"memtable_allocation_type",
+	"memtable_cleanup_threshold",
+	"memtable_flush_writers",
+	"memtable_heap_space_in_mb",
+	"memtable_offheap_space_in_mb",
+	"native_transport_flush_in_batches_legacy",
+	"native_transport_max_concurrent_connections",
+	"native_transport_max_concurrent_connections_per_ip",
+	"native_transport_max_frame_size_in_mb",
+	"native_transport_max_negotiable_protocol_version",
+	"native_transport_max_threads",
+	"native_transport_port",
+	"native_transport_port_ssl",
+	"num_tokens",
+	"otc_backlog_expiration_interval_ms",
+	"otc_coalescing_enough_coalesced_messages",
+	"otc_coalescing_strategy",
+	"otc_coalescing_window_us",
+	"partitioner",
+	"permissions_cache_max_entries",
+	"permissions_update_interval_in_ms",
+	"permissions_validity_in_ms",
+	"phi_convict_threshold",
+	"prepared_statements_cache_size_mb",
+	"range_request_timeout_in_ms",
+	"read_request_timeout_in_ms",
+	"repair_session_max_tree_depth",
+	"request_scheduler",
+	"request_scheduler_id",
+	"request_scheduler_options",
+	"request_timeout_in_ms",
+	"role_manager",
+	"roles_cache_max_entries",
+	"roles_update_interval_in_ms",
+	"roles_validity_in_ms",
+	"row_cache_class_name",
+	"row_cache_keys_to_save",
+	"row_cache_save_period",
+	"row_cache_size_in_mb",
+	"rpc_address",
+	"rpc_interface",
+	"rpc_interface_prefer_ipv6",
+	"rpc_keepalive",
+	"rpc_max_threads",
+	"rpc_min_threads",
+	"rpc_port",
+	"rpc_recv_buff_size_in_bytes",
+	"rpc_send_buff_size_in_bytes",
+	"rpc_server_type",
+	"saved_caches_directory",
+	"seed_provider",
+	"server_encryption_options",
+	"slow_query_log_timeout_in_ms",
+	"snapshot_before_compaction",
+	"snapshot_on_duplicate_row_detection",
+	"ssl_storage_port",
+	"sstable_preemptive_open_interval_in_mb",
+	"start_native_transport",
+	"start_rpc",
+	"storage_port",
+	"stream_throughput_outbound_megabits_per_sec",
+	"streaming_keep_alive_period_in_secs",
+	"streaming_socket_timeout_in_ms",
+	"thrift_framed_transport_size_in_mb",
+	"thrift_prepared_statements_cache_size_mb",
+	"tombstone_failure_threshold",
+	"tombstone_warn_threshold",
+	"tracetype_query_ttl",
+	"tracetype_repair_ttl",
+	"transparent_data_encryption_options",
+	"trickle_fsync",
+	"trickle_fsync_interval_in_kb",
+	"truncate_request_timeout_in_ms",
+	"unlogged_batch_across_partitions_warn_threshold",
+	"user_defined_function_fail_timeout",
+	"user_defined_function_warn_timeout",
+	"user_function_timeout_policy",
+	"windows_timer_interval",
+	"write_request_timeout_in_ms",
+}
+
+// removedIn4_0 are the settings, mostly Thrift related, Cassandra 4.0 doesn't start with anymore
+var removedIn4_0 = []string{
+	"internode_recv_buff_size_in_bytes",
+	"internode_send_buff_size_in_bytes",
+	"request_scheduler",
+	"request_scheduler_id",
+	"request_scheduler_options",
+	"rpc_max_threads",
+	"rpc_min_threads",
+	"rpc_port",
+	"rpc_recv_buff_size_in_bytes",
+	"rpc_send_buff_size_in_bytes",
+	"rpc_server_type",
+	"start_rpc",
+	"streaming_socket_timeout_in_ms",
+	"thrift_framed_transport_size_in_mb",
+	"thrift_prepared_statements_cache_size_mb",
+}
+
+var addedIn4_0 = []string{
+	"allocate_tokens_for_local_replication_factor",
+	"audit_logging_options",
+	"auto_optimise_full_repair_streams",
+	"auto_optimise_inc_repair_streams",
+	"auto_optimise_preview_repair_streams",
+	"autocompaction_on_startup_enabled",
+	"automatic_sstable_upgrade",
+	"block_for_peers_in_remote_dcs",
+	"block_for_peers_timeout_in_secs",
+	"cache_load_timeout_seconds",
+	"commitlog_sync_group_window_in_ms",
+	"concurrent_materialized_view_builders",
+	"concurrent_validations",
+	"consecutive_message_errors_threshold",
+	"corrupted_tombstone_strategy",
+	"diagnostic_events_enabled",
+	"enable_drop_compact_storage",
+	"enable_transient_replication",
+	"flush_compression",
+	"full_query_logging_options",
+	"ideal_consistency_level",
+	"initial_range_tombstone_list_allocation_size",
+	"internode_application_receive_queue_capacity_in_bytes",
+	"internode_application_send_queue_capacity_in_bytes",
+	"internode_application_send_queue_reserve_endpoint_capacity_in_bytes",
+	"internode_application_send_queue_reserve_global_capacity_in_bytes",
+	"internode_socket_receive_buffer_size_in_bytes",
+	"internode_socket_send_buffer_size_in_bytes",
+	"internode_streaming_tcp_user_timeout_in_ms",
+	"internode_tcp_connect_timeout_in_ms",
+	"internode_tcp_user_timeout_in_ms",
+	"keyspace_count_warn_threshold",
+	"local_system_data_file_directory",
+	"max_concurrent_automatic_sstable_upgrades",
+	"native_transport_allow_older_protocols",
+	"native_transport_idle_timeout_in_ms",
+	"native_transport_max_concurrent_requests_in_bytes",
+	"native_transport_max_concurrent_requests_in_bytes_per_ip",
+	"native_transport_receive_queue_capacity_in_bytes",
+	"network_authorizer",
+	"networking_cache_size_in_mb",
+	"periodic_commitlog_sync_lag_block_in_ms",
+	"range_tombstone_list_growth_factor",
+	"repaired_data_tracking_for_partition_reads_enabled",
+	"repaired_data_tracking_for_range_reads_enabled",
+	"report_unconfirmed_repaired_data_mismatches",
+	"snapshot_on_repaired_data_mismatch",
+	"stream_entire_sstables",
+	"streaming_connections_per_host",
+	"table_count_warn_threshold",
+	"use_deterministic_table_id",
+	"use_offheap_merkle_trees",
+	"validation_preview_purge_head_start_in_sec",
+}
+
+// renamedIn4_1 are the settings Cassandra 4.1 renamed, mostly to take values with units, e.g. `max_hint_window: 3h`
+var renamedIn4_1 = map[string]rename{
+	"batch_size_fail_threshold_in_kb":                          {name: "batch_size_fail_threshold", convert: withUnit("KiB")},
+	"batch_size_warn_threshold_in_kb":                          {name: "batch_size_warn_threshold", convert: withUnit("KiB")},
+	"batchlog_replay_throttle_in_kb":                           {name: "batchlog_replay_throttle", convert: withUnit("KiB")},
+	"cache_load_timeout_seconds":                               {name: "cache_load_timeout", convert: withUnit("s")},
+	"cas_contention_timeout_in_ms":                             {name: "cas_contention_timeout", convert: withUnit("ms")},
+	"cdc_free_space_check_interval_ms":                         {name: "cdc_free_space_check_interval", convert: withUnit("ms")},
+	"cdc_total_space_in_mb":                                    {name: "cdc_total_space", convert: withUnit("MiB")},
+	"column_index_cache_size_in_kb":                            {name: "column_index_cache_size", convert: withUnit("KiB")},
+	"column_index_size_in_kb":                                  {name: "column_index_size", convert: withUnit("KiB")},
+	"commitlog_segment_size_in_mb":                             {name: "commitlog_segment_size", convert: withUnit("MiB")},
+	"commitlog_sync_group_window_in_ms":                        {name: "commitlog_sync_group_window", convert: withUnit("ms")},
+	"commitlog_sync_period_in_ms":                              {name: "commitlog_sync_period", convert: withUnit("ms")},
+	"commitlog_total_space_in_mb":                              {name: "commitlog_total_space", convert: withUnit("MiB")},
+	"compaction_large_partition_warning_threshold_mb":          {name: "compaction_large_partition_warning_threshold", convert: withUnit("MiB")},
+	"compaction_throughput_mb_per_sec":                         {name: "compaction_throughput", convert: withUnit("MiB/s")},
+	"counter_cache_size_in_mb":                                 {name: "counter_cache_size", convert: withUnit("MiB")},
+	"counter_write_request_timeout_in_ms":                      {name: "counter_write_request_timeout", convert: withUnit("ms")},
+	"credentials_update_interval_in_ms":                        {name: "credentials_update_interval", convert: withUnit("ms")},
+	"credentials_validity_in_ms":                               {name: "credentials_validity", convert: withUnit("ms")},
+	"dynamic_snitch_reset_interval_in_ms":                      {name: "dynamic_snitch_reset_interval", convert: withUnit("ms")},
+	"dynamic_snitch_update_interval_in_ms":                     {name: "dynamic_snitch_update_interval", convert: withUnit("ms")},
+	"enable_drop_compact_storage":                              {name: "drop_compact_storage_enabled"},
+	"enable_materialized_views":                                {name: "materialized_views_enabled"},
+	"enable_sasi_indexes":                                      {name: "sasi_indexes_enabled"},
+	"enable_scripted_user_defined_functions":                   {name: "scripted_user_defined_functions_enabled"},
+	"enable_transient_replication":                             {name: "transient_replication_enabled"},
+	"enable_user_defined_functions":                            {name: "user_defined_functions_enabled"},
+	"enable_user_defined_functions_threads":                    {name: "user_defined_functions_threads_enabled"},
+	"file_cache_size_in_mb":                                    {name: "file_cache_size", convert: withUnit("MiB")},
+	"gc_log_threshold_in_ms":                                   {name: "gc_log_threshold", convert: withUnit("ms")},
+	"gc_warn_threshold_in_ms":                                  {name: "gc_warn_threshold", convert: withUnit("ms")},
+	"hinted_handoff_throttle_in_kb":                            {name: "hinted_handoff_throttle", convert: withUnit("KiB")},
+	"hints_flush_period_in_ms":                                 {name: "hints_flush_period", convert: withUnit("ms")},
+	"index_summary_capacity_in_mb":                             {name: "index_summary_capacity", convert: withUnit("MiB")},
+	"index_summary_resize_interval_in_minutes":                 {name: "index_summary_resize_interval", convert: withUnit("m")},
+	"inter_dc_stream_throughput_outbound_megabits_per_sec":     {name: "inter_dc_stream_throughput_outbound", convert: megabitsToBytesPerSecond},
+	"internode_socket_receive_buffer_size_in_bytes":            {name: "internode_socket_receive_buffer_size", convert: withUnit("B")},
+	"internode_socket_send_buffer_size_in_bytes":               {name: "internode_socket_send_buffer_size", convert: withUnit("B")},
+	"internode_streaming_tcp_user_timeout_in_ms":               {name: "internode_streaming_tcp_user_timeout", convert: withUnit("ms")},
+	"internode_tcp_connect_timeout_in_ms":                      {name: "internode_tcp_connect_timeout", convert: withUnit("ms")},
+	"internode_tcp_user_timeout_in_ms":                         {name: "internode_tcp_user_timeout", convert: withUnit("ms")},
+	"key_cache_size_in_mb":                                     {name: "key_cache_size", convert: withUnit("MiB")},
+	"max_hint_window_in_ms":                                    {name: "max_hint_window", convert: withUnit("ms")},
+	"max_hints_file_size_in_mb":                                {name: "max_hints_file_size", convert: withUnit("MiB")},
+	"max_mutation_size_in_kb":                                  {name: "max_mutation_size", convert: withUnit("KiB")},
+	"max_value_size_in_mb":                                     {name: "max_value_size", convert: withUnit("MiB")},
+	"memtable_heap_space_in_mb":                                {name: "memtable_heap_space", convert: withUnit("MiB")},
+	"memtable_offheap_space_in_mb":                             {name: "memtable_offheap_space", convert: withUnit("MiB")},
+	"native_transport_idle_timeout_in_ms":                      {name: "native_transport_idle_timeout", convert: withUnit("ms")},
+	"native_transport_max_concurrent_requests_in_bytes":        {name: "native_transport_max_request_data_in_flight", convert: withUnit("B")},
+	"native_transport_max_concurrent_requests_in_bytes_per_ip": {name: "native_transport_max_request_data_in_flight_per_ip", convert: withUnit("B")},
+	"native_transport_max_frame_size_in_mb":                    {name: "native_transport_max_frame_size", convert: withUnit("MiB")},
+	"native_transport_receive_queue_capacity_in_bytes":         {name: "native_transport_receive_queue_capacity", convert: withUnit("B")},
+	"networking_cache_size_in_mb":                              {name: "networking_cache_size", convert: withUnit("MiB")},
+	"periodic_commitlog_sync_lag_block_in_ms":                  {name: "periodic_commitlog_sync_lag_block", convert: withUnit("ms")},
+	"permissions_update_interval_in_ms":                        {name: "permissions_update_interval", convert: withUnit("ms")},
+	"permissions_validity_in_ms":                               {name: "permissions_validity", convert: withUnit("ms")},
+	"prepared_statements_cache_size_mb":                        {name: "prepared_statements_cache_size", convert: withUnit("MiB")},
+	"range_request_timeout_in_ms":                              {name: "range_request_timeout", convert: withUnit("ms")},
+	"read_request_timeout_in_ms":                               {name: "read_request_timeout", convert: withUnit("ms")},
+	"request_timeout_in_ms":                                    {name: "request_timeout", convert: withUnit("ms")},
+	"roles_update_interval_in_ms":                              {name: "roles_update_interval", convert: withUnit("ms")},
+	"roles_validity_in_ms":                                     {name: "roles_validity", convert: withUnit("ms")},
+	"row_cache_size_in_mb":                                     {name: "row_cache_size", convert: withUnit("MiB")},
+	"slow_query_log_timeout_in_ms":                             {name: "slow_query_log_timeout", convert: withUnit("ms")},
+	"sstable_preemptive_open_interval_in_mb":                   {name: "sstable_preemptive_open_interval", convert: withUnit("MiB")},
+	"stream_throughput_outbound_megabits_per_sec":              {name: "stream_throughput_outbound", convert: megabitsToBytesPerSecond},
+	"streaming_keep_alive_period_in_secs":                      {name: "streaming_keep_alive_period", convert: withUnit("s")},
+	"trickle_fsync_interval_in_kb":                             {name: "trickle_fsync_interval", convert: withUnit("KiB")},
+	"truncate_request_timeout_in_ms":                           {name: "truncate_request_timeout", convert: withUnit("ms")},
+	"user_defined_function_fail_timeout":                       {name: "user_defined_functions_fail_timeout", convert: withUnit("ms")},
+	"user_defined_function_warn_timeout":                       {name: "user_defined_functions_warn_timeout", convert: withUnit("ms")},
+	"validation_preview_purge_head_start_in_sec":               {name: "validation_preview_purge_head_start", convert: withUnit("s")},
+	"write_request_timeout_in_ms":                              {name: "write_request_timeout", convert: withUnit("ms")},
+}
+
+var addedIn4_1 = []string{
+	"allow_filtering_enabled",
+	"auth_cache_warming_enabled",
+	"auto_hints_cleanup_enabled",
+	"available_processors",
+	"client_error_reporting_exclusions",
+	"collection_size_warn_threshold",
+	"coordinator_read_size_fail_threshold",
+	"coordinator_read_size_warn_threshold",
+	"data_disk_usage_max_disk_size",
+	"data_disk_usage_percentage_fail_threshold",
+	"data_disk_usage_percentage_warn_threshold",
+	"default_keyspace_rf",
+	"denylist_range_reads_enabled",
+	"denylist_reads_enabled",
+	"denylist_writes_enabled",
+	"drop_truncate_table_enabled",
+	"dump_heap_on_uncaught_exception",
+	"entire_sstable_inter_dc_stream_throughput_outbound",
+	"entire_sstable_stream_throughput_outbound",
+	"failure_detector",
+	"fields_per_udt_warn_threshold",
+	"group_by_enabled",
+	"heap_dump_path",
+	"in_select_cartesian_product_warn_threshold",
+	"internode_error_reporting_exclusions",
+	"items_per_collection_warn_threshold",
+	"keyspaces_fail_threshold",
+	"keyspaces_warn_threshold",
+	"local_read_size_fail_threshold",
+	"local_read_size_warn_threshold",
+	"materialized_views_per_table_fail_threshold",
+	"materialized_views_per_table_warn_threshold",
+	"minimum_replication_factor_fail_threshold",
+	"minimum_replication_factor_warn_threshold",
+	"native_transport_max_requests_per_second",
+	"native_transport_rate_limiting_enabled",
+	"page_size_fail_threshold",
+	"page_size_warn_threshold",
+	"partition_denylist_enabled",
+	"partition_keys_in_select_fail_threshold",
+	"partition_keys_in_select_warn_threshold",
+	"paxos_repair_enabled",
+	"paxos_state_purging",
+	"paxos_variant",
+	"read_before_write_list_operations_enabled",
+	"read_thresholds_enabled",
+	"row_index_read_size_fail_threshold",
+	"row_index_read_size_warn_threshold",
+	"secondary_indexes_enabled",
+	"secondary_indexes_per_table_fail_threshold",
+	"secondary_indexes_per_table_warn_threshold",
+	"startup_checks",
+	"stream_transfer_task_timeout",
+	"tables_fail_threshold",
+	"tables_warn_threshold",
+	"transfer_hints_on_decommission",
+	"traverse_auth_from_root",
+	"uncompressed_tables_enabled",
+	"user_timestamps_enabled",
+	"uuid_sstable_identifiers_enabled",
+}
@@ -0,0 +1,63 @@
+package cassandraconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is the major and minor version of Cassandra. The zero value means the version is unknown.
+type Version struct {
+	Major int
+	Minor int
+}
+
+var (
+	V3_11 = Version{Major: 3, Minor: 11}
+	V4_0  = Version{Major: 4, Minor: 0}
+	V4_1  = Version{Major: 4, Minor: 1}
+)
+
+var imageTagVersionRegexp = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+// ParseImageVersion detects the Cassandra version from the tag of the image, e.g. `cassandra:4.1.3` or `cassandra:3.11.13-0.5.0`
+func ParseImageVersion(image string) (Version, bool) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") { // no tag, the colon belongs to the registry port
+		return Version{}, false
+	}
+
+	match := imageTagVersionRegexp.FindStringSubmatch(image[i+1:])
+	if match == nil {
+		return Version{}, false
+	}
+
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	if major == 0 {
+		return Version{}, false
+	}
+
+	return Version{Major: major, Minor: minor}, true
+}
+
+func (v Version) Known() bool {
+	return v.Major > 0
+}
+
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+
+	return v.Minor >= other.Minor
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
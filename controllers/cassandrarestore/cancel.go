@@ -0,0 +1,176 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// CancelFinalizer blocks the removal of a running CassandraRestore until its Icarus operations and load jobs are cancelled
+const CancelFinalizer = "db.ibm.com/cancel-restore"
+
+// reconcileFinalizer keeps the cancel finalizer while the restore is running, so that deleting the restore cancels it
+func (r *CassandraRestoreReconciler) reconcileFinalizer(ctx context.Context, cr *v1alpha1.CassandraRestore) error {
+	required := !icarus.Finished(cr.Status.State)
+	hasFinalizer := controllerutil.ContainsFinalizer(cr, CancelFinalizer)
+	if required && !hasFinalizer {
+		controllerutil.AddFinalizer(cr, CancelFinalizer)
+		return errors.Wrap(r.Update(ctx, cr), "failed to add finalizer")
+	}
+
+	if !required && hasFinalizer {
+		controllerutil.RemoveFinalizer(cr, CancelFinalizer)
+		return errors.Wrap(r.Update(ctx, cr), "failed to remove finalizer")
+	}
+
+	return nil
+}
+
+// reconcileCancel deletes the load jobs of the restore and cancels its Icarus operations on all nodes.
+// The restore stays in the CANCELLING state until every node, including the nodes that are not ready,
+// has confirmed that its operations have stopped. The restore is marked as cancelled afterwards.
+func (r *CassandraRestoreReconciler) reconcileCancel(ctx context.Context, cr *v1alpha1.CassandraRestore) (ctrl.Result, error) {
+	if cr.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		// the provisioned volumes are kept, the cluster starts with whatever was restored
+		return ctrl.Result{}, r.completeCancel(ctx, cr)
+	}
+
+	jobList := &batchv1.JobList{}
+	err := r.List(ctx, jobList, client.InNamespace(cr.Namespace), client.MatchingLabels{v1alpha1.CassandraRestoreLabel: cr.Name})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list load jobs")
+	}
+
+	for i, job := range jobList.Items {
+		r.Log.Infof("Deleting load job %s", job.Name)
+		err = r.Delete(ctx, &jobList.Items[i], client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !kerrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete load job %s", job.Name)
+		}
+	}
+
+	cc := &v1alpha1.CassandraCluster{}
+	err = r.Get(ctx, types.NamespacedName{Name: cr.Spec.CassandraCluster, Namespace: cr.Namespace}, cc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			r.Log.Infof("Cluster %s of restore %s/%s not found, there's nothing to cancel", cr.Spec.CassandraCluster, cr.Namespace, cr.Name)
+			return ctrl.Result{}, r.completeCancel(ctx, cr)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err = r.setCancelling(ctx, cr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// stops replaying the commit logs, the pods are restarted without them
+	err = r.setCommitLogRestorePointInTime(ctx, cc, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	snapshotTag := restoreSnapshotTag(cr)
+	running := false
+	for i, pod := range pods {
+		ic := r.IcarusClient(coordinator.URL(cc, &pods[i]))
+		icarusRestores, err := ic.Restores(ctx)
+		if err != nil {
+			r.Log.Warnf("Failed to get restores from pod %s, waiting for the pod to confirm the cancellation: %s", pod.Name, err.Error())
+			running = true
+			continue
+		}
+
+		for _, icarusRestore := range icarusRestores {
+			if icarusRestore.SnapshotTag != snapshotTag || icarus.Finished(icarusRestore.State) {
+				continue
+			}
+
+			running = true
+			r.Log.Infof("Cancelling restore operation %s on pod %s", icarusRestore.Id, pod.Name)
+			err = ic.Cancel(ctx, icarusRestore.Id)
+			if err != nil {
+				r.Log.Warnf("Failed to cancel restore operation %s on pod %s: %s", icarusRestore.Id, pod.Name, err.Error())
+			}
+		}
+	}
+
+	if running {
+		r.Log.Infof("Waiting for the operations of restore %s/%s to stop", cr.Namespace, cr.Name)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	return ctrl.Result{}, r.completeCancel(ctx, cr)
+}
+
+// setCancelling moves the restore to the CANCELLING state
+func (r *CassandraRestoreReconciler) setCancelling(ctx context.Context, cr *v1alpha1.CassandraRestore) error {
+	if cr.Status.State == icarus.StateCancelling {
+		return nil
+	}
+
+	r.Log.Infof("Cancelling restore %s/%s", cr.Namespace, cr.Name)
+	cr.Status.State = icarus.StateCancelling
+	icarus.SetConditions(&cr.Status.Conditions, "Restore", cr.Status.State, cr.Generation)
+	return errors.Wrap(r.Status().Update(ctx, cr), "failed to update restore status")
+}
+
+// completeCancel moves the restore to the CANCELLED state and releases the cancel finalizer
+func (r *CassandraRestoreReconciler) completeCancel(ctx context.Context, cr *v1alpha1.CassandraRestore) error {
+	if cr.Status.State != icarus.StateCancelled {
+		cr.Status.State = icarus.StateCancelled
+		cr.Status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		icarus.SetConditions(&cr.Status.Conditions, "Restore", cr.Status.State, cr.Generation)
+		err := r.Status().Update(ctx, cr)
+		if err != nil {
+			return errors.Wrap(err, "failed to update restore status")
+		}
+
+		msg := fmt.Sprintf("Restore %s cancelled", cr.Name)
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventRestoreCancelled, msg)
+	}
+
+	if !controllerutil.ContainsFinalizer(cr, CancelFinalizer) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(cr, CancelFinalizer)
+	return errors.Wrap(r.Update(ctx, cr), "failed to remove finalizer")
+}
+
+// restoreSnapshotTag returns the snapshot tag the Icarus operations of the restore have been created with
+func restoreSnapshotTag(cr *v1alpha1.CassandraRestore) string {
+	if len(cr.Spec.SnapshotTag) != 0 {
+		return cr.Spec.SnapshotTag
+	}
+
+	if len(cr.Spec.CassandraBackup) != 0 {
+		return cr.Spec.CassandraBackup
+	}
+
+	if cr.Spec.BackupCatalog != nil {
+		return cr.Spec.BackupCatalog.SnapshotTag
+	}
+
+	return cr.Status.CassandraBackup
+}
@@ -0,0 +1,69 @@
+package cassandrarestore
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// catalogBackup returns the backup referenced in a CassandraBackupCatalog as a CassandraBackup,
+// so that it's restored the same way as a backup that still has its resource. Returns false if the backup is not in the catalog.
+func (r *CassandraRestoreReconciler) catalogBackup(ctx context.Context, cr *v1alpha1.CassandraRestore) (*v1alpha1.CassandraBackup, bool, error) {
+	cbc := &v1alpha1.CassandraBackupCatalog{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.BackupCatalog.Name, Namespace: cr.Namespace}, cbc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "failed to get backup catalog %s", cr.Spec.BackupCatalog.Name)
+	}
+
+	backup, found := cbc.FindBackup(cr.Spec.BackupCatalog.SnapshotTag, cr.Spec.SchemaVersion)
+	if !found {
+		return nil, false, nil
+	}
+
+	return backupFromCatalog(cbc, backup), true, nil
+}
+
+func backupFromCatalog(cbc *v1alpha1.CassandraBackupCatalog, backup v1alpha1.CatalogBackup) *v1alpha1.CassandraBackup {
+	cb := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: backup.SnapshotTag, Namespace: cbc.Namespace},
+		Spec: v1alpha1.CassandraBackupSpec{
+			CassandraCluster:       cbc.SourceClusterName(),
+			StorageLocation:        cbc.Spec.StorageLocation,
+			SecretName:             cbc.Spec.SecretName,
+			SnapshotTag:            backup.SnapshotTag,
+			Insecure:               cbc.Spec.Insecure,
+			SkipBucketVerification: cbc.Spec.SkipBucketVerification,
+		},
+		Status: v1alpha1.CassandraBackupStatus{
+			State:          icarus.StateCompleted,
+			Progress:       100,
+			SnapshotTag:    backup.Name,
+			SchemaVersion:  backup.SchemaVersion,
+			StartTime:      backup.Time,
+			CompletionTime: backup.Time,
+		},
+	}
+
+	// the key is not known by the catalog, it has to be set in the restore
+	if backup.Encrypted {
+		cb.Spec.Encryption = &v1alpha1.BackupEncryption{}
+	}
+
+	for _, dc := range backup.DCs {
+		for _, node := range dc.Nodes {
+			cb.Status.Nodes = append(cb.Status.Nodes, v1alpha1.NodeProgress{Name: node, State: icarus.StateCompleted, Progress: 100})
+		}
+	}
+
+	return cb
+}
@@ -0,0 +1,57 @@
+package cassandrarestore
+
+import (
+	"testing"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackupFromCatalog(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cbc := &v1alpha1.CassandraBackupCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog", Namespace: "default"},
+		Spec: v1alpha1.CassandraBackupCatalogSpec{
+			CassandraCluster: "staging",
+			SourceCluster:    "prod",
+			StorageLocation:  "s3://bucket",
+			SecretName:       "storage-credentials",
+		},
+		Status: v1alpha1.CassandraBackupCatalogStatus{
+			Backups: []v1alpha1.CatalogBackup{
+				{Name: "daily-schema1-1", SnapshotTag: "daily", SchemaVersion: "schema1"},
+				{Name: "daily-schema2-2", SnapshotTag: "daily", SchemaVersion: "schema2", DCs: []v1alpha1.CatalogDC{
+					{Name: "dc1", Nodes: []string{"prod-cassandra-dc1-rack1-0", "prod-cassandra-dc1-rack1-1"}},
+				}},
+			},
+		},
+	}
+
+	backup, found := cbc.FindBackup("daily", "")
+	g.Expect(found).To(BeTrue())
+	g.Expect(backup.Name).To(Equal("daily-schema2-2"))
+
+	backup, found = cbc.FindBackup("daily", "schema1")
+	g.Expect(found).To(BeTrue())
+	g.Expect(backup.Name).To(Equal("daily-schema1-1"))
+
+	_, found = cbc.FindBackup("weekly", "")
+	g.Expect(found).To(BeFalse())
+
+	cb := backupFromCatalog(cbc, cbc.Status.Backups[1])
+	g.Expect(cb.Name).To(Equal("daily"))
+	g.Expect(cb.Spec.CassandraCluster).To(Equal("prod"))
+	g.Expect(cb.Spec.StorageLocation).To(Equal("s3://bucket"))
+	g.Expect(cb.Spec.SecretName).To(Equal("storage-credentials"))
+	g.Expect(cb.Status.State).To(Equal(icarus.StateCompleted))
+	g.Expect(cb.Status.SnapshotTag).To(Equal("daily-schema2-2"))
+	g.Expect(cb.Status.Nodes).To(HaveLen(2))
+	g.Expect(cb.Status.Nodes[1].Name).To(Equal("prod-cassandra-dc1-rack1-1"))
+	g.Expect(cb.Spec.Encryption).To(BeNil())
+
+	cbc.Status.Backups[1].Encrypted = true
+	cb = backupFromCatalog(cbc, cbc.Status.Backups[1])
+	g.Expect(cb.Spec.Encryption).ToNot(BeNil())
+}
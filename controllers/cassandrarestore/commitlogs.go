@@ -0,0 +1,291 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// A commit log segment can contain mutations older than the segment itself, as mutations are written to it until it's full.
+// Segments created shortly before the snapshot are downloaded as well so that no mutations are missed.
+const commitLogSegmentMargin = time.Hour
+
+// pointInTimeBackup returns the name of the backup restored by a point-in-time restore or an empty string if there's none.
+// The chosen backup is kept in the status, so that the same backup is used until the restore is done.
+func (r *CassandraRestoreReconciler) pointInTimeBackup(ctx context.Context, cr *v1alpha1.CassandraRestore) (string, error) {
+	if len(cr.Status.CassandraBackup) != 0 {
+		return cr.Status.CassandraBackup, nil
+	}
+
+	backups := &v1alpha1.CassandraBackupList{}
+	err := r.List(ctx, backups, client.InNamespace(cr.Namespace))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list backups")
+	}
+
+	backup := latestBackupBefore(backups.Items, cr.Spec.CassandraCluster, cr.Spec.RestorePointInTime.Time)
+	if backup == nil {
+		return "", nil
+	}
+
+	cr.Status.CassandraBackup = backup.Name
+	err = r.Status().Update(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+
+	return backup.Name, nil
+}
+
+// latestBackupBefore returns the latest completed backup of the whole cluster which was started before the point in time
+func latestBackupBefore(backups []v1alpha1.CassandraBackup, clusterName string, pointInTime time.Time) *v1alpha1.CassandraBackup {
+	var latest *v1alpha1.CassandraBackup
+	for i, backup := range backups {
+		if backup.Spec.CassandraCluster != clusterName || backup.Status.State != icarus.StateCompleted {
+			continue
+		}
+
+		if len(backup.Spec.DC) != 0 || len(backup.Spec.Entities) != 0 {
+			continue // the whole cluster is restored, partial backups can't be used
+		}
+
+		if backup.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+			continue // volume snapshots are restored into a new cluster, not by Icarus
+		}
+
+		if snapshotTime(&backup).After(pointInTime) {
+			continue
+		}
+
+		if latest == nil || snapshotTime(&backup).After(snapshotTime(latest)) {
+			latest = &backups[i]
+		}
+	}
+
+	return latest
+}
+
+// snapshotTime returns the time the snapshot of the backup was taken
+func snapshotTime(backup *v1alpha1.CassandraBackup) time.Time {
+	if backup.Status.StartTime != nil {
+		return backup.Status.StartTime.Time
+	}
+
+	return backup.CreationTimestamp.Time
+}
+
+// reconcileCommitLogReplay replays the archived commit logs once the snapshot of a point-in-time restore has been restored.
+// Icarus downloads the commit logs on each node, Cassandra replays them up to the point in time when it's restarted.
+func (r *CassandraRestoreReconciler) reconcileCommitLogReplay(ctx context.Context, cc *v1alpha1.CassandraCluster,
+	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup) (ctrl.Result, error) {
+	pointInTime := cr.Spec.RestorePointInTime.UTC().Format(time.RFC3339)
+	switch cr.Status.CommitLogReplay {
+	case "":
+		r.Log.Infof("Snapshot restored, downloading the commit logs of cluster %s", cc.Name)
+		cr.Status.CommitLogReplay = v1alpha1.CommitLogReplayDownloading
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, r.Status().Update(ctx, cr)
+	case v1alpha1.CommitLogReplayDownloading:
+		downloaded, err := r.downloadCommitLogs(ctx, cc, cr, cb)
+		if err != nil || !downloaded {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err
+		}
+
+		err = r.setCommitLogRestorePointInTime(ctx, cc, pointInTime)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		msg := fmt.Sprintf("Commit logs downloaded. Restarting the Cassandra pods to replay them up to %s", pointInTime)
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventCommitLogReplay, msg)
+		cr.Status.CommitLogReplay = v1alpha1.CommitLogReplayReplaying
+		cr.Status.CommitLogReplayStartTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, r.Status().Update(ctx, cr)
+	case v1alpha1.CommitLogReplayReplaying:
+		replayed, err := r.commitLogsReplayed(ctx, cc, cr.Status.CommitLogReplayStartTime)
+		if err != nil || !replayed {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err
+		}
+
+		// restarts the pods again to stop restoring commit logs on startup
+		err = r.setCommitLogRestorePointInTime(ctx, cc, "")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		msg := fmt.Sprintf("Commit logs replayed up to %s", pointInTime)
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventCommitLogReplay, msg)
+		cr.Status.CommitLogReplay = v1alpha1.CommitLogReplayCompleted
+		cr.Status.State = icarus.StateCompleted
+		cr.Status.Progress = 100
+		cr.Status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		icarus.SetConditions(&cr.Status.Conditions, "Restore", cr.Status.State, cr.Generation)
+		return ctrl.Result{}, r.Status().Update(ctx, cr)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// downloadCommitLogs has the Icarus sidecar of each pod download the commit logs archived by its node.
+// Returns true once all downloads have completed.
+func (r *CassandraRestoreReconciler) downloadCommitLogs(ctx context.Context, cc *v1alpha1.CassandraCluster,
+	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup) (bool, error) {
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return false, err
+	}
+
+	downloaded := len(pods) != 0
+	for i, pod := range pods {
+		if !coordinator.Ready(pod) {
+			downloaded = false
+			continue
+		}
+
+		ic := r.IcarusClient(coordinator.URL(cc, &pods[i]))
+		reqCtx, cancel := context.WithTimeout(ctx, coordinator.NodeRequestTimeout)
+		commitLogRestores, err := ic.CommitLogRestores(reqCtx)
+		cancel()
+		if err != nil {
+			r.Log.Warnf("Failed to get commit log downloads from pod %s: %s", pod.Name, err.Error())
+			downloaded = false
+			continue
+		}
+
+		restoreReq := createCommitLogRestoreReq(cc, cr, cb, pod)
+		commitLogRestore, found := findCommitLogRestore(commitLogRestores, restoreReq)
+		if found && commitLogRestore.State == icarus.StateCompleted {
+			continue
+		}
+
+		downloaded = false
+		if found && commitLogRestore.State != icarus.StateFailed {
+			continue
+		}
+
+		if found {
+			var restoreErrors []string
+			for _, restoreError := range commitLogRestore.Errors {
+				restoreErrors = append(restoreErrors, restoreError.Message)
+			}
+			errMsg := fmt.Sprintf("Commit log download on pod %s failed: %s. Retrying...", pod.Name, strings.Join(restoreErrors, "; "))
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCommitLogRestoreFailed, errMsg)
+		}
+
+		reqCtx, cancel = context.WithTimeout(ctx, coordinator.NodeRequestTimeout)
+		err = ic.RestoreCommitLogs(reqCtx, restoreReq)
+		cancel()
+		if err != nil {
+			r.Log.Warnf("Failed to send commit log download request to pod %s: %s", pod.Name, err.Error())
+			continue
+		}
+
+		r.Log.Infof("Commit log download request sent to pod %s", pod.Name)
+	}
+
+	return downloaded, nil
+}
+
+func createCommitLogRestoreReq(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore,
+	cb *v1alpha1.CassandraBackup, pod v1.Pod) icarus.CommitLogRestoreRequest {
+	archiving := cc.Spec.Cassandra.CommitLogArchiving
+	restoreReq := icarus.CommitLogRestoreRequest{
+		StorageLocation:        icarus.CommitLogStorageLocation(archiving.StorageLocation, cc.Name, pod.Labels[v1alpha1.CassandraClusterDC], pod.Name),
+		CommitlogDownloadDir:   v1alpha1.CommitLogRestoreDir,
+		TimestampEnd:           cr.Spec.RestorePointInTime.UnixMilli(),
+		ConcurrentConnections:  cr.Spec.ConcurrentConnections,
+		K8sNamespace:           cc.Namespace,
+		K8sSecretName:          archiving.SecretName,
+		Insecure:               cr.Spec.Insecure,
+		SkipBucketVerification: cr.Spec.SkipBucketVerification,
+	}
+
+	// without a CassandraBackup the snapshot time is unknown, so all commit logs up to the point in time are downloaded
+	if len(cb.Name) != 0 {
+		restoreReq.TimestampStart = snapshotTime(cb).Add(-commitLogSegmentMargin).UnixMilli()
+	}
+
+	if restoreReq.ConcurrentConnections == 0 {
+		restoreReq.ConcurrentConnections = 10
+	}
+
+	return restoreReq
+}
+
+// findCommitLogRestore returns the most recent commit log download that matches the request
+func findCommitLogRestore(commitLogRestores []icarus.CommitLogRestore, restoreReq icarus.CommitLogRestoreRequest) (icarus.CommitLogRestore, bool) {
+	var commitLogRestore icarus.CommitLogRestore
+	found := false
+	for _, item := range commitLogRestores {
+		if item.StorageLocation != restoreReq.StorageLocation || item.TimestampStart != restoreReq.TimestampStart ||
+			item.TimestampEnd != restoreReq.TimestampEnd {
+			continue
+		}
+
+		if found && item.CreationTime < commitLogRestore.CreationTime {
+			continue
+		}
+
+		commitLogRestore = item
+		found = true
+	}
+
+	return commitLogRestore, found
+}
+
+// setCommitLogRestorePointInTime sets the point in time Cassandra replays the downloaded commit logs up to.
+// The Cassandra pods are restarted by the CassandraCluster controller on change. Removes the annotation if pointInTime is empty.
+func (r *CassandraRestoreReconciler) setCommitLogRestorePointInTime(ctx context.Context, cc *v1alpha1.CassandraCluster, pointInTime string) error {
+	if cc.Annotations[v1alpha1.CommitLogRestorePointInTimeAnnotation] == pointInTime {
+		return nil
+	}
+
+	patch := client.MergeFrom(cc.DeepCopy())
+	if len(pointInTime) == 0 {
+		delete(cc.Annotations, v1alpha1.CommitLogRestorePointInTimeAnnotation)
+	} else {
+		metav1.SetMetaDataAnnotation(&cc.ObjectMeta, v1alpha1.CommitLogRestorePointInTimeAnnotation, pointInTime)
+	}
+
+	err := r.Patch(ctx, cc, patch)
+	if err != nil {
+		return errors.Wrap(err, "failed to update the commit log restore point in time")
+	}
+
+	return nil
+}
+
+// commitLogsReplayed returns true once all Cassandra pods have been restarted after the replay was started and are ready again
+func (r *CassandraRestoreReconciler) commitLogsReplayed(ctx context.Context, cc *v1alpha1.CassandraCluster, replayStartTime *metav1.Time) (bool, error) {
+	if !cc.Status.Ready || replayStartTime == nil {
+		return false, nil
+	}
+
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return false, err
+	}
+
+	for _, pod := range pods {
+		if pod.CreationTimestamp.Before(replayStartTime) || !coordinator.Ready(pod) {
+			return false, nil
+		}
+	}
+
+	return len(pods) != 0, nil
+}
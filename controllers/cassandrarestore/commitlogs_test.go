@@ -0,0 +1,62 @@
+package cassandrarestore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLatestBackupBefore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	pointInTime := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	backup := func(name string, startTime time.Time, mutate func(cb *v1alpha1.CassandraBackup)) v1alpha1.CassandraBackup {
+		cb := v1alpha1.CassandraBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.CassandraBackupSpec{CassandraCluster: "test"},
+			Status: v1alpha1.CassandraBackupStatus{
+				State:     icarus.StateCompleted,
+				StartTime: &metav1.Time{Time: startTime},
+			},
+		}
+		if mutate != nil {
+			mutate(&cb)
+		}
+		return cb
+	}
+
+	backups := []v1alpha1.CassandraBackup{
+		backup("older", pointInTime.Add(-48*time.Hour), nil),
+		backup("latest", pointInTime.Add(-24*time.Hour), nil),
+		backup("after", pointInTime.Add(time.Hour), nil),
+		backup("failed", pointInTime.Add(-time.Hour), func(cb *v1alpha1.CassandraBackup) { cb.Status.State = icarus.StateFailed }),
+		backup("partial", pointInTime.Add(-time.Hour), func(cb *v1alpha1.CassandraBackup) { cb.Spec.Entities = "ks1" }),
+		backup("other-cluster", pointInTime.Add(-time.Hour), func(cb *v1alpha1.CassandraBackup) { cb.Spec.CassandraCluster = "other" }),
+		backup("volume-snapshot", pointInTime.Add(-time.Hour), func(cb *v1alpha1.CassandraBackup) { cb.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot }),
+	}
+
+	g.Expect(latestBackupBefore(backups, "test", pointInTime).Name).To(Equal("latest"))
+	g.Expect(latestBackupBefore(backups, "test", pointInTime.Add(-25*time.Hour)).Name).To(Equal("older"))
+	g.Expect(latestBackupBefore(backups, "test", pointInTime.Add(-72*time.Hour))).To(BeNil())
+}
+
+func TestFindCommitLogRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	restoreReq := icarus.CommitLogRestoreRequest{StorageLocation: "s3://bucket/test/dc1/test-cassandra-dc1-0", TimestampEnd: 1000}
+	commitLogRestores := []icarus.CommitLogRestore{
+		{ID: "other-node", StorageLocation: "s3://bucket/test/dc1/test-cassandra-dc1-1", TimestampEnd: 1000, CreationTime: "2022-05-01T12:00:00Z"},
+		{ID: "failed", StorageLocation: restoreReq.StorageLocation, TimestampEnd: 1000, CreationTime: "2022-05-01T12:00:00Z", State: icarus.StateFailed},
+		{ID: "retry", StorageLocation: restoreReq.StorageLocation, TimestampEnd: 1000, CreationTime: "2022-05-01T12:01:00Z", State: icarus.StateRunning},
+		{ID: "other-point-in-time", StorageLocation: restoreReq.StorageLocation, TimestampEnd: 2000, CreationTime: "2022-05-01T12:02:00Z"},
+	}
+
+	commitLogRestore, found := findCommitLogRestore(commitLogRestores, restoreReq)
+	g.Expect(found).To(BeTrue())
+	g.Expect(commitLogRestore.ID).To(Equal("retry"))
+
+	_, found = findCommitLogRestore(commitLogRestores[:1], restoreReq)
+	g.Expect(found).To(BeFalse())
+}
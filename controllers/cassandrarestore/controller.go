@@ -3,17 +3,23 @@ package cassandrarestore
 import (
 	"context"
 	"fmt"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 
+	"github.com/gocql/gocql"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
-	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/jobs"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +28,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -29,15 +36,20 @@ import (
 // CassandraRestoreReconciler reconciles a CassandraRestore object
 type CassandraRestoreReconciler struct {
 	client.Client
-	Log          *zap.SugaredLogger
-	Scheme       *runtime.Scheme
-	Cfg          config.Config
-	Events       *events.EventRecorder
-	IcarusClient func(coordinatorPodURL string) icarus.Icarus
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	IcarusClient  func(coordinatorPodURL string) icarus.Icarus
+	CqlClient     func(cluster *gocql.ClusterConfig) (cql.CqlClient, error)
+	Jobs          *jobs.JobManager
+	StorageClient func(secret *v1.Secret, insecure bool) storage.Storage
 }
 
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrarestores,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrarestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create
 
 func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	cr := &v1alpha1.CassandraRestore{}
@@ -49,11 +61,32 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	if cr.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(cr, CancelFinalizer) {
+			return r.handleReconcileErr(r.reconcileCancel(ctx, cr))
+		}
+		return ctrl.Result{}, nil
+	}
+
+	err = r.reconcileFinalizer(ctx, cr)
+	if err != nil {
+		return r.handleReconcileErr(ctrl.Result{}, err)
+	}
+
 	if cr.Status.State == icarus.StateCompleted {
 		r.Log.Debugf("Restore %s is completed", cr.Name)
 		return ctrl.Result{}, nil
 	}
 
+	if cr.Status.State == icarus.StateCancelled {
+		r.Log.Debugf("Restore %s is cancelled", cr.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if cr.Spec.Cancel && !icarus.Finished(cr.Status.State) {
+		return r.handleReconcileErr(r.reconcileCancel(ctx, cr))
+	}
+
 	cc := &v1alpha1.CassandraCluster{}
 	err = r.Get(ctx, types.NamespacedName{Name: cr.Spec.CassandraCluster, Namespace: cr.Namespace}, cc)
 	if err != nil {
@@ -66,59 +99,194 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	if !cc.Status.Ready {
-		r.Log.Warnf("CassandraCluster %s/%s is not ready. Not starting backup, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+	if cr.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		return r.handleReconcileErr(r.reconcileVolumeSnapshotRestore(ctx, cr, cc))
+	}
+
+	if !preflightPassed(cr) {
+		passed, err := r.reconcilePreflightChecks(ctx, cc, cr)
+		if err != nil || !passed {
+			return r.handleReconcileErr(ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err)
+		}
+	} else if !cc.Status.Ready {
+		r.Log.Warnf("CassandraCluster %s/%s is not ready. Not proceeding with the restore, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
+	backupName := cr.Spec.CassandraBackup
+	if cr.Spec.RestorePointInTime != nil {
+		if cc.Spec.Cassandra == nil || cc.Spec.Cassandra.CommitLogArchiving == nil {
+			errMsg := fmt.Sprintf("Restore failed. Point-in-time restores require commit log archiving to be enabled in cluster %q", cc.Name)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCommitLogArchivingNotConfigured, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		if len(backupName) == 0 && len(cr.Spec.SnapshotTag) == 0 {
+			backupName, err = r.pointInTimeBackup(ctx, cr)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if len(backupName) == 0 {
+				errMsg := fmt.Sprintf("Restore failed. No completed CassandraBackup of cluster %q started before %s found",
+					cc.Name, cr.Spec.RestorePointInTime.UTC().Format(time.RFC3339))
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cr, events.EventPointInTimeBackupNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+		}
+	}
+
 	cb := &v1alpha1.CassandraBackup{}
-	if len(cr.Spec.CassandraBackup) > 0 {
-		err = r.Get(ctx, types.NamespacedName{Name: cr.Spec.CassandraBackup, Namespace: cr.Namespace}, cb)
+	if len(backupName) > 0 {
+		err = r.Get(ctx, types.NamespacedName{Name: backupName, Namespace: cr.Namespace}, cb)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
-				errMsg := fmt.Sprintf("Restore failed. CassandraBackup %s not found", cr.Spec.CassandraBackup)
+				errMsg := fmt.Sprintf("Restore failed. CassandraBackup %s not found", backupName)
 				r.Log.Warn(errMsg)
 				r.Events.Warning(cr, events.EventCassandraBackupNotFound, errMsg)
 				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 			}
 			return ctrl.Result{}, err
 		}
+	} else if cr.Spec.BackupCatalog != nil {
+		catalogBackup, found, err := r.catalogBackup(ctx, cr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if !found {
+			errMsg := fmt.Sprintf("Restore failed. Backup with snapshot tag %q not found in CassandraBackupCatalog %s",
+				cr.Spec.BackupCatalog.SnapshotTag, cr.Spec.BackupCatalog.Name)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCatalogBackupNotFound, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		cb = catalogBackup
 	}
 
-	secretName := cr.Spec.SecretName
-	if len(secretName) == 0 {
-		secretName = cb.Spec.SecretName
+	if cb.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot {
+		errMsg := fmt.Sprintf("Restore failed. CassandraBackup %s consists of volume snapshots and can only be restored in the VolumeSnapshot mode", cb.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventVolumeSnapshotRestoreFailed, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	storageCredentials := &v1.Secret{}
-	err = r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, storageCredentials)
-	if err != nil {
-		if kerrors.IsNotFound(err) {
-			errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Storage credentials secret %q not found.", cb.Spec.CassandraCluster, cb.Spec.SecretName)
+	storageProvider := cr.StorageProvider()
+	if len(storageProvider) == 0 {
+		storageProvider = cb.StorageProvider()
+	}
+	if storageProvider == v1alpha1.StorageProviderFile && cc.Spec.Icarus.BackupVolume == nil {
+		errMsg := fmt.Sprintf("Restore failed. The file storage provider requires .spec.icarus.backupVolume to be set in cluster %q.", cc.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventBackupVolumeNotConfigured, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	var storageCredentials *v1.Secret
+	if v1alpha1.StorageSecretRequired(storageProvider) {
+		secretName := cr.Spec.SecretName
+		if len(secretName) == 0 {
+			secretName = cb.Spec.SecretName
+		}
+
+		storageCredentials = &v1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, storageCredentials)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Storage credentials secret %q not found.", cb.Spec.CassandraCluster, secretName)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		err = v1alpha1.ValidateStorageSecret(r.Log, storageCredentials, storageProvider)
+		if err != nil {
+			errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", secretName, err.Error())
 			r.Log.Warn(errMsg)
 			r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
 			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 		}
+	}
 
-		return ctrl.Result{}, err
+	err = validateRestoreEncryption(cb, cr)
+	if err != nil {
+		errMsg := fmt.Sprintf("Restore failed. %s", err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRestoreEncryptionMismatch, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if encryption := restoreEncryption(cb, cr); encryption != nil {
+		encryptionSecret := &v1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Name: encryption.SecretName, Namespace: cr.Namespace}, encryptionSecret)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				errMsg := fmt.Sprintf("Restore failed. Encryption secret %q not found.", encryption.SecretName)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cr, events.EventEncryptionSecretNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+			return ctrl.Result{}, err
+		}
+
+		err = v1alpha1.ValidateEncryptionSecret(encryptionSecret, encryption)
+		if err != nil {
+			errMsg := fmt.Sprintf("Encryption secret %q is invalid: %s", encryption.SecretName, err.Error())
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventEncryptionSecretInvalid, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+	}
+
+	if storageCredentials != nil {
+		verified, err := r.verifyStorage(ctx, cr, cb, storageProvider, storageCredentials)
+		if err != nil {
+			return r.handleReconcileErr(ctrl.Result{}, err)
+		}
+
+		if !verified {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
 	}
 
-	err = v1alpha1.ValidateStorageSecret(r.Log, storageCredentials, cb.StorageProvider())
+	err = r.fenceCluster(ctx, cc, cr)
 	if err != nil {
-		errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", cb.Spec.SecretName, err.Error())
+		return r.handleReconcileErr(ctrl.Result{}, err)
+	}
+
+	if cr.Spec.Mode == v1alpha1.RestoreModeLoad {
+		return r.handleReconcileErr(r.reconcileLoad(ctx, cr, cb, cc))
+	}
+
+	coordinatorPod, err := coordinator.Pod(ctx, r.Client, cc, cr.Status.Coordinator)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if coordinatorPod == nil {
+		errMsg := fmt.Sprintf("No ready Cassandra pod is available to coordinate the restore. Trying again in %s...", r.Cfg.RetryDelay)
 		r.Log.Warn(errMsg)
-		r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
+		r.Events.Warning(cr, events.EventCoordinatorNotAvailable, errMsg)
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	dcName := cc.Spec.DCs[0].Name
-	svc := names.DC(cc.Name, dcName)
-	//always use the same pod as the coordinator as only that pod has the global request info
-	coordinatorPodURL := fmt.Sprintf("http://%s-0.%s.%s.svc.cluster.local:%d", svc, svc, cc.Namespace, v1alpha1.IcarusPort)
+	if len(cr.Status.Coordinator) != 0 && cr.Status.Coordinator != coordinatorPod.Name {
+		msg := fmt.Sprintf("Coordinator %s is not available, moving the restore to %s", cr.Status.Coordinator, coordinatorPod.Name)
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventCoordinatorChanged, msg)
+	}
 
-	ic := r.IcarusClient(coordinatorPodURL)
+	ic := r.IcarusClient(coordinator.URL(cc, coordinatorPod))
+
+	return r.handleReconcileErr(r.reconcileRestore(ctx, ic, cr, cb, cc, coordinatorPod.Name))
+}
 
-	res, err := r.reconcileRestore(ctx, ic, cr, cb, cc)
+func (r *CassandraRestoreReconciler) handleReconcileErr(res ctrl.Result, err error) (ctrl.Result, error) {
 	if err != nil {
 		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
 			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
@@ -135,7 +303,8 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 func SetupCassandraRestoreReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		Named("cassandrarestore").
-		For(&v1alpha1.CassandraRestore{})
+		For(&v1alpha1.CassandraRestore{}).
+		Owns(&batchv1.Job{})
 
 	return builder.Complete(r)
 }
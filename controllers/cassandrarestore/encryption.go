@@ -0,0 +1,44 @@
+package cassandrarestore
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+// restoreEncryption returns the encryption the backup data is decrypted with.
+// The encryption of the backup is used unless it's set in the restore.
+func restoreEncryption(cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore) *v1alpha1.BackupEncryption {
+	if cr.Spec.Encryption != nil {
+		return cr.Spec.Encryption
+	}
+
+	return cb.Spec.Encryption
+}
+
+// validateRestoreEncryption makes sure an encrypted backup is restored with a key of the same type and an unencrypted backup without a key,
+// so that encrypted and unencrypted data is never mixed up. Backups restored by storage location and snapshot tag can't be checked.
+func validateRestoreEncryption(cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore) error {
+	encryption := restoreEncryption(cb, cr)
+	if len(cb.Name) == 0 {
+		return nil
+	}
+
+	if cb.Spec.Encryption == nil {
+		if encryption != nil {
+			return errors.Errorf("backup %s is not encrypted, .spec.encryption can't be set", cb.Name)
+		}
+		return nil
+	}
+
+	if len(encryption.SecretName) == 0 {
+		return errors.Errorf("backup %s is encrypted, .spec.encryption.secretName should be set", cb.Name)
+	}
+
+	// the key type of backups from a catalog is not known
+	if len(cb.Spec.Encryption.SecretName) != 0 && encryption.EncryptionKeyType() != cb.Spec.Encryption.EncryptionKeyType() {
+		return errors.Errorf("backup %s is encrypted with a key of type %s, got %s", cb.Name, cb.Spec.Encryption.EncryptionKeyType(), encryption.EncryptionKeyType())
+	}
+
+	return nil
+}
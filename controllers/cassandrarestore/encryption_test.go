@@ -0,0 +1,46 @@
+package cassandrarestore
+
+import (
+	"testing"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateRestoreEncryption(t *testing.T) {
+	g := NewGomegaWithT(t)
+	encryption := &v1alpha1.BackupEncryption{SecretName: "backup-key"}
+	cb := &v1alpha1.CassandraBackup{ObjectMeta: metav1.ObjectMeta{Name: "backup"}}
+	cr := &v1alpha1.CassandraRestore{}
+
+	g.Expect(validateRestoreEncryption(cb, cr)).To(Succeed())
+	g.Expect(restoreEncryption(cb, cr)).To(BeNil())
+
+	// an unencrypted backup can't be decrypted
+	cr.Spec.Encryption = encryption
+	g.Expect(validateRestoreEncryption(cb, cr)).ToNot(Succeed())
+
+	// the key of the backup is used by default
+	cb.Spec.Encryption = encryption
+	cr.Spec.Encryption = nil
+	g.Expect(validateRestoreEncryption(cb, cr)).To(Succeed())
+	g.Expect(restoreEncryption(cb, cr)).To(Equal(encryption))
+
+	cr.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "other-key", KeyType: v1alpha1.EncryptionKeyTypeDataKey}
+	g.Expect(validateRestoreEncryption(cb, cr)).To(Succeed())
+	g.Expect(restoreEncryption(cb, cr).SecretName).To(Equal("other-key"))
+
+	cr.Spec.Encryption.KeyType = v1alpha1.EncryptionKeyTypeKeyEncryptionKey
+	g.Expect(validateRestoreEncryption(cb, cr)).ToNot(Succeed())
+
+	// encrypted backups from a catalog need the key in the restore
+	cb.Spec.Encryption = &v1alpha1.BackupEncryption{}
+	cr.Spec.Encryption = nil
+	g.Expect(validateRestoreEncryption(cb, cr)).ToNot(Succeed())
+	cr.Spec.Encryption = encryption
+	g.Expect(validateRestoreEncryption(cb, cr)).To(Succeed())
+
+	// restores by storage location and snapshot tag are not checked
+	g.Expect(validateRestoreEncryption(&v1alpha1.CassandraBackup{}, cr)).To(Succeed())
+}
@@ -10,10 +10,7 @@ import (
 )
 
 func createRestoreReq(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore) icarus.RestoreRequest {
-	storageLocation := restore.Spec.StorageLocation
-	if len(storageLocation) == 0 {
-		storageLocation = backup.Spec.StorageLocation
-	}
+	storageLocation := v1alpha1.IcarusStorageLocation(restoreStorageLocation(backup, restore))
 	if storageLocation[len(storageLocation):] != "/" {
 		storageLocation += "/"
 	}
@@ -70,6 +67,7 @@ func createRestoreReq(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraB
 		ResolveHostIdFromTopology: restore.Spec.ResolveHostIdFromTopology,
 		ExactSchemaVersion:        restore.Spec.ExactSchemaVersion,
 		SchemaVersion:             restore.Spec.SchemaVersion,
+		Encryption:                icarus.NewEncryption(restoreEncryption(backup, restore)),
 	}
 
 	if restoreReq.ConcurrentConnections == 0 {
@@ -129,6 +127,7 @@ func (r *CassandraRestoreReconciler) restoreConfigChanged(existingRestore icarus
 		Import:                    existingRestore.Import,
 		RestorationPhase:          existingRestore.RestorationPhase,
 		RestorationStrategyType:   existingRestore.RestorationStrategyType,
+		Encryption:                existingRestore.Encryption,
 	}
 
 	cmpIgnoreFields := cmpopts.IgnoreFields(icarus.RestoreRequest{}, "SnapshotTag")
@@ -139,3 +138,11 @@ func (r *CassandraRestoreReconciler) restoreConfigChanged(existingRestore icarus
 
 	return false
 }
+
+func restoreStorageLocation(backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore) string {
+	if len(restore.Spec.StorageLocation) != 0 {
+		return restore.Spec.StorageLocation
+	}
+
+	return backup.Spec.StorageLocation
+}
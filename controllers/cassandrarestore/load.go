@@ -0,0 +1,408 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// where the SSTables of a backed up node are downloaded to in the load job
+	loadDataDir        = "/var/lib/cassandra-load"
+	loadDataVolumeName = "sstables"
+	loadBackupVolume   = "icarus-backups"
+)
+
+// the SSTables are moved into <keyspace>/<table> directories as sstableloader expects them, system keyspaces are skipped
+const loadScript = `set -e
+for dir in %[1]s/data/*/*/; do
+  [ -d "$dir" ] || continue
+  keyspace=$(basename "$(dirname "$dir")")
+  case "$keyspace" in
+    system|system_*) continue ;;
+  esac
+  table=$(basename "$dir")
+  table=${table%%%%-*}
+  mkdir -p "%[1]s/load/$keyspace"
+  mv "$dir" "%[1]s/load/$keyspace/$table"
+  echo "Loading $keyspace.$table"
+  sstableloader --nodes "$LOAD_HOSTS" --username "$CASSANDRA_USER" --password "$CASSANDRA_PASSWORD" "%[1]s/load/$keyspace/$table"
+done
+`
+
+// loadSource is a backed up node whose SSTables are loaded into a DC of the restored cluster
+type loadSource struct {
+	Node     string
+	SourceDC string
+	TargetDC string
+}
+
+// reconcileLoad restores the backup by streaming the SSTables of each backed up node into the cluster with sstableloader.
+// Each node is loaded by a job, which allows to restore into a cluster with a different name or topology.
+func (r *CassandraRestoreReconciler) reconcileLoad(ctx context.Context, cr *v1alpha1.CassandraRestore,
+	cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+	if cr.Status.State == icarus.StateFailed {
+		r.Log.Debugf("Restore %s/%s has failed. Recreate the CassandraRestore resource to start a new restore attempt", cr.Namespace, cr.Name)
+		return ctrl.Result{}, nil
+	}
+
+	internodeEncryption := cc.Spec.Encryption.Server.InternodeEncryption
+	if (len(internodeEncryption) != 0 && internodeEncryption != v1alpha1.InternodeEncryptionNone) || cc.Spec.Encryption.Client.Enabled {
+		errMsg := fmt.Sprintf("Restore failed. The Load mode doesn't support clusters with internode or client encryption enabled, cluster %q has it enabled", cc.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRestoreLoadNotSupported, errMsg)
+		return ctrl.Result{}, r.failRestore(ctx, cr, cc.Name, errMsg)
+	}
+
+	sources, err := loadSources(cc, cr, cb)
+	if err != nil {
+		errMsg := fmt.Sprintf("Restore failed. Invalid DC mapping: %s", err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRestoreDCMappingInvalid, errMsg)
+		return ctrl.Result{}, r.failRestore(ctx, cr, cc.Name, errMsg)
+	}
+
+	jobList := &batchv1.JobList{}
+	err = r.List(ctx, jobList, client.InNamespace(cr.Namespace), client.MatchingLabels{v1alpha1.CassandraRestoreLabel: cr.Name})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list load jobs")
+	}
+
+	jobs := make(map[string]batchv1.Job, len(jobList.Items))
+	for _, job := range jobList.Items {
+		jobs[job.Name] = job
+	}
+
+	restoreStatus := cr.DeepCopy()
+	restoreStatus.Status.Nodes = nil
+	restoreStatus.Status.Errors = nil
+	completed, finished := 0, 0
+	for i, source := range sources {
+		job, found := jobs[names.RestoreLoadJob(cr.Name, i)]
+		if !found {
+			newJob := loadJob(r.Cfg, cc, cr, cb, source, names.RestoreLoadJob(cr.Name, i))
+			err = ctrl.SetControllerReference(cr, newJob, r.Scheme)
+			if err != nil {
+				return ctrl.Result{}, errors.Wrap(err, "failed to set owner reference")
+			}
+
+			err = r.Create(ctx, newJob)
+			if err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to create load job %s", newJob.Name)
+			}
+
+			r.Log.Infof("Created job %s to load the SSTables of node %s into DC %s", newJob.Name, source.Node, source.TargetDC)
+			job = *newJob
+		}
+
+		node := v1alpha1.NodeProgress{
+			Name:           source.Node,
+			State:          loadJobState(job),
+			StartTime:      job.Status.StartTime,
+			CompletionTime: job.Status.CompletionTime,
+		}
+
+		switch node.State {
+		case icarus.StateCompleted:
+			node.Progress = 100
+			completed++
+			finished++
+		case icarus.StateFailed:
+			finished++
+			restoreStatus.Status.Errors = append(restoreStatus.Status.Errors, v1alpha1.RestoreError{
+				Source:  source.Node,
+				Message: loadJobFailure(job),
+			})
+		}
+
+		restoreStatus.Status.Nodes = append(restoreStatus.Status.Nodes, node)
+	}
+
+	if restoreStatus.Status.StartTime == nil {
+		msg := fmt.Sprintf("Loading the SSTables of %d backed up nodes into cluster %s", len(sources), cc.Name)
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventRestoreLoadStarted, msg)
+		restoreStatus.Status.StartTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	}
+
+	restoreStatus.Status.Progress = completed * 100 / len(sources)
+	restoreStatus.Status.State = icarus.StateRunning
+	if finished == len(sources) {
+		restoreStatus.Status.State = icarus.StateCompleted
+		if len(restoreStatus.Status.Errors) != 0 {
+			restoreStatus.Status.State = icarus.StateFailed
+		}
+
+		if restoreStatus.Status.CompletionTime == nil {
+			restoreStatus.Status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		}
+	}
+
+	icarus.SetConditions(&restoreStatus.Status.Conditions, "Restore", restoreStatus.Status.State, cr.Generation)
+
+	if !cmp.Equal(cr.Status, restoreStatus.Status) {
+		r.Log.Info("Updating restore status")
+		r.Log.Debugf(cmp.Diff(cr.Status, restoreStatus.Status))
+		err = r.Status().Update(ctx, restoreStatus)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if finished == len(sources) {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+// loadSources returns the backed up nodes to load, each with the DC of the cluster it's loaded into.
+// Without a DC mapping each DC of the backup is loaded into the DC with the same name.
+func loadSources(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup) ([]loadSource, error) {
+	dcMapping := cr.Spec.DCMapping
+	if len(dcMapping) == 0 {
+		dcMapping = make(map[string]string, len(cc.Spec.DCs))
+		for _, dc := range cc.Spec.DCs {
+			dcMapping[dc.Name] = dc.Name
+		}
+	}
+
+	targetDCs := make(map[string]bool, len(cc.Spec.DCs))
+	for _, dc := range cc.Spec.DCs {
+		targetDCs[dc.Name] = true
+	}
+
+	sourceDCs := make([]string, 0, len(dcMapping))
+	for sourceDC, targetDC := range dcMapping {
+		if !targetDCs[targetDC] {
+			return nil, fmt.Errorf("DC %q doesn't exist in cluster %q", targetDC, cc.Name)
+		}
+		sourceDCs = append(sourceDCs, sourceDC)
+	}
+	sort.Strings(sourceDCs)
+
+	var sources []loadSource
+	for _, node := range cb.Status.Nodes {
+		// pods are named after their DC, if a DC name is a prefix of another one, the longer one matches
+		nodeDC := ""
+		for _, sourceDC := range sourceDCs {
+			if strings.HasPrefix(node.Name, names.DC(cb.Spec.CassandraCluster, sourceDC)+"-") && len(sourceDC) > len(nodeDC) {
+				nodeDC = sourceDC
+			}
+		}
+
+		if len(nodeDC) != 0 {
+			sources = append(sources, loadSource{Node: node.Name, SourceDC: nodeDC, TargetDC: dcMapping[nodeDC]})
+		}
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("none of the DCs %v has nodes in backup %q", sourceDCs, cb.Name)
+	}
+
+	return sources, nil
+}
+
+// loadJob returns the job that loads the SSTables of a backed up node.
+// Icarus downloads the SSTables of the node in an init container, sstableloader streams them into the target DC afterwards.
+func loadJob(cfg config.Config, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, source loadSource, name string) *batchv1.Job {
+	jobLabels := labels.ComponentLabels(cc, v1alpha1.CassandraClusterComponentRestore)
+	jobLabels[v1alpha1.CassandraRestoreLabel] = cr.Name
+
+	secretName := cr.Spec.SecretName
+	if len(secretName) == 0 {
+		secretName = cb.Spec.SecretName
+	}
+
+	snapshotTag := cr.Spec.SnapshotTag
+	if len(snapshotTag) == 0 {
+		snapshotTag = cb.Name
+	}
+
+	concurrentConnections := cr.Spec.ConcurrentConnections
+	if concurrentConnections == 0 {
+		concurrentConnections = 10
+	}
+
+	storageLocation := strings.TrimSuffix(v1alpha1.IcarusStorageLocation(restoreStorageLocation(cb, cr)), "/")
+	args := []string{
+		"java", "-jar", "icarus.jar", "esop", "restore",
+		"--cassandra-dir=" + loadDataDir,
+		fmt.Sprintf("--storage-location=%s/%s/%s/%s", storageLocation, cb.Spec.CassandraCluster, source.SourceDC, source.Node),
+		"--snapshot-tag=" + snapshotTag,
+		"--restoration-strategy-type=IN_PLACE",
+		"--resolve-host-id-from-topology",
+		"--concurrent-connections=" + strconv.FormatInt(concurrentConnections, 10),
+		"--k8s-namespace=" + cr.Namespace,
+	}
+
+	if len(secretName) != 0 {
+		args = append(args, "--k8s-secret-name="+secretName)
+	}
+	if len(cr.Spec.Entities) != 0 {
+		args = append(args, "--entities="+cr.Spec.Entities)
+	}
+	if len(cr.Spec.SchemaVersion) != 0 {
+		args = append(args, "--schema-version="+cr.Spec.SchemaVersion)
+	}
+	if cr.Spec.ExactSchemaVersion {
+		args = append(args, "--exact-schema-version")
+	}
+	if cr.Spec.Insecure {
+		args = append(args, "--insecure")
+	}
+	if cr.Spec.SkipBucketVerification {
+		args = append(args, "--skip-bucket-verification")
+	}
+	if encryption := restoreEncryption(cb, cr); encryption != nil {
+		args = append(args,
+			"--encryption-k8s-secret-name="+encryption.SecretName,
+			"--encryption-k8s-secret-key="+encryption.EncryptionSecretKey(),
+			"--encryption-key-type="+string(encryption.EncryptionKeyType()),
+		)
+	}
+
+	// the cluster is defaulted by the CassandraCluster controller only
+	icarusImage := cc.Spec.Icarus.Image
+	if len(icarusImage) == 0 {
+		icarusImage = cfg.DefaultIcarusImage
+	}
+
+	cassandraImage := cfg.DefaultCassandraImage
+	var cassandraImagePullPolicy v1.PullPolicy
+	if cc.Spec.Cassandra != nil && len(cc.Spec.Cassandra.Image) != 0 {
+		cassandraImage = cc.Spec.Cassandra.Image
+		cassandraImagePullPolicy = cc.Spec.Cassandra.ImagePullPolicy
+	}
+
+	loadDataVolumeMount := v1.VolumeMount{Name: loadDataVolumeName, MountPath: loadDataDir}
+	download := v1.Container{
+		Name:            "download",
+		Image:           icarusImage,
+		ImagePullPolicy: cc.Spec.Icarus.ImagePullPolicy,
+		Command:         args,
+		VolumeMounts:    []v1.VolumeMount{loadDataVolumeMount},
+	}
+
+	load := v1.Container{
+		Name:            "load",
+		Image:           cassandraImage,
+		ImagePullPolicy: cassandraImagePullPolicy,
+		Command:         []string{"bash", "-c", fmt.Sprintf(loadScript, loadDataDir)},
+		Env: []v1.EnvVar{
+			{Name: "LOAD_HOSTS", Value: fmt.Sprintf("%s.%s.svc.cluster.local", names.DCService(cc.Name, source.TargetDC), cc.Namespace)},
+			{Name: "CASSANDRA_USER", ValueFrom: activeAdminSecretKey(cc, v1alpha1.CassandraOperatorAdminRole)},
+			{Name: "CASSANDRA_PASSWORD", ValueFrom: activeAdminSecretKey(cc, v1alpha1.CassandraOperatorAdminPassword)},
+		},
+		VolumeMounts: []v1.VolumeMount{loadDataVolumeMount},
+	}
+
+	volumes := []v1.Volume{
+		{Name: loadDataVolumeName, VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+	}
+
+	if cc.Spec.Icarus.BackupVolume != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: loadBackupVolume,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: cc.Spec.Icarus.BackupVolume.PersistentVolumeClaim,
+				NFS:                   cc.Spec.Icarus.BackupVolume.NFS,
+			},
+		})
+		download.VolumeMounts = append(download.VolumeMounts, v1.VolumeMount{Name: loadBackupVolume, MountPath: v1alpha1.IcarusBackupVolumeMountPath})
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: proto.Int32(3),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy:      v1.RestartPolicyNever,
+					ServiceAccountName: names.CassandraServiceAccount(cc.Name),
+					InitContainers:     []v1.Container{download},
+					Containers:         []v1.Container{load},
+					Volumes:            volumes,
+				},
+			},
+		},
+	}
+
+	if len(cc.Spec.ImagePullSecretName) != 0 {
+		job.Spec.Template.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: cc.Spec.ImagePullSecretName}}
+	}
+
+	if cr.Spec.Timeout != 0 {
+		job.Spec.ActiveDeadlineSeconds = proto.Int64(int64(time.Duration(cr.Spec.Timeout) * time.Hour / time.Second))
+	}
+
+	return job
+}
+
+func activeAdminSecretKey(cc *v1alpha1.CassandraCluster, key string) *v1.EnvVarSource {
+	return &v1.EnvVarSource{
+		SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: names.ActiveAdminSecret(cc.Name)},
+			Key:                  key,
+		},
+	}
+}
+
+// loadJobState maps the conditions of a load job to a restore state
+func loadJobState(job batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return icarus.StateCompleted
+		case batchv1.JobFailed:
+			return icarus.StateFailed
+		}
+	}
+
+	if job.Status.Active != 0 {
+		return icarus.StateRunning
+	}
+
+	return icarus.StatePending
+}
+
+func loadJobFailure(job batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			return fmt.Sprintf("job %s failed: %s", job.Name, condition.Message)
+		}
+	}
+
+	return fmt.Sprintf("job %s failed", job.Name)
+}
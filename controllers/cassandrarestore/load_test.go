@@ -0,0 +1,117 @@
+package cassandrarestore
+
+import (
+	"testing"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadSources(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+		Spec:       v1alpha1.CassandraClusterSpec{DCs: []v1alpha1.DC{{Name: "dc1"}, {Name: "dc1-b"}}},
+	}
+	cb := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup"},
+		Spec:       v1alpha1.CassandraBackupSpec{CassandraCluster: "prod"},
+		Status: v1alpha1.CassandraBackupStatus{
+			Nodes: []v1alpha1.NodeProgress{
+				{Name: "prod-cassandra-dc1-rack1-0"},
+				{Name: "prod-cassandra-dc1-b-rack1-0"},
+				{Name: "prod-cassandra-dc2-rack1-0"},
+			},
+		},
+	}
+	cr := &v1alpha1.CassandraRestore{}
+
+	sources, err := loadSources(cc, cr, cb)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sources).To(Equal([]loadSource{
+		{Node: "prod-cassandra-dc1-rack1-0", SourceDC: "dc1", TargetDC: "dc1"},
+		{Node: "prod-cassandra-dc1-b-rack1-0", SourceDC: "dc1-b", TargetDC: "dc1-b"},
+	}))
+
+	cr.Spec.DCMapping = map[string]string{"dc2": "dc1"}
+	sources, err = loadSources(cc, cr, cb)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sources).To(Equal([]loadSource{{Node: "prod-cassandra-dc2-rack1-0", SourceDC: "dc2", TargetDC: "dc1"}}))
+
+	cr.Spec.DCMapping = map[string]string{"dc2": "dc3"}
+	_, err = loadSources(cc, cr, cb)
+	g.Expect(err).To(MatchError(`DC "dc3" doesn't exist in cluster "staging"`))
+
+	cr.Spec.DCMapping = map[string]string{"dc3": "dc1"}
+	_, err = loadSources(cc, cr, cb)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestLoadJob(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "default"},
+		Spec:       v1alpha1.CassandraClusterSpec{ImagePullSecretName: "pull-secret"},
+	}
+	cb := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup"},
+		Spec:       v1alpha1.CassandraBackupSpec{CassandraCluster: "prod", StorageLocation: "s3://bucket/", SecretName: "storage-credentials"},
+	}
+	cr := &v1alpha1.CassandraRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec:       v1alpha1.CassandraRestoreSpec{Entities: "ks1", Timeout: 2},
+	}
+	cfg := config.Config{DefaultCassandraImage: "cassandra/image", DefaultIcarusImage: "icarus/image"}
+	source := loadSource{Node: "prod-cassandra-dc1-rack1-0", SourceDC: "dc1", TargetDC: "staging"}
+
+	job := loadJob(cfg, cc, cr, cb, source, "restore-load-0")
+	g.Expect(job.Labels).To(HaveKeyWithValue(v1alpha1.CassandraRestoreLabel, "restore"))
+	g.Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(7200)))
+	g.Expect(job.Spec.Template.Spec.ImagePullSecrets).To(Equal([]v1.LocalObjectReference{{Name: "pull-secret"}}))
+
+	download := job.Spec.Template.Spec.InitContainers[0]
+	g.Expect(download.Image).To(Equal("icarus/image"))
+	g.Expect(download.Command).To(ContainElements(
+		"--storage-location=s3://bucket/prod/dc1/prod-cassandra-dc1-rack1-0",
+		"--snapshot-tag=backup",
+		"--k8s-secret-name=storage-credentials",
+		"--entities=ks1",
+		"--resolve-host-id-from-topology",
+	))
+
+	load := job.Spec.Template.Spec.Containers[0]
+	g.Expect(load.Image).To(Equal("cassandra/image"))
+	g.Expect(load.Env).To(ContainElement(v1.EnvVar{Name: "LOAD_HOSTS", Value: "staging-cassandra-staging.default.svc.cluster.local"}))
+	g.Expect(load.Command[2]).To(ContainSubstring("table=${table%%-*}"))
+
+	cc.Spec.ImagePullSecretName = ""
+	cb.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "backup-key"}
+	job = loadJob(cfg, cc, cr, cb, source, "restore-load-0")
+	g.Expect(job.Spec.Template.Spec.ImagePullSecrets).To(BeEmpty())
+	g.Expect(job.Spec.Template.Spec.InitContainers[0].Command).To(ContainElements(
+		"--encryption-k8s-secret-name=backup-key",
+		"--encryption-k8s-secret-key=key",
+		"--encryption-key-type=DataKey",
+	))
+}
+
+func TestLoadJobState(t *testing.T) {
+	g := NewGomegaWithT(t)
+	job := batchv1.Job{}
+	g.Expect(loadJobState(job)).To(Equal(icarus.StatePending))
+
+	job.Status.Active = 1
+	g.Expect(loadJobState(job)).To(Equal(icarus.StateRunning))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	g.Expect(loadJobState(job)).To(Equal(icarus.StateFailed))
+	g.Expect(loadJobFailure(job)).To(ContainSubstring("BackoffLimitExceeded"))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	g.Expect(loadJobState(job)).To(Equal(icarus.StateCompleted))
+}
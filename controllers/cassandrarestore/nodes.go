@@ -0,0 +1,108 @@
+package cassandrarestore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// nodesProgress returns the state of the restore on each node.
+// Nodes that are not ready or can't be reached keep their last known state.
+func (r *CassandraRestoreReconciler) nodesProgress(ctx context.Context, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore,
+	icarusRestore icarus.Restore) ([]v1alpha1.NodeProgress, error) {
+	pods, err := coordinator.Pods(ctx, r.Client, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	lastKnownNodes := make(map[string]v1alpha1.NodeProgress, len(cr.Status.Nodes))
+	for _, node := range cr.Status.Nodes {
+		lastKnownNodes[node.Name] = node
+	}
+
+	var nodePods []v1.Pod
+	for _, pod := range pods {
+		if len(cr.Spec.DC) == 0 || util.Contains(strings.Split(cr.Spec.DC, ","), pod.Labels[v1alpha1.CassandraClusterDC]) {
+			nodePods = append(nodePods, pod)
+		}
+	}
+
+	// the nodes are queried concurrently, each request is bounded by a timeout
+	nodeRestores := make([][]icarus.Restore, len(nodePods))
+	var wg sync.WaitGroup
+	for i := range nodePods {
+		if !coordinator.Ready(nodePods[i]) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reqCtx, cancel := context.WithTimeout(ctx, coordinator.NodeRequestTimeout)
+			defer cancel()
+			restores, err := r.IcarusClient(coordinator.URL(cc, &nodePods[i])).Restores(reqCtx)
+			if err != nil {
+				r.Log.Debugf("Failed to get restore progress from pod %s: %s", nodePods[i].Name, err.Error())
+				return
+			}
+			nodeRestores[i] = restores
+		}(i)
+	}
+	wg.Wait()
+
+	var nodes []v1alpha1.NodeProgress
+	for i, pod := range nodePods {
+		node, nodeKnown := lastKnownNodes[pod.Name]
+		if nodeRestore, found := findNodeRestore(nodeRestores[i], icarusRestore.SnapshotTag); found {
+			node = v1alpha1.NodeProgress{
+				Name:             pod.Name,
+				State:            nodeRestore.State,
+				Progress:         int(nodeRestore.Progress * 100),
+				StartTime:        icarus.ParseTime(nodeRestore.StartTime),
+				CompletionTime:   icarus.ParseTime(nodeRestore.CompletionTime),
+				TransferredBytes: nodeRestore.TransferredBytes,
+				TotalBytes:       nodeRestore.TotalBytes,
+			}
+			nodeKnown = true
+		}
+
+		if nodeKnown {
+			nodes = append(nodes, node)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes, nil
+}
+
+// findNodeRestore returns the most recent local restore of a node that was started by the coordinator.
+// A restore is executed in several phases, each of them is a separate operation on the node.
+func findNodeRestore(icarusRestores []icarus.Restore, snapshotTag string) (icarus.Restore, bool) {
+	var nodeRestore icarus.Restore
+	found := false
+	for _, item := range icarusRestores {
+		if item.GlobalRequest || item.SnapshotTag != snapshotTag {
+			continue
+		}
+
+		if found && item.CreationTime < nodeRestore.CreationTime {
+			continue
+		}
+
+		nodeRestore = item
+		found = true
+	}
+
+	return nodeRestore, found
+}
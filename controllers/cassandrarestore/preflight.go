@@ -0,0 +1,324 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the CassandraCluster controller uses the same defaults to find and mark executed CQL ConfigMaps
+	defaultCQLConfigMapLabelKey = "cql-scripts"
+	annotationCQLChecksum       = "cql-checksum"
+)
+
+// preflightPassed returns true if the restore can proceed without running the pre-flight checks.
+// The checks are run once before the restore is started.
+func preflightPassed(cr *v1alpha1.CassandraRestore) bool {
+	return len(cr.Status.State) != 0 || meta.IsStatusConditionTrue(cr.Status.Conditions, v1alpha1.ConditionTypePreflightChecksPassed)
+}
+
+// reconcilePreflightChecks runs the pre-flight checks and reports the result in the PreflightChecksPassed condition.
+// Returns true if all checks have passed.
+func (r *CassandraRestoreReconciler) reconcilePreflightChecks(ctx context.Context, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) (bool, error) {
+	failures, err := r.preflightChecks(ctx, cc, cr)
+	if err != nil {
+		return false, err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypePreflightChecksPassed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             "PreflightChecksPassed",
+		Message:            "The cluster is ready to be restored",
+	}
+
+	if len(failures) != 0 {
+		errMsg := fmt.Sprintf("Pre-flight checks failed: %s. Trying again in %s...", strings.Join(failures, "; "), r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRestorePreflightFailed, errMsg)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "PreflightChecksFailed"
+		condition.Message = strings.Join(failures, "; ")
+	}
+
+	restoreStatus := cr.DeepCopy()
+	meta.SetStatusCondition(&restoreStatus.Status.Conditions, condition)
+	if !cmp.Equal(cr.Status, restoreStatus.Status) {
+		err = r.Status().Update(ctx, restoreStatus)
+		if err != nil {
+			return false, err
+		}
+
+		restoreStatus.DeepCopyInto(cr)
+	}
+
+	return len(failures) == 0, nil
+}
+
+// preflightChecks verifies that the cluster can be restored into. Returns the reasons the restore can't be started yet.
+func (r *CassandraRestoreReconciler) preflightChecks(ctx context.Context, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) ([]string, error) {
+	var failures []string
+	if !cc.Status.Ready {
+		failures = append(failures, fmt.Sprintf("cluster %s is not ready", cc.Name))
+	}
+
+	if len(cc.Status.MaintenanceState) != 0 || maintenanceRequested(cc, time.Now()) {
+		failures = append(failures, fmt.Sprintf("cluster %s is in maintenance", cc.Name))
+	}
+
+	fencedBy := cc.Annotations[v1alpha1.RestoreFenceAnnotation]
+	if len(fencedBy) != 0 && fencedBy != cr.Name {
+		failures = append(failures, fmt.Sprintf("cluster %s is fenced by restore %s", cc.Name, fencedBy))
+	}
+
+	scalingFailures, err := r.scalingChecks(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+	failures = append(failures, scalingFailures...)
+
+	// the schema can be checked only if the cluster is available
+	if len(failures) != 0 {
+		return failures, nil
+	}
+
+	schemaFailures, err := r.schemaChecks(ctx, cc, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(failures, schemaFailures...), nil
+}
+
+// scalingChecks verifies that no DC is being scaled and no pod is being decommissioned
+func (r *CassandraRestoreReconciler) scalingChecks(ctx context.Context, cc *v1alpha1.CassandraCluster) ([]string, error) {
+	var failures []string
+	for _, dc := range cc.Spec.DCs {
+		sts := &appsv1.StatefulSet{}
+		err := r.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, dc.Name), Namespace: cc.Namespace}, sts)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				failures = append(failures, fmt.Sprintf("DC %s is not created yet", dc.Name))
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get statefulset of DC %s", dc.Name)
+		}
+
+		if dc.Replicas != nil && (sts.Spec.Replicas == nil || *sts.Spec.Replicas != *dc.Replicas) {
+			failures = append(failures, fmt.Sprintf("DC %s is being scaled", dc.Name))
+		} else if sts.Status.ReadyReplicas != sts.Status.Replicas {
+			failures = append(failures, fmt.Sprintf("DC %s has %d of %d pods ready", dc.Name, sts.Status.ReadyReplicas, sts.Status.Replicas))
+		}
+
+		decommissionJobPrefix := names.PodDecommissionJob(names.DC(cc.Name, dc.Name) + "-")
+		decommissionJobs := podJobs(r.Jobs.RunningJobs(decommissionJobPrefix), decommissionJobPrefix)
+		if len(decommissionJobs) != 0 {
+			failures = append(failures, fmt.Sprintf("pods of DC %s are being decommissioned: %s", dc.Name, strings.Join(decommissionJobs, ", ")))
+		}
+	}
+
+	return failures, nil
+}
+
+// podJobs returns the jobs named after the prefix followed by a pod ordinal. Jobs of a DC whose name starts
+// with the name of another DC, e.g. dc1-b and dc1, share the prefix and are filtered out by the ordinal.
+func podJobs(jobs []string, prefix string) []string {
+	var dcJobs []string
+	for _, job := range jobs {
+		if _, err := strconv.Atoi(strings.TrimPrefix(job, prefix)); err == nil {
+			dcJobs = append(dcJobs, job)
+		}
+	}
+
+	return dcJobs
+}
+
+// schemaChecks verifies that the restored keyspaces and tables exist and the schema version matches if it's required.
+// Keyspaces and tables are allowed to be missing only while CQL ConfigMaps that may create them are waiting to be executed.
+func (r *CassandraRestoreReconciler) schemaChecks(ctx context.Context, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) ([]string, error) {
+	checkSchemaVersion := cr.Spec.ExactSchemaVersion && len(cr.Spec.SchemaVersion) != 0
+	if len(cr.Spec.Entities) == 0 && !checkSchemaVersion {
+		return nil, nil
+	}
+
+	// the client certificates are available to the CassandraCluster controller only
+	if cc.Spec.Encryption.Client.Enabled {
+		r.Log.Warnf("Client encryption is enabled in cluster %s, skipping the keyspace and schema version checks", cc.Name)
+		return nil, nil
+	}
+
+	cqlClient, err := r.newCQLClient(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+	defer cqlClient.CloseSession()
+
+	var failures []string
+	if len(cr.Spec.Entities) != 0 {
+		keyspaces, err := cqlClient.GetKeyspacesInfo()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get keyspaces")
+		}
+
+		tables, err := cqlClient.GetTables()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get tables")
+		}
+
+		missing := missingEntities(cr.Spec.Entities, keyspaces, tables)
+		if len(missing) != 0 {
+			pending, err := r.pendingCQLConfigMaps(ctx, cc)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(pending) != 0 {
+				failures = append(failures, fmt.Sprintf("%s not found, waiting for CQL ConfigMaps %s to be executed",
+					strings.Join(missing, ", "), strings.Join(pending, ", ")))
+			} else {
+				failures = append(failures, fmt.Sprintf("%s not found", strings.Join(missing, ", ")))
+			}
+		}
+	}
+
+	if checkSchemaVersion {
+		schemaVersion, err := cqlClient.GetSchemaVersion()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get schema version")
+		}
+
+		if schemaVersion != cr.Spec.SchemaVersion {
+			failures = append(failures, fmt.Sprintf("schema version %s doesn't match the schema version %s of the backup", schemaVersion, cr.Spec.SchemaVersion))
+		}
+	}
+
+	return failures, nil
+}
+
+func (r *CassandraRestoreReconciler) newCQLClient(ctx context.Context, cc *v1alpha1.CassandraCluster) (cql.CqlClient, error) {
+	adminSecret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.ActiveAdminSecret(cc.Name), Namespace: cc.Namespace}, adminSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret %s", names.ActiveAdminSecret(cc.Name))
+	}
+
+	role := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminRole])
+	password := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminPassword])
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, role, password, r.Log))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the cluster")
+	}
+
+	return cqlClient, nil
+}
+
+// missingEntities returns the keyspaces and tables of the entities (e.g. 'ks1,ks2' or 'ks1.t1,ks2.t2') that don't exist
+func missingEntities(entities string, keyspaces []cql.Keyspace, tables []cql.Table) []string {
+	existing := make(map[string]bool, len(keyspaces)+len(tables))
+	for _, keyspace := range keyspaces {
+		existing[keyspace.Name] = true
+	}
+	for _, table := range tables {
+		existing[table.Keyspace+"."+table.Name] = true
+	}
+
+	var missing []string
+	for _, entity := range strings.Split(entities, ",") {
+		entity = strings.TrimSpace(entity)
+		if len(entity) == 0 || existing[entity] {
+			continue
+		}
+
+		if strings.Contains(entity, ".") {
+			missing = append(missing, "table "+entity)
+		} else {
+			missing = append(missing, "keyspace "+entity)
+		}
+	}
+
+	return missing
+}
+
+// pendingCQLConfigMaps returns the names of the CQL ConfigMaps that haven't been executed by the CassandraCluster controller yet
+func (r *CassandraRestoreReconciler) pendingCQLConfigMaps(ctx context.Context, cc *v1alpha1.CassandraCluster) ([]string, error) {
+	labelKey := cc.Spec.CQLConfigMapLabelKey
+	if len(labelKey) == 0 {
+		labelKey = defaultCQLConfigMapLabelKey
+	}
+
+	cmList := &v1.ConfigMapList{}
+	err := r.List(ctx, cmList, client.HasLabels{labelKey}, client.InNamespace(cc.Namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list CQL ConfigMaps")
+	}
+
+	var pending []string
+	for _, cm := range cmList.Items {
+		if cm.Annotations[annotationCQLChecksum] != util.Sha1(fmt.Sprintf("%v", cm.Data)) {
+			pending = append(pending, cm.Name)
+		}
+	}
+
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// fenceCluster marks the cluster as fenced by the restore. The fence is enforced and removed by the CassandraCluster controller.
+func (r *CassandraRestoreReconciler) fenceCluster(ctx context.Context, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) error {
+	if !cr.Spec.Fence || cr.Status.State == icarus.StateCompleted || cr.Status.State == icarus.StateFailed ||
+		cc.Annotations[v1alpha1.RestoreFenceAnnotation] == cr.Name {
+		return nil
+	}
+
+	patch := client.MergeFrom(cc.DeepCopy())
+	metav1.SetMetaDataAnnotation(&cc.ObjectMeta, v1alpha1.RestoreFenceAnnotation, cr.Name)
+	err := r.Patch(ctx, cc, patch)
+	if err != nil {
+		return errors.Wrap(err, "failed to fence the cluster")
+	}
+
+	msg := fmt.Sprintf("Cluster %s fenced while restore %s is running", cc.Name, cr.Name)
+	r.Log.Info(msg)
+	r.Events.Normal(cr, events.EventClusterFenced, msg)
+	return nil
+}
+
+// maintenanceRequested returns true if any pod of the cluster is requested to be in maintenance. Expired requests are ignored.
+func maintenanceRequested(cc *v1alpha1.CassandraCluster, now time.Time) bool {
+	for _, dc := range cc.Spec.DCs {
+		if dc.Replicas == nil {
+			continue
+		}
+
+		for i := 0; i < int(*dc.Replicas); i++ {
+			if cc.MaintenanceRequested(dc.Name, fmt.Sprintf("%s-%d", names.DC(cc.Name, dc.Name), i), now) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
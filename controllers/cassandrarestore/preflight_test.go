@@ -0,0 +1,46 @@
+package cassandrarestore
+
+import (
+	"testing"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMissingEntities(t *testing.T) {
+	g := NewGomegaWithT(t)
+	keyspaces := []cql.Keyspace{{Name: "ks1"}, {Name: "ks2"}}
+	tables := []cql.Table{{Keyspace: "ks1", Name: "t1"}, {Keyspace: "ks2", Name: "t1"}}
+
+	g.Expect(missingEntities("ks1,ks2", keyspaces, tables)).To(BeEmpty())
+	g.Expect(missingEntities("ks1.t1, ks2.t1", keyspaces, tables)).To(BeEmpty())
+	g.Expect(missingEntities("ks1,ks3", keyspaces, tables)).To(Equal([]string{"keyspace ks3"}))
+	g.Expect(missingEntities("ks1.t1,ks1.t2,ks3.t1", keyspaces, tables)).To(Equal([]string{"table ks1.t2", "table ks3.t1"}))
+}
+
+func TestPreflightPassed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cr := &v1alpha1.CassandraRestore{}
+	g.Expect(preflightPassed(cr)).To(BeFalse())
+
+	cr.Status.Conditions = []metav1.Condition{{Type: v1alpha1.ConditionTypePreflightChecksPassed, Status: metav1.ConditionFalse}}
+	g.Expect(preflightPassed(cr)).To(BeFalse())
+
+	cr.Status.Conditions[0].Status = metav1.ConditionTrue
+	g.Expect(preflightPassed(cr)).To(BeTrue())
+
+	// restores started before the checks were introduced are not checked
+	cr = &v1alpha1.CassandraRestore{Status: v1alpha1.CassandraRestoreStatus{State: icarus.StateRunning}}
+	g.Expect(preflightPassed(cr)).To(BeTrue())
+}
+
+func TestPodJobs(t *testing.T) {
+	g := NewGomegaWithT(t)
+	jobs := []string{"pod-decommission-test-cassandra-dc1-2", "pod-decommission-test-cassandra-dc1-b-0"}
+
+	g.Expect(podJobs(jobs, "pod-decommission-test-cassandra-dc1-")).To(Equal([]string{"pod-decommission-test-cassandra-dc1-2"}))
+	g.Expect(podJobs(jobs, "pod-decommission-test-cassandra-dc1-b-")).To(Equal([]string{"pod-decommission-test-cassandra-dc1-b-0"}))
+}
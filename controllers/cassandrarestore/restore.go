@@ -10,7 +10,12 @@ import (
 )
 
 func (r *CassandraRestoreReconciler) reconcileRestore(ctx context.Context, ic icarus.Icarus,
-	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, coordinatorPod string) (ctrl.Result, error) {
+	if len(cr.Status.CommitLogReplay) != 0 {
+		// the snapshot has been restored already and Icarus doesn't know about it anymore once the pods have been restarted
+		return r.reconcileCommitLogReplay(ctx, cc, cr, cb)
+	}
+
 	snapshotTag := cr.Spec.SnapshotTag
 	if len(snapshotTag) == 0 {
 		if cb == nil {
@@ -33,24 +38,43 @@ func (r *CassandraRestoreReconciler) reconcileRestore(ctx context.Context, ic ic
 				"Recreate the CassandraRestore resource to start a new restore attempt", cr.Namespace, cr.Name)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, r.reconcileFailedRestore(ctx, ic, cr, cb, cc, relatedIcarusRestore)
+		return ctrl.Result{}, r.reconcileFailedRestore(ctx, ic, cr, cb, cc, coordinatorPod, relatedIcarusRestore)
 	}
 
-	if !relatedIcarusRestoreFound { // doesn't exist yet, create it
+	// doesn't exist yet, create it. If the coordinator has changed, the new coordinator
+	// doesn't know about the running restore, so the restore is sent again.
+	if !relatedIcarusRestoreFound {
 		err = ic.Restore(ctx, createRestoreReq(cc, cb, cr))
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
 		r.Log.Info("Restore request sent")
+		if cr.Status.Coordinator != coordinatorPod {
+			cr.Status.Coordinator = coordinatorPod
+			err = r.Status().Update(ctx, cr)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	err = r.reconcileStatus(ctx, cr, relatedIcarusRestore)
+	nodes, err := r.nodesProgress(ctx, cc, cr, relatedIcarusRestore)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.reconcileStatus(ctx, cr, coordinatorPod, relatedIcarusRestore, nodes)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if cr.Spec.RestorePointInTime != nil && relatedIcarusRestore.State == icarus.StateCompleted {
+		return r.reconcileCommitLogReplay(ctx, cc, cr, cb)
+	}
+
 	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 }
 
@@ -71,7 +95,7 @@ func findRelatedIcarusRestore(icarusRestores []icarus.Restore, snapshotTag strin
 }
 
 func (r *CassandraRestoreReconciler) reconcileFailedRestore(ctx context.Context, ic icarus.Icarus, cr *v1alpha1.CassandraRestore,
-	cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, relatedIcarusRestore icarus.Restore) error {
+	cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, coordinatorPod string, relatedIcarusRestore icarus.Restore) error {
 	newRestoreRequest := createRestoreReq(cc, cb, cr)
 	if r.restoreConfigChanged(relatedIcarusRestore, newRestoreRequest) {
 		r.Log.Info("Detected a configuration change for restore %s/%s, sending a new restore request", cb.Namespace, cb.Name)
@@ -80,7 +104,8 @@ func (r *CassandraRestoreReconciler) reconcileFailedRestore(ctx context.Context,
 			return err
 		}
 
-		cr.Status = v1alpha1.CassandraRestoreStatus{} // reset status since we're restarting restore in Icarus
+		// reset status since we're restarting restore in Icarus
+		cr.Status = v1alpha1.CassandraRestoreStatus{Coordinator: coordinatorPod, CassandraBackup: cr.Status.CassandraBackup}
 		err = r.Status().Update(ctx, cr)
 		if err != nil {
 			return err
@@ -2,15 +2,29 @@ package cassandrarestore
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func (r *CassandraRestoreReconciler) reconcileStatus(ctx context.Context, cr *v1alpha1.CassandraRestore, relatedIcarusRestore icarus.Restore) error {
+func (r *CassandraRestoreReconciler) reconcileStatus(ctx context.Context, cr *v1alpha1.CassandraRestore, coordinatorPod string,
+	relatedIcarusRestore icarus.Restore, nodes []v1alpha1.NodeProgress) error {
 	restoreStatus := cr.DeepCopy()
+	restoreStatus.Status.Coordinator = coordinatorPod
 	restoreStatus.Status.Progress = int(relatedIcarusRestore.Progress * 100)
+	restoreStatus.Status.Phase = relatedIcarusRestore.RestorationPhase
+	restoreStatus.Status.StartTime = icarus.ParseTime(relatedIcarusRestore.StartTime)
+	restoreStatus.Status.CompletionTime = icarus.ParseTime(relatedIcarusRestore.CompletionTime)
+	restoreStatus.Status.Nodes = nodes
+	restoreStatus.Status.DownloadedBytes, restoreStatus.Status.TotalBytes = 0, 0
+	for _, node := range nodes {
+		restoreStatus.Status.DownloadedBytes += node.TransferredBytes
+		restoreStatus.Status.TotalBytes += node.TotalBytes
+	}
 	if cr.Status.State != relatedIcarusRestore.State {
 		restoreStatus.Status.State = relatedIcarusRestore.State
 		if relatedIcarusRestore.State == icarus.StateFailed {
@@ -26,6 +40,14 @@ func (r *CassandraRestoreReconciler) reconcileStatus(ctx context.Context, cr *v1
 		}
 	}
 
+	if cr.Spec.RestorePointInTime != nil && restoreStatus.Status.State == icarus.StateCompleted {
+		// a point-in-time restore is completed once the commit logs have been replayed
+		restoreStatus.Status.State = icarus.StateRunning
+		restoreStatus.Status.CompletionTime = nil
+	}
+
+	icarus.SetConditions(&restoreStatus.Status.Conditions, "Restore", restoreStatus.Status.State, cr.Generation)
+
 	if !cmp.Equal(cr.Status, restoreStatus.Status) {
 		r.Log.Info("Updating restore status")
 		r.Log.Debugf(cmp.Diff(cr.Status, restoreStatus.Status))
@@ -33,7 +55,21 @@ func (r *CassandraRestoreReconciler) reconcileStatus(ctx context.Context, cr *v1
 		if err != nil {
 			return err
 		}
+
+		restoreStatus.DeepCopyInto(cr)
 	}
 
 	return nil
 }
+
+// failRestore sets the restore to Failed with the reason. Failed restores are not retried.
+func (r *CassandraRestoreReconciler) failRestore(ctx context.Context, cr *v1alpha1.CassandraRestore, source, errMsg string) error {
+	cr.Status.State = icarus.StateFailed
+	cr.Status.Errors = []v1alpha1.RestoreError{{Source: source, Message: errMsg}}
+	if cr.Status.CompletionTime == nil {
+		cr.Status.CompletionTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	}
+	icarus.SetConditions(&cr.Status.Conditions, "Restore", cr.Status.State, cr.Generation)
+
+	return errors.Wrap(r.Status().Update(ctx, cr), "failed to update restore status")
+}
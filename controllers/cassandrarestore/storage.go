@@ -0,0 +1,49 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+)
+
+// verifyStorage checks the storage credentials against the bucket with the backup before the restore is started.
+// Returns false if the storage provider rejected the request.
+func (r *CassandraRestoreReconciler) verifyStorage(ctx context.Context, cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup,
+	storageProvider v1alpha1.StorageProvider, storageCredentials *v1.Secret) (bool, error) {
+	if cr.Spec.SkipBucketVerification || storage.Verified(cr.Status.Conditions, cr.Generation) {
+		return true, nil
+	}
+
+	err := r.StorageClient(storageCredentials, cr.Spec.Insecure).Verify(ctx, storageProvider, restoreStorageLocation(cb, cr))
+
+	restoreStatus := cr.DeepCopy()
+	storage.SetCondition(&restoreStatus.Status.Conditions, err, cr.Generation)
+	if !cmp.Equal(cr.Status, restoreStatus.Status) {
+		updateErr := r.Status().Update(ctx, restoreStatus)
+		if updateErr != nil {
+			return false, updateErr
+		}
+
+		restoreStatus.DeepCopyInto(cr)
+	}
+
+	if err == storage.ErrNotVerifiable {
+		r.Log.Debugf("Storage credentials of restore %s/%s can't be verified: %s", cr.Namespace, cr.Name, err.Error())
+		return true, nil
+	}
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Restore failed. Storage verification failed: %s", err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventStorageVerificationFailed, errMsg)
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,221 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const volumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+
+// reconcileVolumeSnapshotRestore provisions the PVCs of the cluster from the VolumeSnapshots of the backup.
+// The PVCs are created before the StatefulSets, which then use them instead of provisioning empty volumes.
+// The restore is completed once the cluster is ready. It fails if the backup can't be restored into the cluster,
+// which lets the cluster create its StatefulSets with empty volumes.
+func (r *CassandraRestoreReconciler) reconcileVolumeSnapshotRestore(ctx context.Context, cr *v1alpha1.CassandraRestore, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+	if cr.Status.State == icarus.StateFailed {
+		r.Log.Infof("Restore %s/%s has failed. Recreate the CassandraRestore and CassandraCluster resources to start a new restore attempt", cr.Namespace, cr.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if cr.Status.State == icarus.StateRunning {
+		if !cc.Status.Ready {
+			r.Log.Debugf("Waiting for cluster %s/%s to start from the restored volumes", cc.Namespace, cc.Name)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		now := &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		cr.Status.State = icarus.StateCompleted
+		cr.Status.Progress = 100
+		cr.Status.CompletionTime = now
+		for i := range cr.Status.Nodes {
+			cr.Status.Nodes[i].State = icarus.StateCompleted
+			cr.Status.Nodes[i].Progress = 100
+			cr.Status.Nodes[i].CompletionTime = now
+		}
+		icarus.SetConditions(&cr.Status.Conditions, "Restore", cr.Status.State, cr.Generation)
+		r.Log.Infof("Restore %s/%s completed", cr.Namespace, cr.Name)
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), "failed to update restore status")
+	}
+
+	cb := &v1alpha1.CassandraBackup{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.CassandraBackup, Namespace: cr.Namespace}, cb)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("Restore failed. CassandraBackup %s not found", cr.Spec.CassandraBackup)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCassandraBackupNotFound, errMsg)
+			return ctrl.Result{}, r.failRestore(ctx, cr, cr.Spec.CassandraBackup, errMsg)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cb.Spec.Mode == v1alpha1.BackupModeVolumeSnapshot && !icarus.Finished(cb.Status.State) {
+		r.Log.Infof("Waiting for backup %s to complete before restoring it. Trying again in %s...", cb.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if cb.Spec.Mode != v1alpha1.BackupModeVolumeSnapshot || cb.Status.State != icarus.StateCompleted {
+		errMsg := fmt.Sprintf("Restore failed. CassandraBackup %s is not a completed backup in the VolumeSnapshot mode", cb.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventVolumeSnapshotRestoreFailed, errMsg)
+		return ctrl.Result{}, r.failRestore(ctx, cr, cb.Name, errMsg)
+	}
+
+	if !cc.Spec.Cassandra.Persistence.Enabled {
+		errMsg := fmt.Sprintf("Restore failed. The VolumeSnapshot mode requires persistence to be enabled in cluster %q", cc.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventVolumeSnapshotRestoreFailed, errMsg)
+		return ctrl.Result{}, r.failRestore(ctx, cr, cc.Name, errMsg)
+	}
+
+	claims, err := snapshotClaims(cr, cb, cc)
+	if err != nil {
+		errMsg := fmt.Sprintf("Restore failed. %s", err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventVolumeSnapshotRestoreFailed, errMsg)
+		return ctrl.Result{}, r.failRestore(ctx, cr, cb.Name, errMsg)
+	}
+
+	for _, claim := range claims {
+		actualClaim := &v1.PersistentVolumeClaim{}
+		err = r.Get(ctx, types.NamespacedName{Name: claim.Name, Namespace: claim.Namespace}, actualClaim)
+		if err == nil {
+			if actualClaim.Annotations[v1alpha1.VolumeSnapshotRestoreAnnotation] == cr.Name {
+				continue
+			}
+
+			// the volume has been provisioned by the StatefulSet or by someone else, it's not safe to continue
+			errMsg := fmt.Sprintf("Restore failed. PVC %s already exists and is not provisioned from the backup", claim.Name)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventVolumeSnapshotRestoreFailed, errMsg)
+			return ctrl.Result{}, r.failRestore(ctx, cr, claim.Name, errMsg)
+		}
+
+		if !kerrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get pvc %s", claim.Name)
+		}
+
+		r.Log.Infof("Creating PVC %s from volume snapshot %s", claim.Name, claim.Spec.DataSource.Name)
+		err = r.Create(ctx, claim)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to create pvc %s", claim.Name)
+		}
+	}
+
+	startTime := &metav1.Time{Time: time.Now().Truncate(time.Second)}
+	cr.Status.State = icarus.StateRunning
+	cr.Status.StartTime = startTime
+	cr.Status.CassandraBackup = cb.Name
+	cr.Status.Nodes = nil
+	for _, claim := range claims {
+		pod := claim.Name[strings.Index(claim.Name, "-")+1:]
+		if len(cr.Status.Nodes) != 0 && cr.Status.Nodes[len(cr.Status.Nodes)-1].Name == pod {
+			continue
+		}
+		cr.Status.Nodes = append(cr.Status.Nodes, v1alpha1.NodeProgress{Name: pod, State: icarus.StateRunning, StartTime: startTime})
+	}
+	icarus.SetConditions(&cr.Status.Conditions, "Restore", cr.Status.State, cr.Generation)
+	err = r.Status().Update(ctx, cr)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to update restore status")
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+// snapshotClaims returns the PVCs of the cluster provisioned from the VolumeSnapshots of the backup.
+// The backed up cluster is expected to have the same topology as the restored one.
+func snapshotClaims(cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) ([]*v1.PersistentVolumeClaim, error) {
+	dcNodes := make(map[string]map[string]bool)
+	for _, snapshot := range cb.Status.VolumeSnapshots {
+		if dcNodes[snapshot.DC] == nil {
+			dcNodes[snapshot.DC] = make(map[string]bool)
+		}
+		dcNodes[snapshot.DC][snapshot.Pod] = true
+	}
+
+	if len(dcNodes) != len(cc.Spec.DCs) {
+		return nil, errors.Errorf("backup %s has %d DCs, cluster %s has %d", cb.Name, len(dcNodes), cc.Name, len(cc.Spec.DCs))
+	}
+
+	for _, dc := range cc.Spec.DCs {
+		nodes, found := dcNodes[dc.Name]
+		if !found {
+			return nil, errors.Errorf("DC %q not found in backup %s", dc.Name, cb.Name)
+		}
+
+		if dc.Replicas == nil || int(*dc.Replicas) != len(nodes) {
+			return nil, errors.Errorf("DC %q has %d nodes in backup %s, the cluster should have the same number of replicas", dc.Name, len(nodes), cb.Name)
+		}
+	}
+
+	pvcLabels := labels.ComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra)
+	if cc.Spec.Cassandra.Persistence.Labels != nil {
+		pvcLabels = util.MergeMap(cc.Spec.Cassandra.Persistence.Labels, pvcLabels)
+	}
+
+	claims := make([]*v1.PersistentVolumeClaim, 0, len(cb.Status.VolumeSnapshots))
+	for _, snapshot := range cb.Status.VolumeSnapshots {
+		spec := cc.Spec.Cassandra.Persistence.DataVolumeClaimSpec.DeepCopy()
+		if snapshot.Volume == "commitlog" {
+			if !cc.Spec.Cassandra.Persistence.CommitLogVolume {
+				continue // the commit logs are flushed, the data volume has everything needed
+			}
+			spec = cc.Spec.Cassandra.Persistence.CommitLogVolumeClaimSpec.DeepCopy()
+		}
+
+		spec.DataSource = &v1.TypedLocalObjectReference{
+			APIGroup: &[]string{volumeSnapshotAPIGroup}[0],
+			Kind:     "VolumeSnapshot",
+			Name:     snapshot.Name,
+		}
+
+		// the volume can't be smaller than the snapshot it's provisioned from
+		if len(snapshot.RestoreSize) != 0 {
+			restoreSize, err := resource.ParseQuantity(snapshot.RestoreSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid restore size of volume snapshot %s", snapshot.Name)
+			}
+
+			if spec.Resources.Requests == nil {
+				spec.Resources.Requests = v1.ResourceList{}
+			}
+			if storage, ok := spec.Resources.Requests[v1.ResourceStorage]; !ok || storage.Cmp(restoreSize) < 0 {
+				spec.Resources.Requests[v1.ResourceStorage] = restoreSize
+			}
+		}
+
+		pod := names.DC(cc.Name, snapshot.DC) + strings.TrimPrefix(snapshot.Pod, names.DC(cb.Spec.CassandraCluster, snapshot.DC))
+		annotations := util.MergeMap(map[string]string{}, cc.Spec.Cassandra.Persistence.Annotations)
+		annotations[v1alpha1.VolumeSnapshotRestoreAnnotation] = cr.Name
+		claims = append(claims, &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        snapshot.Volume + "-" + pod, // the PVC name expected by the StatefulSet
+				Namespace:   cc.Namespace,
+				Labels:      labels.WithDCLabel(pvcLabels, snapshot.DC),
+				Annotations: annotations,
+			},
+			Spec: *spec,
+		})
+	}
+
+	return claims, nil
+}
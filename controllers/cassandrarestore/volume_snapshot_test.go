@@ -0,0 +1,72 @@
+package cassandrarestore
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSnapshotClaims(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cr := &v1alpha1.CassandraRestore{ObjectMeta: metav1.ObjectMeta{Name: "restore"}}
+	cb := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily"},
+		Spec:       v1alpha1.CassandraBackupSpec{CassandraCluster: "prod"},
+		Status: v1alpha1.CassandraBackupStatus{
+			VolumeSnapshots: []v1alpha1.BackupVolumeSnapshot{
+				{Name: "daily-data-prod-cassandra-dc1-0", Volume: "data", Pod: "prod-cassandra-dc1-0", DC: "dc1", RestoreSize: "20Gi"},
+				{Name: "daily-commitlog-prod-cassandra-dc1-0", Volume: "commitlog", Pod: "prod-cassandra-dc1-0", DC: "dc1"},
+				{Name: "daily-data-prod-cassandra-dc1-1", Volume: "data", Pod: "prod-cassandra-dc1-1", DC: "dc1", RestoreSize: "5Gi"},
+				{Name: "daily-commitlog-prod-cassandra-dc1-1", Volume: "commitlog", Pod: "prod-cassandra-dc1-1", DC: "dc1"},
+			},
+		},
+	}
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{{Name: "dc1", Replicas: proto.Int32(2)}},
+			Cassandra: &v1alpha1.Cassandra{
+				Persistence: v1alpha1.Persistence{
+					Enabled: true,
+					DataVolumeClaimSpec: v1.PersistentVolumeClaimSpec{
+						Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")}},
+					},
+				},
+			},
+		},
+	}
+
+	claims, err := snapshotClaims(cr, cb, cc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(claims).To(HaveLen(2)) // the cluster has no commit log volume
+	g.Expect(claims[0].Name).To(Equal("data-test-cassandra-dc1-0"))
+	g.Expect(claims[0].Namespace).To(Equal("default"))
+	g.Expect(claims[0].Labels).To(HaveKeyWithValue(v1alpha1.CassandraClusterInstance, "test"))
+	g.Expect(claims[0].Labels).To(HaveKeyWithValue(v1alpha1.CassandraClusterDC, "dc1"))
+	g.Expect(claims[0].Annotations).To(HaveKeyWithValue(v1alpha1.VolumeSnapshotRestoreAnnotation, "restore"))
+	g.Expect(claims[0].Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+	g.Expect(claims[0].Spec.DataSource.Name).To(Equal("daily-data-prod-cassandra-dc1-0"))
+	g.Expect(claims[0].Spec.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+	g.Expect(claims[1].Name).To(Equal("data-test-cassandra-dc1-1"))
+	g.Expect(claims[1].Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+	g.Expect(cc.Spec.Cassandra.Persistence.DataVolumeClaimSpec.DataSource).To(BeNil())
+
+	cc.Spec.Cassandra.Persistence.CommitLogVolume = true
+	claims, err = snapshotClaims(cr, cb, cc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(claims).To(HaveLen(4))
+	g.Expect(claims[1].Name).To(Equal("commitlog-test-cassandra-dc1-0"))
+
+	cc.Spec.DCs[0].Replicas = proto.Int32(3)
+	_, err = snapshotClaims(cr, cb, cc)
+	g.Expect(err).To(HaveOccurred())
+
+	cc.Spec.DCs[0] = v1alpha1.DC{Name: "dc2", Replicas: proto.Int32(2)}
+	_, err = snapshotClaims(cr, cb, cc)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	commitLogArchivingPropertiesFile = "commitlog_archiving.properties"
+	commitLogArchiveScriptFile       = "archive-commitlog.sh"
+	// the format of restore_point_in_time in commitlog_archiving.properties, always in GMT
+	commitLogRestorePointInTimeFormat = "2006:01:02 15:04:05"
+	// archived segments are kept for at least a day, so that failed uploads can be retried
+	minCommitLogArchiveRetention = 24 * time.Hour
+)
+
+// commitLogArchivingProperties renders the commitlog_archiving.properties used by Cassandra.
+// Commit logs are restored only while a point-in-time restore replays them.
+func commitLogArchivingProperties(cc *v1alpha1.CassandraCluster) string {
+	properties := []string{
+		fmt.Sprintf("archive_command=/bin/bash /etc/cassandra-configmaps/%s %%path %%name", commitLogArchiveScriptFile),
+	}
+
+	if pointInTime, restoring := commitLogRestorePointInTime(cc); restoring {
+		properties = append(properties,
+			"restore_command=/bin/cp -f %from %to",
+			"restore_directories="+v1alpha1.CommitLogRestoreDir,
+			"restore_point_in_time="+pointInTime.UTC().Format(commitLogRestorePointInTimeFormat),
+		)
+	}
+
+	return strings.Join(properties, "\n") + "\n"
+}
+
+// commitLogArchiveScript returns the script called by Cassandra for each commit log segment it's done with.
+// The segment is hard linked into the archive directory, or copied if the commit logs are on a separate volume.
+// Segments are removed from the archive once Icarus had enough time to upload them.
+func commitLogArchiveScript(cc *v1alpha1.CassandraCluster) string {
+	retention := minCommitLogArchiveRetention
+	if uploadInterval := 3 * cc.Spec.Cassandra.CommitLogArchiving.UploadInterval.Duration; uploadInterval > retention {
+		retention = uploadInterval
+	}
+
+	return fmt.Sprintf(`#!/bin/bash
+set -e
+mkdir -p %[1]s
+ln "$1" "%[1]s/$2" 2>/dev/null || cp "$1" "%[1]s/$2"
+find %[1]s -type f -mmin +%[2]d -delete
+`, v1alpha1.CommitLogArchiveDir, int(retention.Minutes()))
+}
+
+func commitLogRestorePointInTime(cc *v1alpha1.CassandraCluster) (time.Time, bool) {
+	value, found := cc.Annotations[v1alpha1.CommitLogRestorePointInTimeAnnotation]
+	if !found {
+		return time.Time{}, false
+	}
+
+	pointInTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return pointInTime, true
+}
+
+// reconcileCommitLogArchiving has the Icarus sidecar of each ready pod upload the archived commit logs once per upload interval.
+// Failed uploads don't block the reconcile loop, the archived segments are uploaded with the next request.
+func (r *CassandraClusterReconciler) reconcileCommitLogArchiving(ctx context.Context, cc *v1alpha1.CassandraCluster, podList *v1.PodList) {
+	archiving := cc.Spec.Cassandra.CommitLogArchiving
+	if archiving == nil {
+		return
+	}
+
+	for i, pod := range podList.Items {
+		if !coordinator.Ready(pod) {
+			continue
+		}
+
+		ic := r.IcarusClient(coordinator.URL(cc, &podList.Items[i]))
+		reqCtx, cancel := context.WithTimeout(ctx, coordinator.NodeRequestTimeout)
+		uploads, err := ic.CommitLogBackups(reqCtx)
+		cancel()
+		if err != nil {
+			r.Log.Warnf("Failed to get commit log uploads from pod %s: %s", pod.Name, err.Error())
+			continue
+		}
+
+		if !commitLogUploadDue(uploads, archiving.UploadInterval.Duration, time.Now()) {
+			continue
+		}
+
+		reqCtx, cancel = context.WithTimeout(ctx, coordinator.NodeRequestTimeout)
+		err = ic.BackupCommitLogs(reqCtx, icarus.CommitLogBackupRequest{
+			StorageLocation:          icarus.CommitLogStorageLocation(archiving.StorageLocation, cc.Name, pod.Labels[v1alpha1.CassandraClusterDC], pod.Name),
+			CommitLogArchiveOverride: v1alpha1.CommitLogArchiveDir,
+			ConcurrentConnections:    10,
+			K8sNamespace:             cc.Namespace,
+			K8sSecretName:            archiving.SecretName,
+		})
+		cancel()
+		if err != nil {
+			r.Log.Warnf("Failed to start commit log upload on pod %s: %s", pod.Name, err.Error())
+			continue
+		}
+
+		r.Log.Debugf("Started commit log upload on pod %s", pod.Name)
+	}
+}
+
+// commitLogUploadDue returns true if no upload is in progress and the last one was started more than an upload interval ago
+func commitLogUploadDue(uploads []icarus.CommitLogBackup, uploadInterval time.Duration, now time.Time) bool {
+	for _, upload := range uploads {
+		if upload.State == icarus.StatePending || upload.State == icarus.StateRunning {
+			return false
+		}
+
+		creationTime, err := time.Parse(time.RFC3339, upload.CreationTime)
+		if err == nil && now.Sub(creationTime) < uploadInterval {
+			return false
+		}
+	}
+
+	return true
+}
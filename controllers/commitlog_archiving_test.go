@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCommitLogArchivingProperties(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			Cassandra: &v1alpha1.Cassandra{
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{
+					StorageLocation: "s3://bucket",
+					UploadInterval:  &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
+	}
+
+	asserts.Expect(commitLogArchivingProperties(cc)).To(gomega.Equal(
+		"archive_command=/bin/bash /etc/cassandra-configmaps/archive-commitlog.sh %path %name\n"))
+
+	cc.Annotations = map[string]string{v1alpha1.CommitLogRestorePointInTimeAnnotation: "2022-05-01T14:30:00+02:00"}
+	asserts.Expect(commitLogArchivingProperties(cc)).To(gomega.Equal(
+		"archive_command=/bin/bash /etc/cassandra-configmaps/archive-commitlog.sh %path %name\n" +
+			"restore_command=/bin/cp -f %from %to\n" +
+			"restore_directories=/var/lib/cassandra/commitlog-restore\n" +
+			"restore_point_in_time=2022:05:01 12:30:00\n"))
+
+	asserts.Expect(commitLogArchiveScript(cc)).To(gomega.ContainSubstring("-mmin +1440 -delete"))
+	cc.Spec.Cassandra.CommitLogArchiving.UploadInterval.Duration = 12 * time.Hour
+	asserts.Expect(commitLogArchiveScript(cc)).To(gomega.ContainSubstring("-mmin +2160 -delete"))
+}
+
+func TestCommitLogUploadDue(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	upload := func(state string, age time.Duration) icarus.CommitLogBackup {
+		return icarus.CommitLogBackup{State: state, CreationTime: now.Add(-age).Format(time.RFC3339)}
+	}
+
+	asserts.Expect(commitLogUploadDue(nil, 10*time.Minute, now)).To(gomega.BeTrue())
+	asserts.Expect(commitLogUploadDue([]icarus.CommitLogBackup{upload(icarus.StateCompleted, 11*time.Minute)}, 10*time.Minute, now)).To(gomega.BeTrue())
+	asserts.Expect(commitLogUploadDue([]icarus.CommitLogBackup{upload(icarus.StateFailed, 5*time.Minute)}, 10*time.Minute, now)).To(gomega.BeFalse())
+	asserts.Expect(commitLogUploadDue([]icarus.CommitLogBackup{upload(icarus.StateRunning, time.Hour)}, 10*time.Minute, now)).To(gomega.BeFalse())
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/eventhandler"
 	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
 	"github.com/ibm/cassandra-operator/controllers/jobs"
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
@@ -37,6 +38,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbac "k8s.io/api/rbac/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -86,6 +88,7 @@ type CassandraClusterReconciler struct {
 	CqlClient     func(cluster *gocql.ClusterConfig) (cql.CqlClient, error)
 	ReaperClient  func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient
 	NodectlClient func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl
+	IcarusClient  func(icarusURL string) icarus.Icarus
 }
 
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandraclusters,verbs=get;list;watch;create;update;patch;delete
@@ -95,7 +98,7 @@ type CassandraClusterReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete;
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;create;update;deletecollection;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;create;update;delete;deletecollection;
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;patch;update
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=list;get;watch;create;update;delete
@@ -108,6 +111,7 @@ type CassandraClusterReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=list;watch;get;create;update;delete
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;create;update;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=list;watch;get;create;update;delete;patch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=list;watch;get;create;update;delete
 
 func (r *CassandraClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -138,14 +142,27 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 
 	r.defaultCassandraCluster(cc)
 
+	// plan mode pauses the whole reconcile, so that nothing is changed in the cluster until the plan is reviewed
+	if _, planMode := cc.Annotations[v1alpha1.PlanAnnotation]; planMode {
+		if err = r.reconcilePlan(ctx, cc); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile plan")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute * 1}, nil
+	}
+
+	if err = r.cleanupPlan(ctx, cc); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err = r.cleanupNetworkPolicies(ctx, cc); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to cleanup network policies")
 	}
 
 	clusterReady := false
-	ccStatus := cc.DeepCopy()
 	defer func() {
-		if ccStatus.Status.Ready != clusterReady {
+		// copied when the reconcile ends, so that the status updated during the reconcile is not overwritten
+		if cc.Status.Ready != clusterReady {
+			ccStatus := cc.DeepCopy()
 			ccStatus.Status.Ready = clusterReady
 			statusErr := r.Status().Update(ctx, ccStatus)
 			if statusErr != nil {
@@ -153,6 +170,11 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 			}
 		}
 	}()
+
+	if err = r.reconcileQueuedOperations(ctx, cc); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	err = r.reconcileCassandraRBAC(ctx, cc)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -261,6 +283,18 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, errors.Wrap(err, "Error reconciling cassandra pods configmap")
 	}
 
+	if len(podList.Items) == 0 {
+		restoreName, err := r.pendingVolumeSnapshotRestore(ctx, cc)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if len(restoreName) != 0 {
+			r.Log.Infof("Waiting for restore %s to provision the volumes of the cluster. Trying again in %s...", restoreName, r.Cfg.RetryDelay)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+	}
+
 	if err = r.reconcileCassandra(ctx, cc, restartChecksum); err != nil {
 		if errors.Cause(err) == errTLSSecretNotFound || errors.Cause(err) == errTLSSecretInvalid {
 			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
@@ -268,6 +302,14 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, errors.Wrap(err, "Error reconciling statefulsets")
 	}
 
+	if err = r.reconcileCassandraPodRackLabels(ctx, cc, podList, nodeList); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Error reconciling cassandra pod rack labels")
+	}
+
+	if err = r.reconcilePodDisruptionBudgets(ctx, cc); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Error reconciling pod disruption budgets")
+	}
+
 	allDCs, err := r.getAllDCs(ctx, cc, proberClient)
 	if err != nil {
 		if errors.Cause(err) == ErrRegionNotReady {
@@ -305,6 +347,16 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		}
 	}
 
+	restarting, err := r.reconcileRestartingPod(ctx, cc, podList, auth)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile restarting pod")
+	}
+
+	if restarting {
+		r.Log.Infof("Pod restart in progress. Trying again in %s...", r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
 	clusterReady, err = r.clusterReady(ctx, cc, proberClient)
 	if err != nil {
 		clusterReady = false
@@ -316,6 +368,26 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
+	restarting, err = r.reconcileRollingRestart(ctx, cc, podList, auth)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile rolling restart")
+	}
+
+	if restarting {
+		r.Log.Infof("Rolling restart in progress. Trying again in %s...", r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	r.reconcileCommitLogArchiving(ctx, cc, podList)
+
+	if err = r.reconcileRuntimeSettings(ctx, cc, podList, auth); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile runtime settings")
+	}
+
+	if err = r.reconcileLoggingLevels(ctx, cc, podList, auth); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile logging levels")
+	}
+
 	cqlClient, err := r.reconcileAdminRole(ctx, cc, auth, allDCs)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile Admin Role")
@@ -374,6 +446,15 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile repair schedules")
 	}
 
+	fencedBy, err := r.restoreFence(ctx, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileRestoreFence(ctx, cc, reaperClient, fencedBy); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile restore fence")
+	}
+
 	err = r.reconcileKeyspaces(ctx, cc, cqlClient, reaperClient, allDCs)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile keyspaces")
@@ -387,7 +468,7 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, err
 	}
 
-	err = r.reconcileNetworkPolicies(ctx, cc, proberClient, podList)
+	err = r.reconcileNetworkPolicies(ctx, cc, proberClient, podList, fencedBy)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Error reconciling network policies")
 	}
@@ -395,6 +476,16 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 	return ctrl.Result{RequeueAfter: time.Minute * 1}, nil
 }
 
+// restoreCluster maps a CassandraRestore that fences the cluster or provisions its volumes to the CassandraCluster it restores
+func restoreCluster(obj client.Object) []reconcile.Request {
+	cr, ok := obj.(*v1alpha1.CassandraRestore)
+	if !ok || (!cr.Spec.Fence && cr.Spec.Mode != v1alpha1.RestoreModeVolumeSnapshot) {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: cr.Spec.CassandraCluster, Namespace: cr.Namespace}}}
+}
+
 func needsRequeue(result ctrl.Result, err error) bool {
 	return result.Requeue || result.RequeueAfter.Nanoseconds() > 0 || err != nil
 }
@@ -411,9 +502,11 @@ func SetupCassandraReconciler(r reconcile.Reconciler, mgr manager.Manager, logr
 		Owns(&rbac.Role{}).
 		Owns(&rbac.RoleBinding{}).
 		Owns(&v1.ServiceAccount{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Watches(&source.Kind{Type: &v1.Secret{}}, eventhandler.NewAnnotationEventHandler()).
 		Watches(&source.Kind{Type: &v1.ConfigMap{}}, eventhandler.NewAnnotationEventHandler()).
-		Watches(&source.Channel{Source: reconcileChan}, &handler.EnqueueRequestForObject{})
+		Watches(&source.Channel{Source: reconcileChan}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &v1alpha1.CassandraRestore{}}, handler.EnqueueRequestsFromMapFunc(restoreCluster)) // lifts the restore fence, creates the statefulsets once the volumes are restored
 
 	// WithEventFilter(predicate.NewPredicate(logr)) // uncomment to see kubernetes events in the logs, e.g. ConfigMap updates
 
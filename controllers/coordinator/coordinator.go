@@ -0,0 +1,124 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeRequestTimeout bounds a request to the Icarus sidecar of a single node, so that an unresponsive node doesn't block the reconcile
+const NodeRequestTimeout = 10 * time.Second
+
+// Pod returns the Cassandra pod that coordinates Icarus global requests for the cluster.
+// The current coordinator is kept as long as it's eligible, since only that pod has the global request info.
+// Otherwise the first ready pod is chosen. Returns nil if no pod can be the coordinator.
+func Pod(ctx context.Context, cl client.Client, cc *dbv1alpha1.CassandraCluster, current string) (*v1.Pod, error) {
+	pods, err := Pods(ctx, cl, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectPod(cc, pods, current), nil
+}
+
+// Pods returns the Cassandra pods of the cluster
+func Pods(ctx context.Context, cl client.Client, cc *dbv1alpha1.CassandraCluster) ([]v1.Pod, error) {
+	pods := &v1.PodList{}
+	err := cl.List(ctx, pods, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.ComponentLabels(cc, dbv1alpha1.CassandraClusterComponentCassandra)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cassandra pods")
+	}
+
+	return pods.Items, nil
+}
+
+// URL returns the Icarus URL of the pod
+func URL(cc *dbv1alpha1.CassandraCluster, pod *v1.Pod) string {
+	svc := names.DC(cc.Name, pod.Labels[dbv1alpha1.CassandraClusterDC])
+	return fmt.Sprintf("http://%s.%s.%s.svc.cluster.local:%d", pod.Name, svc, cc.Namespace, dbv1alpha1.IcarusPort)
+}
+
+func selectPod(cc *dbv1alpha1.CassandraCluster, pods []v1.Pod, current string) *v1.Pod {
+	dcOrder := make(map[string]int, len(cc.Spec.DCs))
+	for i, dc := range cc.Spec.DCs {
+		dcOrder[dc.Name] = i
+	}
+
+	var candidates []v1.Pod
+	for _, pod := range pods {
+		if _, dcExists := dcOrder[pod.Labels[dbv1alpha1.CassandraClusterDC]]; !dcExists {
+			continue // the DC is being decommissioned
+		}
+
+		if pod.DeletionTimestamp != nil || !Ready(pod) || inMaintenance(cc, pod) {
+			continue
+		}
+
+		if pod.Name == current {
+			return &pod
+		}
+
+		candidates = append(candidates, pod)
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// prefer pods from the first DCs with the lowest ordinal, i.e. pod-0 of the first DC if it's available
+	sort.Slice(candidates, func(i, j int) bool {
+		dcI, dcJ := dcOrder[candidates[i].Labels[dbv1alpha1.CassandraClusterDC]], dcOrder[candidates[j].Labels[dbv1alpha1.CassandraClusterDC]]
+		if dcI != dcJ {
+			return dcI < dcJ
+		}
+
+		return names.PodOrdinal(candidates[i].Name) < names.PodOrdinal(candidates[j].Name)
+	})
+
+	return &candidates[0]
+}
+
+// Ready returns true if all containers of the pod are ready
+func Ready(pod v1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+func inMaintenance(cc *dbv1alpha1.CassandraCluster, pod v1.Pod) bool {
+	dcName := pod.Labels[dbv1alpha1.CassandraClusterDC]
+	if cc.MaintenanceRequested(dcName, pod.Name, time.Now()) {
+		return true
+	}
+
+	for _, entry := range cc.Status.MaintenanceState {
+		if entry.DC != dcName {
+			continue
+		}
+
+		for _, podName := range entry.Pods {
+			if string(podName) == pod.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+package coordinator
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectPod(t *testing.T) {
+	cc := &dbv1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: dbv1alpha1.CassandraClusterSpec{
+			DCs: []dbv1alpha1.DC{
+				{Name: "dc1", Replicas: proto.Int32(3)},
+				{Name: "dc2", Replicas: proto.Int32(3)},
+			},
+		},
+	}
+
+	pods := []v1.Pod{
+		testPod("test-cassandra-dc2-0", "dc2"),
+		testPod("test-cassandra-dc1-10", "dc1"),
+		testPod("test-cassandra-dc1-2", "dc1"),
+		testPod("test-cassandra-dc1-0", "dc1"),
+		testPod("test-cassandra-dc3-0", "dc3"),
+	}
+
+	tests := []struct {
+		name        string
+		current     string
+		maintenance []dbv1alpha1.Maintenance
+		notReady    []string
+		expected    string
+	}{
+		{
+			name:     "first pod of the first dc is preferred",
+			expected: "test-cassandra-dc1-0",
+		},
+		{
+			name:     "current coordinator is kept",
+			current:  "test-cassandra-dc1-2",
+			expected: "test-cassandra-dc1-2",
+		},
+		{
+			name:     "not ready coordinator is replaced",
+			current:  "test-cassandra-dc1-2",
+			notReady: []string{"test-cassandra-dc1-2", "test-cassandra-dc1-0"},
+			expected: "test-cassandra-dc1-10",
+		},
+		{
+			name:        "pods in maintenance are skipped",
+			current:     "test-cassandra-dc1-0",
+			maintenance: []dbv1alpha1.Maintenance{{DC: "dc1"}},
+			expected:    "test-cassandra-dc2-0",
+		},
+		{
+			name:     "pods from decommissioned dcs are skipped",
+			current:  "test-cassandra-dc3-0",
+			expected: "test-cassandra-dc1-0",
+		},
+		{
+			name:     "no pods available",
+			notReady: []string{"test-cassandra-dc1-0", "test-cassandra-dc1-2", "test-cassandra-dc1-10", "test-cassandra-dc2-0"},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			testCC := cc.DeepCopy()
+			testCC.Spec.Maintenance = test.maintenance
+
+			var testPods []v1.Pod
+			for _, pod := range pods {
+				pod := *pod.DeepCopy()
+				for _, podName := range test.notReady {
+					if pod.Name == podName {
+						pod.Status.ContainerStatuses[0].Ready = false
+					}
+				}
+				testPods = append(testPods, pod)
+			}
+
+			pod := selectPod(testCC, testPods, test.current)
+			if len(test.expected) == 0 {
+				g.Expect(pod).To(BeNil())
+				return
+			}
+
+			g.Expect(pod).ToNot(BeNil())
+			g.Expect(pod.Name).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &dbv1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	pod := testPod("test-cassandra-dc2-1", "dc2")
+	g.Expect(URL(cc, &pod)).To(Equal("http://test-cassandra-dc2-1.test-cassandra-dc2.default.svc.cluster.local:4567"))
+}
+
+func testPod(name, dc string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{dbv1alpha1.CassandraClusterDC: dc},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "cassandra", Ready: true}},
+		},
+	}
+}
@@ -1,4 +1,4 @@
-package controllers
+package cql
 
 import (
 	"fmt"
@@ -11,7 +11,8 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/names"
 )
 
-func newCassandraConfig(cc *v1alpha1.CassandraCluster, adminRole string, adminPwd string, logr *zap.SugaredLogger) *gocql.ClusterConfig {
+// NewClusterConfig returns the config used to connect to the CassandraCluster with the given role
+func NewClusterConfig(cc *v1alpha1.CassandraCluster, adminRole string, adminPwd string, logr *zap.SugaredLogger) *gocql.ClusterConfig {
 	cassCfg := gocql.NewCluster(fmt.Sprintf("%s.%s.svc.cluster.local", names.DCService(cc.Name, cc.Spec.DCs[0].Name), cc.Namespace))
 	cassCfg.Authenticator = &gocql.PasswordAuthenticator{
 		Username: adminRole,
@@ -14,6 +14,8 @@ const (
 
 type CqlClient interface {
 	GetKeyspacesInfo() ([]Keyspace, error)
+	GetTables() ([]Table, error)
+	GetSchemaVersion() (string, error)
 	UpdateRF(keyspaceName string, strategyOptions map[string]string) error
 	GetRoles() ([]Role, error)
 	CreateRole(role Role) error
@@ -50,6 +52,11 @@ type Keyspace struct {
 	Replication map[string]string
 }
 
+type Table struct {
+	Keyspace string
+	Name     string
+}
+
 func (c cassandraClient) Query(stmt string, values ...interface{}) error {
 	return c.Session.Query(stmt, values).Exec()
 }
@@ -72,6 +79,32 @@ func (c cassandraClient) GetKeyspacesInfo() ([]Keyspace, error) {
 	return keyspaces, nil
 }
 
+func (c cassandraClient) GetTables() ([]Table, error) {
+	iter := c.Session.Query("SELECT keyspace_name,table_name FROM system_schema.tables").Iter()
+	var keyspaceName, tableName string
+	tables := make([]Table, 0, iter.NumRows())
+	for iter.Scan(&keyspaceName, &tableName) {
+		tables = append(tables, Table{Keyspace: keyspaceName, Name: tableName})
+	}
+
+	err := iter.Close()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to close iterator")
+	}
+	return tables, nil
+}
+
+// GetSchemaVersion returns the schema version of the node the session is connected to
+func (c cassandraClient) GetSchemaVersion() (string, error) {
+	var schemaVersion gocql.UUID
+	err := c.Session.Query("SELECT schema_version FROM system.local").Scan(&schemaVersion)
+	if err != nil {
+		return "", err
+	}
+
+	return schemaVersion.String(), nil
+}
+
 func (c cassandraClient) UpdateRF(keyspaceName string, rfOptions map[string]string) error {
 	query := fmt.Sprintf("ALTER KEYSPACE %s %s ;", keyspaceName, ReplicationQuery(rfOptions))
 	return c.Session.Query(query).Exec()
@@ -44,12 +44,23 @@ func (r *CassandraClusterReconciler) reconcileCQLConfigMaps(ctx context.Context,
 			continue
 		}
 
+		keyspaceToRepair := cm.Annotations[annotationRepairKeyspace]
+		if len(keyspaceToRepair) > 0 {
+			// the scripts are executed together with the repair
+			allowed, err := r.maintenanceAllowed(ctx, cc, dbv1alpha1.QueuedOperationRepair, keyspaceToRepair)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				continue
+			}
+		}
+
 		err := r.executeCQLCMScripts(cc, cm, cqlClient)
 		if err != nil {
 			return errors.Wrapf(err, "failed to execute CQL scripts from ConfigMap %s/%s", cm.Namespace, cm.Name)
 		}
 
-		keyspaceToRepair := cm.Annotations[annotationRepairKeyspace]
 		if len(keyspaceToRepair) > 0 {
 			r.Log.Infof("Starting repair for %q keyspace", keyspaceToRepair)
 			err := reaperClient.RunRepair(ctx, keyspaceToRepair, repairCauseCQLConfigMap)
@@ -8,6 +8,8 @@ import (
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/names"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var (
@@ -59,6 +61,15 @@ func (r *CassandraClusterReconciler) defaultCassandraCluster(cc *dbv1alpha1.Cass
 		cc.Spec.NetworkPolicies.AllowReaperNodeIPs = proto.Bool(true)
 	}
 
+	if cc.Spec.PodDisruptionBudget.Enabled == nil {
+		cc.Spec.PodDisruptionBudget.Enabled = proto.Bool(true)
+	}
+
+	if cc.Spec.PodDisruptionBudget.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(1)
+		cc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+	}
+
 	r.defaultServerTLS(cc)
 	r.defaultClientTLS(cc)
 
@@ -135,6 +146,10 @@ func (r *CassandraClusterReconciler) defaultReaper(cc *dbv1alpha1.CassandraClust
 }
 
 func (r *CassandraClusterReconciler) defaultProber(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.Prober.Replicas == 0 {
+		cc.Spec.Prober.Replicas = 1
+	}
+
 	if cc.Spec.Prober.Image == "" {
 		cc.Spec.Prober.Image = r.Cfg.DefaultProberImage
 	}
@@ -219,6 +234,10 @@ func (r *CassandraClusterReconciler) defaultCassandra(cc *dbv1alpha1.CassandraCl
 
 	cc.Spec.Cassandra.Persistence.CommitLogVolumeClaimSpec.AccessModes = []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}
 
+	if cc.Spec.Cassandra.CommitLogArchiving != nil && cc.Spec.Cassandra.CommitLogArchiving.UploadInterval == nil {
+		cc.Spec.Cassandra.CommitLogArchiving.UploadInterval = &metav1.Duration{Duration: 10 * time.Minute}
+	}
+
 	r.defaultMonitoring(cc)
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/config"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestDefaultingFunction(t *testing.T) {
@@ -27,12 +28,15 @@ func TestDefaultingFunction(t *testing.T) {
 	g.Expect(cc.Spec.CQLConfigMapLabelKey).To(Equal(defaultCQLConfigMapLabelKey))
 	g.Expect(cc.Spec.TopologySpreadByZone).ToNot(BeNil())
 	g.Expect(*cc.Spec.TopologySpreadByZone).To(BeTrue())
+	g.Expect(*cc.Spec.PodDisruptionBudget.Enabled).To(BeTrue())
+	g.Expect(*cc.Spec.PodDisruptionBudget.MaxUnavailable).To(Equal(intstr.FromInt(1)))
 	g.Expect(cc.Spec.Cassandra).ToNot(BeNil())
 	g.Expect(cc.Spec.Cassandra.Image).To(Equal("cassandra/image"))
 	g.Expect(cc.Spec.Cassandra.ImagePullPolicy).To(Equal(v1.PullIfNotPresent))
 	g.Expect(cc.Spec.Cassandra.NumSeeds).To(Equal(int32(2)))
 	g.Expect(cc.Spec.Cassandra.PurgeGossip).ToNot(BeNil())
 	g.Expect(*cc.Spec.Cassandra.PurgeGossip).To(Equal(true))
+	g.Expect(cc.Spec.Prober.Replicas).To(Equal(int32(1)))
 	g.Expect(cc.Spec.Prober.Image).To(Equal("prober/image"))
 	g.Expect(cc.Spec.Prober.ImagePullPolicy).To(Equal(v1.PullIfNotPresent))
 	g.Expect(cc.Spec.Prober.Jolokia.Image).To(Equal("jolokia/image"))
@@ -25,12 +25,54 @@ const (
 	EventCassandraBackupNotFound          = "CassandraBackupNotFound"
 	EventStorageCredentialsSecretNotFound = "StorageCredentialsSecretNotFound"
 	EventStorageCredentialsSecretInvalid  = "StorageCredentialsSecretInvalid"
+	EventBackupVolumeNotConfigured        = "BackupVolumeNotConfigured"
+	EventCoordinatorNotAvailable          = "CoordinatorNotAvailable"
+	EventBackupScheduleInvalid            = "InvalidBackupSchedule"
+	EventBackupScheduleSkipped            = "BackupScheduleSkipped"
+	EventBackupRemovalFailed              = "BackupRemovalFailed"
+	EventCommitLogArchivingNotConfigured  = "CommitLogArchivingNotConfigured"
+	EventPointInTimeBackupNotFound        = "PointInTimeBackupNotFound"
+	EventCommitLogRestoreFailed           = "CommitLogRestoreFailed"
+	EventRestoreDCMappingInvalid          = "RestoreDCMappingInvalid"
+	EventRestoreLoadNotSupported          = "RestoreLoadNotSupported"
+	EventRestorePreflightFailed           = "RestorePreflightFailed"
+	EventBackupCatalogSyncFailed          = "BackupCatalogSyncFailed"
+	EventCatalogBackupNotFound            = "CatalogBackupNotFound"
+	EventVolumeSnapshotFailed             = "VolumeSnapshotFailed"
+	EventNodeFlushFailed                  = "NodeFlushFailed"
+	EventVolumeSnapshotRestoreFailed      = "VolumeSnapshotRestoreFailed"
+	EventEncryptionSecretNotFound         = "EncryptionSecretNotFound"
+	EventEncryptionSecretInvalid          = "EncryptionSecretInvalid"
+	EventRestoreEncryptionMismatch        = "RestoreEncryptionMismatch"
+	EventStorageVerificationFailed        = "StorageVerificationFailed"
+	EventNodeShutdownFailed               = "NodeShutdownFailed"
+	EventRuntimeSettingsDrifted           = "RuntimeSettingsDrifted"
+	EventLoggingLevelNotApplied           = "LoggingLevelNotApplied"
 
-	EventAdminRoleChanged = "AdminRoleChanged"
-	EventRegionInit       = "RegionInit"
-	EventDCInit           = "DCInit"
-	EventCQLScriptSuccess = "CQLScriptSuccess"
-	EventCQLScriptFailed  = "CQLScriptFailed"
+	EventAdminRoleChanged        = "AdminRoleChanged"
+	EventRegionInit              = "RegionInit"
+	EventDCInit                  = "DCInit"
+	EventCQLScriptSuccess        = "CQLScriptSuccess"
+	EventCQLScriptFailed         = "CQLScriptFailed"
+	EventBackupScheduled         = "BackupScheduled"
+	EventBackupExpired           = "BackupExpired"
+	EventBackupRemoved           = "BackupRemoved"
+	EventCoordinatorChanged      = "CoordinatorChanged"
+	EventCommitLogReplay         = "CommitLogReplay"
+	EventRestoreLoadStarted      = "RestoreLoadStarted"
+	EventClusterFenced           = "ClusterFenced"
+	EventClusterUnfenced         = "ClusterUnfenced"
+	EventBackupCancelled         = "BackupCancelled"
+	EventRestoreCancelled        = "RestoreCancelled"
+	EventRollingRestartStarted   = "RollingRestartStarted"
+	EventPodRestarted            = "PodRestarted"
+	EventRollingRestartCompleted = "RollingRestartCompleted"
+	EventRestartPending          = "RestartPending"
+	EventPendingRestartApplied   = "PendingRestartApplied"
+	EventOperationQueued         = "OperationQueued"
+	EventMaintenanceExpired      = "MaintenanceExpired"
+	EventPlanUpdated             = "PlanUpdated"
+	EventRuntimeSettingsApplied  = "RuntimeSettingsApplied"
 )
 
 // EventReason is the reason why the event was created. The value appears in the 'Reason' tab of the events list
@@ -15,28 +15,31 @@ const (
 	StateCompleted = "COMPLETED"
 	StateCancelled = "CANCELLED"
 	StateFailed    = "FAILED"
+	// StateCancelling is set by the operator, not Icarus, while a cancelled backup or restore waits for all nodes to stop their operations
+	StateCancelling = "CANCELLING"
 )
 
 type BackupRequest struct {
-	Type                   string    `json:"type"`
-	StorageLocation        string    `json:"storageLocation"`
-	DataDirs               []string  `json:"dataDirs"`
-	GlobalRequest          bool      `json:"globalRequest"`
-	SnapshotTag            string    `json:"snapshotTag"`
-	K8sNamespace           string    `json:"k8sNamespace,omitempty"`
-	K8sSecretName          string    `json:"k8sSecretName,omitempty"`
-	Duration               string    `json:"duration,omitempty"`
-	Bandwidth              *DataRate `json:"bandwidth,omitempty"`
-	ConcurrentConnections  int64     `json:"concurrentConnections,omitempty"`
-	DC                     string    `json:"dc,omitempty"`
-	Entities               string    `json:"entities,omitempty"`
-	Timeout                int64     `json:"timeout,omitempty"`
-	MetadataDirective      string    `json:"metadataDirective,omitempty"`
-	Insecure               bool      `json:"insecure"`
-	CreateMissingBucket    bool      `json:"createMissingBucket"`
-	SkipRefreshing         bool      `json:"skipRefreshing"`
-	SkipBucketVerification bool      `json:"skipBucketVerification"`
-	Retry                  Retry     `json:"retry,omitempty"`
+	Type                   string      `json:"type"`
+	StorageLocation        string      `json:"storageLocation"`
+	DataDirs               []string    `json:"dataDirs"`
+	GlobalRequest          bool        `json:"globalRequest"`
+	SnapshotTag            string      `json:"snapshotTag"`
+	K8sNamespace           string      `json:"k8sNamespace,omitempty"`
+	K8sSecretName          string      `json:"k8sSecretName,omitempty"`
+	Duration               string      `json:"duration,omitempty"`
+	Bandwidth              *DataRate   `json:"bandwidth,omitempty"`
+	ConcurrentConnections  int64       `json:"concurrentConnections,omitempty"`
+	DC                     string      `json:"dc,omitempty"`
+	Entities               string      `json:"entities,omitempty"`
+	Timeout                int64       `json:"timeout,omitempty"`
+	MetadataDirective      string      `json:"metadataDirective,omitempty"`
+	Insecure               bool        `json:"insecure"`
+	CreateMissingBucket    bool        `json:"createMissingBucket"`
+	SkipRefreshing         bool        `json:"skipRefreshing"`
+	SkipBucketVerification bool        `json:"skipBucketVerification"`
+	Retry                  Retry       `json:"retry,omitempty"`
+	Encryption             *Encryption `json:"encryption,omitempty"`
 }
 
 type DataRate struct {
@@ -45,33 +48,38 @@ type DataRate struct {
 }
 
 type Backup struct {
-	ID                     string    `json:"id"`
-	CreationTime           string    `json:"creationTime"`
-	State                  string    `json:"state"`
-	Errors                 []Error   `json:"errors"`
-	Progress               float64   `json:"progress"`
-	StartTime              string    `json:"startTime"`
-	Type                   string    `json:"type"`
-	StorageLocation        string    `json:"storageLocation"`
-	ConcurrentConnections  int64     `json:"concurrentConnections"`
-	MetadataDirective      string    `json:"metadataDirective"`
-	Entities               string    `json:"entities"`
-	SnapshotTag            string    `json:"snapshotTag"`
-	GlobalRequest          bool      `json:"globalRequest"`
-	Timeout                int64     `json:"timeout"`
-	Insecure               bool      `json:"insecure"`
-	SchemaVersion          string    `json:"schemaVersion"`
-	CreateMissingBucket    bool      `json:"createMissingBucket"`
-	SkipBucketVerification bool      `json:"skipBucketVerification"`
-	UploadClusterTopology  bool      `json:"uploadClusterTopology"`
-	Retry                  Retry     `json:"retry"`
-	SkipRefreshing         bool      `json:"skipRefreshing"`
-	DataDirs               []string  `json:"dataDirs"`
-	DC                     string    `json:"dc"`
-	K8sNamespace           string    `json:"k8sNamespace"`
-	K8sSecretName          string    `json:"k8sSecretName"`
-	Duration               string    `json:"duration"`
-	Bandwidth              *DataRate `json:"bandwidth"`
+	ID                     string      `json:"id"`
+	CreationTime           string      `json:"creationTime"`
+	State                  string      `json:"state"`
+	Errors                 []Error     `json:"errors"`
+	Progress               float64     `json:"progress"`
+	StartTime              string      `json:"startTime"`
+	CompletionTime         string      `json:"completionTime"`
+	Type                   string      `json:"type"`
+	StorageLocation        string      `json:"storageLocation"`
+	ConcurrentConnections  int64       `json:"concurrentConnections"`
+	MetadataDirective      string      `json:"metadataDirective"`
+	Entities               string      `json:"entities"`
+	SnapshotTag            string      `json:"snapshotTag"`
+	GlobalRequest          bool        `json:"globalRequest"`
+	Timeout                int64       `json:"timeout"`
+	Insecure               bool        `json:"insecure"`
+	SchemaVersion          string      `json:"schemaVersion"`
+	CreateMissingBucket    bool        `json:"createMissingBucket"`
+	SkipBucketVerification bool        `json:"skipBucketVerification"`
+	UploadClusterTopology  bool        `json:"uploadClusterTopology"`
+	Retry                  Retry       `json:"retry"`
+	SkipRefreshing         bool        `json:"skipRefreshing"`
+	DataDirs               []string    `json:"dataDirs"`
+	DC                     string      `json:"dc"`
+	K8sNamespace           string      `json:"k8sNamespace"`
+	K8sSecretName          string      `json:"k8sSecretName"`
+	Duration               string      `json:"duration"`
+	Bandwidth              *DataRate   `json:"bandwidth"`
+	Encryption             *Encryption `json:"encryption"`
+	// Bytes uploaded so far and the total bytes to upload
+	TransferredBytes int64 `json:"transferredBytes"`
+	TotalBytes       int64 `json:"totalBytes"`
 }
 
 type Error struct {
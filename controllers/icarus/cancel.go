@@ -0,0 +1,40 @@
+package icarus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Cancel stops a running operation. Icarus reports the operation as CANCELLED once it has stopped.
+func (c *client) Cancel(ctx context.Context, operationID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.addr+"/operations/"+operationID, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the operation is not known to Icarus anymore, e.g. if the sidecar has been restarted, so there's nothing to cancel
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("cancel request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
+
+// Finished returns true if an operation in the state has finished, either successfully or not
+func Finished(state string) bool {
+	return state == StateCompleted || state == StateFailed || state == StateCancelled
+}
@@ -0,0 +1,153 @@
+package icarus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+type CommitLogBackupRequest struct {
+	Type                     string `json:"type"`
+	StorageLocation          string `json:"storageLocation"`
+	CommitLogArchiveOverride string `json:"commitLogArchiveOverride"`
+	ConcurrentConnections    int64  `json:"concurrentConnections"`
+	K8sNamespace             string `json:"k8sNamespace,omitempty"`
+	K8sSecretName            string `json:"k8sSecretName,omitempty"`
+	Insecure                 bool   `json:"insecure"`
+	SkipBucketVerification   bool   `json:"skipBucketVerification"`
+}
+
+type CommitLogBackup struct {
+	ID                       string  `json:"id"`
+	CreationTime             string  `json:"creationTime"`
+	State                    string  `json:"state"`
+	Errors                   []Error `json:"errors"`
+	Progress                 float64 `json:"progress"`
+	StartTime                string  `json:"startTime"`
+	CompletionTime           string  `json:"completionTime"`
+	Type                     string  `json:"type"`
+	StorageLocation          string  `json:"storageLocation"`
+	CommitLogArchiveOverride string  `json:"commitLogArchiveOverride"`
+	K8sNamespace             string  `json:"k8sNamespace"`
+	K8sSecretName            string  `json:"k8sSecretName"`
+}
+
+type CommitLogRestoreRequest struct {
+	Type                   string `json:"type"`
+	StorageLocation        string `json:"storageLocation"`
+	CommitlogDownloadDir   string `json:"commitlogDownloadDir"`
+	TimestampStart         int64  `json:"timestampStart"`
+	TimestampEnd           int64  `json:"timestampEnd"`
+	ConcurrentConnections  int64  `json:"concurrentConnections"`
+	K8sNamespace           string `json:"k8sNamespace,omitempty"`
+	K8sSecretName          string `json:"k8sSecretName,omitempty"`
+	Insecure               bool   `json:"insecure"`
+	SkipBucketVerification bool   `json:"skipBucketVerification"`
+}
+
+type CommitLogRestore struct {
+	ID                   string  `json:"id"`
+	CreationTime         string  `json:"creationTime"`
+	State                string  `json:"state"`
+	Errors               []Error `json:"errors"`
+	Progress             float64 `json:"progress"`
+	StartTime            string  `json:"startTime"`
+	CompletionTime       string  `json:"completionTime"`
+	Type                 string  `json:"type"`
+	StorageLocation      string  `json:"storageLocation"`
+	CommitlogDownloadDir string  `json:"commitlogDownloadDir"`
+	TimestampStart       int64   `json:"timestampStart"`
+	TimestampEnd         int64   `json:"timestampEnd"`
+	K8sNamespace         string  `json:"k8sNamespace"`
+	K8sSecretName        string  `json:"k8sSecretName"`
+}
+
+func (c *client) BackupCommitLogs(ctx context.Context, backupReq CommitLogBackupRequest) error {
+	backupReq.Type = "commitlog-backup"
+	return c.createOperation(ctx, backupReq.Type, backupReq)
+}
+
+func (c *client) CommitLogBackups(ctx context.Context) ([]CommitLogBackup, error) {
+	var backups []CommitLogBackup
+	err := c.listOperations(ctx, "commitlog-backup", &backups)
+	if err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+func (c *client) RestoreCommitLogs(ctx context.Context, restoreReq CommitLogRestoreRequest) error {
+	restoreReq.Type = "commitlog-restore"
+	return c.createOperation(ctx, restoreReq.Type, restoreReq)
+}
+
+func (c *client) CommitLogRestores(ctx context.Context) ([]CommitLogRestore, error) {
+	var restores []CommitLogRestore
+	err := c.listOperations(ctx, "commitlog-restore", &restores)
+	if err != nil {
+		return nil, err
+	}
+
+	return restores, nil
+}
+
+func (c *client) createOperation(ctx context.Context, operationType string, operation interface{}) error {
+	body, err := json.Marshal(operation)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.addr+"/operations", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s request failed: code: %d, body: %s", operationType, resp.StatusCode, string(b))
+	}
+
+	return nil
+}
+
+func (c *client) listOperations(ctx context.Context, operationType string, operations interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.addr+"/operations?type="+operationType, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s request failed: code: %d, body: %s", operationType, resp.StatusCode, string(b))
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, operations)
+}
+
+// CommitLogStorageLocation returns the location of the commit logs archived by a Cassandra pod as used by Icarus.
+// Each pod uploads to its own location since commit logs are node specific.
+func CommitLogStorageLocation(storageLocation, clusterName, dcName, podName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", strings.TrimSuffix(v1alpha1.IcarusStorageLocation(storageLocation), "/"), clusterName, dcName, podName)
+}
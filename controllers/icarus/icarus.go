@@ -3,6 +3,12 @@ package icarus
 import (
 	"context"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Retry struct {
@@ -12,11 +18,40 @@ type Retry struct {
 	Enabled     bool   `json:"enabled"`
 }
 
+// Encryption is the client-side encryption of the backup data. The key is read by Icarus from the secret.
+type Encryption struct {
+	K8sSecretName string `json:"k8sSecretName"`
+	K8sSecretKey  string `json:"k8sSecretKey"`
+	KeyType       string `json:"keyType"`
+}
+
+// NewEncryption returns the encryption of an Icarus request or nil if the backup data is not encrypted
+func NewEncryption(encryption *v1alpha1.BackupEncryption) *Encryption {
+	if encryption == nil {
+		return nil
+	}
+
+	return &Encryption{
+		K8sSecretName: encryption.SecretName,
+		K8sSecretKey:  encryption.EncryptionSecretKey(),
+		KeyType:       string(encryption.EncryptionKeyType()),
+	}
+}
+
 type Icarus interface {
 	Backup(ctx context.Context, req BackupRequest) (Backup, error)
 	Backups(ctx context.Context) ([]Backup, error)
 	Restore(ctx context.Context, req RestoreRequest) error
 	Restores(ctx context.Context) ([]Restore, error)
+	RemoveBackup(ctx context.Context, req RemoveBackupRequest) (RemoveBackup, error)
+	RemoveBackups(ctx context.Context) ([]RemoveBackup, error)
+	List(ctx context.Context, req ListRequest) (List, error)
+	Lists(ctx context.Context) ([]List, error)
+	BackupCommitLogs(ctx context.Context, req CommitLogBackupRequest) error
+	CommitLogBackups(ctx context.Context) ([]CommitLogBackup, error)
+	RestoreCommitLogs(ctx context.Context, req CommitLogRestoreRequest) error
+	CommitLogRestores(ctx context.Context) ([]CommitLogRestore, error)
+	Cancel(ctx context.Context, operationID string) error
 }
 
 type client struct {
@@ -30,3 +65,41 @@ func New(addr string) Icarus {
 		httpClient: &http.Client{},
 	}
 }
+
+// ParseTime parses a time reported by Icarus. Returns nil if the time is not set or can't be parsed.
+func ParseTime(t string) *metav1.Time {
+	parsedTime, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return nil
+	}
+
+	// the API server stores times with a precision of seconds
+	return &metav1.Time{Time: parsedTime.Truncate(time.Second)}
+}
+
+// SetConditions sets the Progressing, Complete and Failed conditions of a backup or restore based on the Icarus state
+func SetConditions(conditions *[]metav1.Condition, operation string, state string, generation int64) {
+	reason := operation + "Unknown"
+	if len(state) != 0 {
+		reason = operation + strings.ToUpper(state[:1]) + strings.ToLower(state[1:])
+	}
+
+	conditionStatus := func(conditionStates ...string) metav1.ConditionStatus {
+		for _, conditionState := range conditionStates {
+			if state == conditionState {
+				return metav1.ConditionTrue
+			}
+		}
+		return metav1.ConditionFalse
+	}
+
+	for _, condition := range []metav1.Condition{
+		{Type: v1alpha1.ConditionTypeProgressing, Status: conditionStatus(StatePending, StateRunning)},
+		{Type: v1alpha1.ConditionTypeComplete, Status: conditionStatus(StateCompleted)},
+		{Type: v1alpha1.ConditionTypeFailed, Status: conditionStatus(StateFailed)},
+	} {
+		condition.ObservedGeneration = generation
+		condition.Reason = reason
+		meta.SetStatusCondition(conditions, condition)
+	}
+}
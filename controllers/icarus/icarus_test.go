@@ -0,0 +1,83 @@
+package icarus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(ParseTime("")).To(BeNil())
+	g.Expect(ParseTime("not a time")).To(BeNil())
+	g.Expect(ParseTime("2022-05-01T12:00:00.123Z").Time).To(Equal(time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestSetConditions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	var conditions []metav1.Condition
+
+	SetConditions(&conditions, "Backup", StateRunning, 1)
+	g.Expect(conditions).To(HaveLen(3))
+	g.Expect(meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionTypeProgressing)).To(BeTrue())
+	g.Expect(meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeComplete)).To(BeTrue())
+	g.Expect(meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeFailed)).To(BeTrue())
+	g.Expect(meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeProgressing).Reason).To(Equal("BackupRunning"))
+
+	SetConditions(&conditions, "Backup", StateFailed, 2)
+	g.Expect(conditions).To(HaveLen(3))
+	g.Expect(meta.IsStatusConditionFalse(conditions, v1alpha1.ConditionTypeProgressing)).To(BeTrue())
+	g.Expect(meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionTypeFailed)).To(BeTrue())
+	g.Expect(meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeFailed).Reason).To(Equal("BackupFailed"))
+	g.Expect(meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeFailed).ObservedGeneration).To(Equal(int64(2)))
+
+	conditions = nil
+	SetConditions(&conditions, "Restore", "", 1)
+	g.Expect(meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeComplete).Reason).To(Equal("RestoreUnknown"))
+}
+
+func TestCommitLogStorageLocation(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(CommitLogStorageLocation("s3://bucket", "test", "dc1", "test-cassandra-dc1-0")).To(Equal("s3://bucket/test/dc1/test-cassandra-dc1-0"))
+	g.Expect(CommitLogStorageLocation("s3://bucket/", "test", "dc1", "test-cassandra-dc1-0")).To(Equal("s3://bucket/test/dc1/test-cassandra-dc1-0"))
+	g.Expect(CommitLogStorageLocation("file://commitlogs", "test", "dc1", "test-cassandra-dc1-0")).To(Equal("file:///var/lib/cassandra-backups/commitlogs/test/dc1/test-cassandra-dc1-0"))
+}
+
+func TestManifestSnapshotTag(t *testing.T) {
+	g := NewGomegaWithT(t)
+	manifest := ManifestReport{Name: "backup-1-0f3e1c2a-9c5e-3f2b-8a61-2d0d6c9e4f10-1666000000000", SchemaVersion: "0f3e1c2a-9c5e-3f2b-8a61-2d0d6c9e4f10"}
+	g.Expect(manifest.SnapshotTag()).To(Equal("backup-1"))
+
+	manifest.SchemaVersion = ""
+	g.Expect(manifest.SnapshotTag()).To(Equal(manifest.Name))
+	g.Expect(NodeStorageLocation("s3://bucket/", "test", "dc1", "test-cassandra-dc1-0")).To(Equal("s3://bucket/test/dc1/test-cassandra-dc1-0"))
+}
+
+func TestCancel(t *testing.T) {
+	g := NewGomegaWithT(t)
+	var method, path string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	ic := New(server.URL)
+	g.Expect(ic.Cancel(context.Background(), "operation-id")).To(Succeed())
+	g.Expect(method).To(Equal(http.MethodDelete))
+	g.Expect(path).To(Equal("/operations/operation-id"))
+
+	status = http.StatusNotFound
+	g.Expect(ic.Cancel(context.Background(), "operation-id")).To(Succeed())
+
+	status = http.StatusInternalServerError
+	g.Expect(ic.Cancel(context.Background(), "operation-id")).ToNot(Succeed())
+}
@@ -0,0 +1,137 @@
+package icarus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+// ListRequest lists the backups of a node found in the storage
+type ListRequest struct {
+	Type                   string `json:"type"`
+	StorageLocation        string `json:"storageLocation"`
+	K8sNamespace           string `json:"k8sNamespace,omitempty"`
+	K8sSecretName          string `json:"k8sSecretName,omitempty"`
+	Insecure               bool   `json:"insecure"`
+	SkipBucketVerification bool   `json:"skipBucketVerification"`
+	// Makes Icarus return the report in the response of the operation instead of writing it to a file
+	ToRequest bool `json:"toRequest"`
+	JSON      bool `json:"json"`
+}
+
+type List struct {
+	ID                     string     `json:"id"`
+	CreationTime           string     `json:"creationTime"`
+	State                  string     `json:"state"`
+	Errors                 []Error    `json:"errors"`
+	Progress               float64    `json:"progress"`
+	StartTime              string     `json:"startTime"`
+	Type                   string     `json:"type"`
+	StorageLocation        string     `json:"storageLocation"`
+	K8sNamespace           string     `json:"k8sNamespace"`
+	K8sSecretName          string     `json:"k8sSecretName"`
+	Insecure               bool       `json:"insecure"`
+	SkipBucketVerification bool       `json:"skipBucketVerification"`
+	Response               ListReport `json:"response"`
+}
+
+// ListReport holds the manifests of the backups of a node
+type ListReport struct {
+	TotalSize int64            `json:"totalSize"`
+	Reports   []ManifestReport `json:"reports"`
+}
+
+// ManifestReport describes the backup of a node. The name of the manifest is the snapshot tag of the backup
+// followed by the schema version and the time of the backup in milliseconds.
+type ManifestReport struct {
+	Name          string `json:"name"`
+	Size          int64  `json:"size"`
+	SchemaVersion string `json:"schemaVersion"`
+	UnixTimestamp int64  `json:"unixtimestamp"`
+	Encrypted     bool   `json:"encrypted"`
+}
+
+// SnapshotTag returns the snapshot tag the backup was created with
+func (m ManifestReport) SnapshotTag() string {
+	if len(m.SchemaVersion) != 0 {
+		if index := strings.LastIndex(m.Name, "-"+m.SchemaVersion+"-"); index > 0 {
+			return m.Name[:index]
+		}
+	}
+
+	return m.Name
+}
+
+func (c *client) List(ctx context.Context, listReq ListRequest) (List, error) {
+	listReq.Type = "list"
+	listReq.ToRequest = true
+	listReq.JSON = true
+	body, err := json.Marshal(listReq)
+	if err != nil {
+		return List{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.addr+"/operations", bytes.NewReader(body))
+	if err != nil {
+		return List{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return List{}, err
+	}
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return List{}, fmt.Errorf("list request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	list := List{}
+	err = json.Unmarshal(b, &list)
+	if err != nil {
+		return List{}, err
+	}
+
+	return list, nil
+}
+
+func (c *client) Lists(ctx context.Context) ([]List, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.addr+"/operations?type=list", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []List
+	err = json.Unmarshal(b, &lists)
+	if err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+// NodeStorageLocation returns the location of the backups of a Cassandra node as used by Icarus
+func NodeStorageLocation(storageLocation, clusterName, dcName, nodeName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", strings.TrimSuffix(v1alpha1.IcarusStorageLocation(storageLocation), "/"), clusterName, dcName, nodeName)
+}
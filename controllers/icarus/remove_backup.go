@@ -0,0 +1,103 @@
+package icarus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type RemoveBackupRequest struct {
+	Type                   string `json:"type"`
+	StorageLocation        string `json:"storageLocation"`
+	BackupName             string `json:"backupName"`
+	GlobalRequest          bool   `json:"globalRequest"`
+	ResolveNodes           bool   `json:"resolveNodes"`
+	K8sNamespace           string `json:"k8sNamespace,omitempty"`
+	K8sSecretName          string `json:"k8sSecretName,omitempty"`
+	Insecure               bool   `json:"insecure"`
+	SkipBucketVerification bool   `json:"skipBucketVerification"`
+	Dry                    bool   `json:"dry"`
+}
+
+type RemoveBackup struct {
+	ID                     string  `json:"id"`
+	CreationTime           string  `json:"creationTime"`
+	State                  string  `json:"state"`
+	Errors                 []Error `json:"errors"`
+	Progress               float64 `json:"progress"`
+	StartTime              string  `json:"startTime"`
+	Type                   string  `json:"type"`
+	StorageLocation        string  `json:"storageLocation"`
+	BackupName             string  `json:"backupName"`
+	GlobalRequest          bool    `json:"globalRequest"`
+	ResolveNodes           bool    `json:"resolveNodes"`
+	K8sNamespace           string  `json:"k8sNamespace"`
+	K8sSecretName          string  `json:"k8sSecretName"`
+	Insecure               bool    `json:"insecure"`
+	SkipBucketVerification bool    `json:"skipBucketVerification"`
+	Dry                    bool    `json:"dry"`
+}
+
+func (c *client) RemoveBackup(ctx context.Context, removeReq RemoveBackupRequest) (RemoveBackup, error) {
+	removeReq.Type = "remove-backup"
+	body, err := json.Marshal(removeReq)
+	if err != nil {
+		return RemoveBackup{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.addr+"/operations", bytes.NewReader(body))
+	if err != nil {
+		return RemoveBackup{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return RemoveBackup{}, err
+	}
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return RemoveBackup{}, fmt.Errorf("remove backup request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	removal := RemoveBackup{}
+	err = json.Unmarshal(b, &removal)
+	if err != nil {
+		return RemoveBackup{}, err
+	}
+
+	return removal, nil
+}
+
+func (c *client) RemoveBackups(ctx context.Context) ([]RemoveBackup, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.addr+"/operations?type=remove-backup", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("remove backup request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var removals []RemoveBackup
+	err = json.Unmarshal(b, &removals)
+	if err != nil {
+		return nil, err
+	}
+
+	return removals, nil
+}
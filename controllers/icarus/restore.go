@@ -35,6 +35,7 @@ type RestoreRequest struct {
 	DC                        string            `json:"dc,omitempty"`
 	SchemaVersion             string            `json:"schemaVersion,omitempty"`
 	ExactSchemaVersion        bool              `json:"exactSchemaVersion,omitempty"`
+	Encryption                *Encryption       `json:"encryption,omitempty"`
 }
 
 type Restore struct {
@@ -44,6 +45,7 @@ type Restore struct {
 	Errors                    []Error           `json:"errors"`
 	Progress                  float64           `json:"progress"`
 	StartTime                 string            `json:"startTime"`
+	CompletionTime            string            `json:"completionTime"`
 	Type                      string            `json:"type"`
 	StorageLocation           string            `json:"storageLocation"`
 	ConcurrentConnections     int64             `json:"concurrentConnections"`
@@ -70,6 +72,10 @@ type Restore struct {
 	K8sNamespace              string            `json:"k8sNamespace"`
 	K8sSecretName             string            `json:"k8sSecretName"`
 	Rename                    map[string]string `json:"rename"`
+	Encryption                *Encryption       `json:"encryption"`
+	// Bytes downloaded so far and the total bytes to download
+	TransferredBytes int64 `json:"transferredBytes"`
+	TotalBytes       int64 `json:"totalBytes"`
 }
 
 type RestoreImport struct {
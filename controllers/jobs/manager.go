@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,11 +35,13 @@ type job struct {
 
 func (j *JobManager) Run(name string, notifyObj client.Object, f func() error) error {
 	j.Lock()
-	_, exists := j.jobsList[name]
-	j.Unlock()
-	if exists {
+	existingJob, exists := j.jobsList[name]
+	if exists && existingJob.finished.IsZero() {
+		j.Unlock()
 		return errors.Errorf("job %s already exists", name)
 	}
+	j.jobsList[name] = job{} // a finished job is replaced
+	j.Unlock()
 
 	j.log.Infof("starting job %s", name)
 	go func() {
@@ -73,11 +77,15 @@ func (j *JobManager) Run(name string, notifyObj client.Object, f func() error) e
 }
 
 func (j *JobManager) Exists(name string) bool {
+	j.Lock()
+	defer j.Unlock()
 	_, exists := j.jobsList[name]
 	return exists
 }
 
 func (j *JobManager) IsRunning(name string) bool {
+	j.Lock()
+	defer j.Unlock()
 	existingJob, exists := j.jobsList[name]
 	if !exists {
 		return false
@@ -86,6 +94,21 @@ func (j *JobManager) IsRunning(name string) bool {
 	return existingJob.finished.IsZero()
 }
 
+// RunningJobs returns the names of the running jobs which names start with the prefix
+func (j *JobManager) RunningJobs(prefix string) []string {
+	j.Lock()
+	defer j.Unlock()
+	var running []string
+	for name, existingJob := range j.jobsList {
+		if strings.HasPrefix(name, prefix) && existingJob.finished.IsZero() {
+			running = append(running, name)
+		}
+	}
+
+	sort.Strings(running)
+	return running
+}
+
 func (j *JobManager) RemoveJob(name string) error {
 	j.Lock()
 	defer j.Unlock()
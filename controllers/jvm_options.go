@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cassandraconfig"
+)
+
+const (
+	mebibyte = int64(1) << 20
+	gibibyte = int64(1) << 30
+
+	// CMS performs worse than G1 with larger heaps
+	maxCMSHeapSize = 8 * gibibyte
+	// cassandra-env.sh sizes the young generation with 100MB per core
+	newSizePerCore = 100 * mebibyte
+)
+
+var cmsOptions = []string{
+	"-XX:+UseParNewGC",
+	"-XX:+UseConcMarkSweepGC",
+	"-XX:+CMSParallelRemarkEnabled",
+	"-XX:SurvivorRatio=8",
+	"-XX:MaxTenuringThreshold=1",
+	"-XX:CMSInitiatingOccupancyFraction=75",
+	"-XX:+UseCMSInitiatingOccupancyOnly",
+	"-XX:CMSWaitDuration=10000",
+	"-XX:+CMSParallelInitialMarkEnabled",
+	"-XX:+CMSEdenChunksRecordAlways",
+	"-XX:+CMSClassUnloadingEnabled",
+}
+
+// derivedJVMOptions returns the heap and GC options derived from the resources of the cassandra container
+// that are not set in `.spec.cassandra.jvmOptions`. useCMS is true if the G1 options of the default jvm.options have to be replaced with CMS.
+//
+// The heap sizes are calculated the same way cassandra-env.sh does it from the memory of the host:
+// max(min(1/2 memory, 1GB), min(1/4 memory, 8GB)). The maximum heap size is based on the memory limit,
+// the initial heap size on the memory request. The maximum heap size is raised to the initial heap size set by the user.
+func derivedJVMOptions(cc *v1alpha1.CassandraCluster) (options []string, useCMS bool) {
+	userOptions := cc.Spec.Cassandra.JVMOptions
+	resources := cc.Spec.Cassandra.Resources
+
+	maxHeapSize := int64(0)
+	if value, found := v1alpha1.JVMOption(userOptions, v1alpha1.JVMMaxHeapSizeOptions); found {
+		maxHeapSize, _ = v1alpha1.ParseJVMSize(value) // validated by the webhook
+	} else if memory, found := containerMemory(resources.Limits, resources.Requests); found {
+		maxHeapSize = heapSize(memory)
+		// the JVM doesn't start if the initial heap size is larger than the maximum heap size
+		if value, found := v1alpha1.JVMOption(userOptions, v1alpha1.JVMInitialHeapSizeOptions); found {
+			if initialHeapSize, _ := v1alpha1.ParseJVMSize(value); initialHeapSize > maxHeapSize {
+				maxHeapSize = initialHeapSize
+			}
+		}
+		options = append(options, "-Xmx"+formatJVMSize(maxHeapSize))
+	}
+
+	if _, found := v1alpha1.JVMOption(userOptions, v1alpha1.JVMInitialHeapSizeOptions); !found {
+		if memory, found := containerMemory(resources.Requests, resources.Limits); found {
+			initialHeapSize := heapSize(memory)
+			if maxHeapSize > 0 && initialHeapSize > maxHeapSize {
+				initialHeapSize = maxHeapSize
+			}
+			options = append(options, "-Xms"+formatJVMSize(initialHeapSize))
+		}
+	}
+
+	if maxHeapSize == 0 || userSetGC(userOptions) {
+		return options, false
+	}
+
+	version, versionKnown := cassandraconfig.ParseImageVersion(cc.Spec.Cassandra.Image)
+	// Cassandra 4.0 and later run with Java 11 which deprecates CMS, G1 is already set by the default jvm.options
+	if !versionKnown || version.AtLeast(cassandraconfig.V4_0) || maxHeapSize >= maxCMSHeapSize {
+		return options, false
+	}
+
+	if _, found := v1alpha1.JVMOption(userOptions, v1alpha1.JVMNewSizeOptions); !found {
+		newSize := maxHeapSize / 4
+		if cpu, found := containerCPU(resources.Limits, resources.Requests); found {
+			cores := cpu.MilliValue() / 1000
+			if cores < 1 {
+				cores = 1
+			}
+			if cores*newSizePerCore < newSize {
+				newSize = cores * newSizePerCore
+			}
+		}
+		options = append(options, "-Xmn"+formatJVMSize(newSize))
+	}
+
+	return append(options, cmsOptions...), true
+}
+
+// heapSize returns the heap size for the given memory, rounded down to MB
+func heapSize(memory int64) int64 {
+	half := memory / 2
+	if half > gibibyte {
+		half = gibibyte
+	}
+
+	quarter := memory / 4
+	if quarter > 8*gibibyte {
+		quarter = 8 * gibibyte
+	}
+
+	size := half
+	if quarter > size {
+		size = quarter
+	}
+
+	return size / mebibyte * mebibyte
+}
+
+func formatJVMSize(size int64) string {
+	if size%gibibyte == 0 {
+		return fmt.Sprintf("%dG", size/gibibyte)
+	}
+
+	return fmt.Sprintf("%dM", size/mebibyte)
+}
+
+// containerMemory returns the memory from the first resource list it's set in
+func containerMemory(resourceLists ...v1.ResourceList) (int64, bool) {
+	for _, resourceList := range resourceLists {
+		if memory, found := resourceList[v1.ResourceMemory]; found && !memory.IsZero() {
+			return memory.Value(), true
+		}
+	}
+
+	return 0, false
+}
+
+// containerCPU returns the CPU from the first resource list it's set in
+func containerCPU(resourceLists ...v1.ResourceList) (resource.Quantity, bool) {
+	for _, resourceList := range resourceLists {
+		if cpu, found := resourceList[v1.ResourceCPU]; found && !cpu.IsZero() {
+			return cpu, true
+		}
+	}
+
+	return resource.Quantity{}, false
+}
+
+func userSetGC(options []string) bool {
+	for _, option := range options {
+		if strings.HasPrefix(option, "-XX:+Use") && strings.HasSuffix(option, "GC") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutG1Options comments out the G1 options of the jvm.options file
+func withoutG1Options(jvmOptions string) string {
+	lines := strings.Split(jvmOptions, "\n")
+	for i, line := range lines {
+		if line == "-XX:+UseG1GC" || strings.HasPrefix(line, "-XX:G1") {
+			lines[i] = "#" + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+func TestDerivedJVMOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	testCases := []struct {
+		name            string
+		image           string
+		resources       v1.ResourceRequirements
+		jvmOptions      []string
+		expectedOptions []string
+		expectedCMS     bool
+	}{
+		{
+			name:  "no resources",
+			image: "cassandra:4.1.3",
+		},
+		{
+			name:  "Java 11 uses G1",
+			image: "cassandra:4.1.3",
+			resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("16Gi")},
+				Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Gi")},
+			},
+			expectedOptions: []string{"-Xmx8G", "-Xms4G"},
+		},
+		{
+			name:  "small memory",
+			image: "cassandra:4.0.7",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1500Mi")},
+			},
+			expectedOptions: []string{"-Xmx750M", "-Xms750M"},
+		},
+		{
+			name:  "Java 8 uses CMS for small heaps",
+			image: "cassandra:3.11.13",
+			resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("8Gi"), v1.ResourceCPU: resource.MustParse("2")},
+			},
+			expectedOptions: append([]string{"-Xmx2G", "-Xms2G", "-Xmn200M"}, cmsOptions...),
+			expectedCMS:     true,
+		},
+		{
+			name:  "Java 8 uses G1 for large heaps",
+			image: "cassandra:3.11.13",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("32Gi")},
+			},
+			expectedOptions: []string{"-Xmx8G", "-Xms8G"},
+		},
+		{
+			name:  "initial heap size capped at the max heap size set by the user",
+			image: "cassandra:3.11.13",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+			jvmOptions:      []string{"-Xmx1G", "-Xmn256M"},
+			expectedOptions: append([]string{"-Xms1G"}, cmsOptions...),
+			expectedCMS:     true,
+		},
+		{
+			name:  "max heap size raised to the initial heap size set by the user",
+			image: "cassandra:4.1.3",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			jvmOptions:      []string{"-Xms2G"},
+			expectedOptions: []string{"-Xmx2G"},
+		},
+		{
+			name:  "GC set by the user",
+			image: "cassandra:3.11.13",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			jvmOptions:      []string{"-XX:+UseG1GC"},
+			expectedOptions: []string{"-Xmx1G", "-Xms1G"},
+		},
+		{
+			name:  "unknown version",
+			image: "cassandra/image",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			jvmOptions:      []string{"-Xms512M", "-XX:MaxHeapSize=2G"},
+			expectedOptions: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		cc := &v1alpha1.CassandraCluster{
+			Spec: v1alpha1.CassandraClusterSpec{
+				Cassandra: &v1alpha1.Cassandra{
+					Image:      tc.image,
+					Resources:  tc.resources,
+					JVMOptions: tc.jvmOptions,
+				},
+			},
+		}
+
+		options, useCMS := derivedJVMOptions(cc)
+		g.Expect(options).To(Equal(tc.expectedOptions), tc.name)
+		g.Expect(useCMS).To(Equal(tc.expectedCMS), tc.name)
+	}
+}
+
+func TestWithoutG1Options(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	jvmOptions := "-Xss256k\n#-XX:+UseConcMarkSweepGC\n-XX:+UseG1GC\n-XX:G1RSetUpdatingPauseTimePercent=5\n"
+	g.Expect(withoutG1Options(jvmOptions)).To(Equal("-Xss256k\n#-XX:+UseConcMarkSweepGC\n#-XX:+UseG1GC\n#-XX:G1RSetUpdatingPauseTimePercent=5\n"))
+}
@@ -39,6 +39,17 @@ func (r *CassandraClusterReconciler) reconcileKeyspaces(ctx context.Context, cc
 
 		desiredOptions := desiredReplicationOptions(cc, string(systemKeyspace), allDCs)
 		if !cmp.Equal(keyspaceInfo.Replication, desiredOptions) {
+			// the initial switch to NetworkTopologyStrategy is part of the cluster setup and not held back
+			if keyspaceInfo.Replication["class"] == cql.ReplicationClassNetworkTopologyStrategy {
+				allowed, err := r.maintenanceAllowed(ctx, cc, dbv1alpha1.QueuedOperationKeyspaceReplication, string(systemKeyspace))
+				if err != nil {
+					return err
+				}
+				if !allowed {
+					continue
+				}
+			}
+
 			r.Log.Infof("Updating keyspace %q with replication options %v", systemKeyspace, desiredOptions)
 			err = cqlClient.UpdateRF(string(systemKeyspace), desiredOptions)
 			if err != nil {
@@ -164,7 +175,7 @@ func (r *CassandraClusterReconciler) reconcileSystemAuthIfReady(ctx context.Cont
 	}
 	cassandraOperatorAdminRole := string(adminRoleSecret.Data[dbv1alpha1.CassandraOperatorAdminRole])
 	cassandraOperatorAdminPassword := string(adminRoleSecret.Data[dbv1alpha1.CassandraOperatorAdminPassword])
-	cqlClient, err := r.CqlClient(newCassandraConfig(cc, cassandraOperatorAdminRole, cassandraOperatorAdminPassword, r.Log))
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, cassandraOperatorAdminRole, cassandraOperatorAdminPassword, r.Log))
 	if err == nil { // if the current region is the ready one it will succeed
 		defer cqlClient.CloseSession()
 		err = r.reconcileSystemAuthKeyspace(ctx, cc, cqlClient, allDCs)
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cassandraconfig"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+const (
+	plainLogPattern = `%-5level [%thread] %date{ISO8601} - %msg%n`
+
+	auditLogger     = "org.apache.cassandra.audit"
+	slowQueryLogger = "org.apache.cassandra.db.monitoring.MonitoringTask"
+)
+
+// jsonLogPattern writes one JSON object per line, the message and the stack trace are escaped as JSON strings
+var jsonLogPattern = `{"timestamp":"%date{"yyyy-MM-dd'T'HH:mm:ss.SSSXXX"}","level":"%level","thread":"%thread","logger":"%logger",` +
+	`"message":"` + jsonEscaped("%msg") + `","exception":"` + jsonEscaped("%ex") + `"}%nopex%n`
+
+var stdoutLogPatternRegexp = regexp.MustCompile(`(?s)(<appender name="STDOUT"[^>]*>.*?<pattern>)(.*?)(</pattern>)`)
+
+// java8GCLogOptions are the GC logging options of the default jvm.options of Cassandra 3.11.
+// cassandra-env.sh sets the location of the log with -Xloggc.
+var java8GCLogOptions = []string{
+	"-XX:+PrintGCDetails",
+	"-XX:+PrintGCDateStamps",
+	"-XX:+PrintHeapAtGC",
+	"-XX:+PrintTenuringDistribution",
+	"-XX:+PrintGCApplicationStoppedTime",
+	"-XX:+PrintPromotionFailure",
+	"-XX:+UseGCLogFileRotation",
+	"-XX:NumberOfGCLogFiles=10",
+	"-XX:GCLogFileSize=10M",
+}
+
+// java11GCLogOptions are the GC logging options cassandra-env.sh of Cassandra 4.0 uses with Java 11
+var java11GCLogOptions = []string{
+	"-Xlog:gc=info,heap*=trace,age*=debug,safepoint=info,promotion*=trace:file=/var/log/cassandra/gc.log:time,uptime,pid,tid,level:filecount=10,filesize=10485760",
+}
+
+// logbackConfig adds the appenders and loggers of `.spec.cassandra.logging` to the default logback.xml.
+// The levels of the loggers are left out if withLoggers is false, as they are applied without restarting the nodes.
+func logbackConfig(defaultConfig string, logging *v1alpha1.CassandraLogging, withLoggers bool) string {
+	pattern := plainLogPattern
+	if logging.Encoder == v1alpha1.LogEncoderJSON {
+		pattern = jsonLogPattern
+		defaultConfig = stdoutLogPatternRegexp.ReplaceAllString(defaultConfig, "${1}"+strings.ReplaceAll(pattern, "$", "$$")+"${3}")
+	}
+
+	var config strings.Builder
+	if logging.AuditLog {
+		config.WriteString(fileAppender("AUDIT", "audit", pattern))
+		config.WriteString(fmt.Sprintf("  <logger name=\"%s\" level=\"INFO\" additivity=\"false\">\n    <appender-ref ref=\"AUDIT\" />\n  </logger>\n", auditLogger))
+	}
+
+	if logging.SlowQueryLog {
+		config.WriteString(fileAppender("SLOWQUERIES", "slow_queries", pattern))
+		config.WriteString(fmt.Sprintf("  <logger name=\"%s\" level=\"DEBUG\" additivity=\"false\">\n    <appender-ref ref=\"SLOWQUERIES\" />\n  </logger>\n", slowQueryLogger))
+	}
+
+	if withLoggers {
+		loggers := make([]string, 0, len(logging.Loggers))
+		for logger := range logging.Loggers {
+			loggers = append(loggers, logger)
+		}
+		sort.Strings(loggers)
+		for _, logger := range loggers {
+			config.WriteString(fmt.Sprintf("  <logger name=\"%s\" level=\"%s\"/>\n", logger, logging.Loggers[logger]))
+		}
+	}
+
+	return strings.Replace(defaultConfig, "</configuration>", config.String()+"</configuration>", 1)
+}
+
+// jsonEscaped escapes the output of a conversion word as a JSON string
+func jsonEscaped(conversion string) string {
+	return `%replace(%replace(%replace(%replace(` + conversion + `){'\\','\\\\'}){'"','\\"'}){'\r?\n','\\n'}){'\t','\\t'}`
+}
+
+func fileAppender(name, file, pattern string) string {
+	return fmt.Sprintf(`  <appender name="%[1]s" class="ch.qos.logback.core.rolling.RollingFileAppender">
+    <file>${cassandra.logdir}/%[2]s.log</file>
+    <rollingPolicy class="ch.qos.logback.core.rolling.FixedWindowRollingPolicy">
+      <fileNamePattern>${cassandra.logdir}/%[2]s.log.%%i.zip</fileNamePattern>
+      <minIndex>1</minIndex>
+      <maxIndex>10</maxIndex>
+    </rollingPolicy>
+    <triggeringPolicy class="ch.qos.logback.core.rolling.SizeBasedTriggeringPolicy">
+      <maxFileSize>20MB</maxFileSize>
+    </triggeringPolicy>
+    <encoder>
+      <pattern>%[3]s</pattern>
+    </encoder>
+  </appender>
+`, name, file, pattern)
+}
+
+// gcLogOptions returns the GC logging options for the Java version of the image.
+// Images with an unknown version are expected to run with Java 8 as the default jvm.options.
+func gcLogOptions(cc *v1alpha1.CassandraCluster) []string {
+	version, versionKnown := cassandraconfig.ParseImageVersion(cc.Spec.Cassandra.Image)
+	if versionKnown && version.AtLeast(cassandraconfig.V4_0) {
+		return java11GCLogOptions
+	}
+
+	return java8GCLogOptions
+}
+
+// reconcileLoggingLevels sets the levels of the loggers on the ready nodes that run with different levels.
+// Nodes the levels were set on since they started are skipped until the levels change.
+// Restarted nodes read the levels from logback.xml. Loggers removed from the spec keep their level until the node restarts.
+func (r *CassandraClusterReconciler) reconcileLoggingLevels(ctx context.Context, cc *v1alpha1.CassandraCluster, podList *v1.PodList, auth credentials) error {
+	if cc.Spec.Cassandra.Logging == nil || len(cc.Spec.Cassandra.Logging.Loggers) == 0 {
+		return r.updateLoggingLevelsStatus(ctx, cc, nil)
+	}
+
+	pods := make([]v1.Pod, len(podList.Items))
+	copy(pods, podList.Items)
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	nctl := r.NodectlClient(nodectl.JolokiaURL(cc), auth.activeRole, auth.activePassword, r.Log)
+	var nodes []v1alpha1.NodeLoggingLevels
+	for _, pod := range pods {
+		previous := nodeLoggingLevelsStatus(cc, pod.Name)
+		if !coordinator.Ready(pod) || loggingLevelsInSync(cc, pod, previous) {
+			if previous != nil {
+				nodes = append(nodes, *previous)
+			}
+			continue
+		}
+
+		if r.applyLoggingLevels(ctx, cc, nctl, pod) {
+			nodes = append(nodes, v1alpha1.NodeLoggingLevels{
+				Pod:           v1alpha1.PodName(pod.Name),
+				Loggers:       cc.Spec.Cassandra.Logging.Loggers,
+				NodeStartedAt: cassandraStartedAt(pod),
+			})
+		} else if previous != nil {
+			nodes = append(nodes, *previous)
+		}
+	}
+
+	return r.updateLoggingLevelsStatus(ctx, cc, nodes)
+}
+
+// applyLoggingLevels sets the levels the node doesn't run with. Returns true if all levels are set.
+func (r *CassandraClusterReconciler) applyLoggingLevels(ctx context.Context, cc *v1alpha1.CassandraCluster, nctl nodectl.Nodectl, pod v1.Pod) bool {
+	loggers := make([]string, 0, len(cc.Spec.Cassandra.Logging.Loggers))
+	for logger := range cc.Spec.Cassandra.Logging.Loggers {
+		loggers = append(loggers, logger)
+	}
+	sort.Strings(loggers)
+
+	reqCtx, cancel := context.WithTimeout(ctx, jmxRequestTimeout)
+	levels, err := nctl.LoggingLevels(reqCtx, pod.Status.PodIP)
+	cancel()
+	if err != nil {
+		r.Log.Warnf("Failed to read logging levels of node %s: %s", pod.Name, err.Error())
+		return false
+	}
+
+	applied := true
+	for _, logger := range loggers {
+		level := string(cc.Spec.Cassandra.Logging.Loggers[logger])
+		if levels[logger] == level {
+			continue
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, jmxRequestTimeout)
+		err := nctl.SetLoggingLevel(reqCtx, pod.Status.PodIP, logger, level)
+		cancel()
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed to set level of logger %s of node %s to %s: %s", logger, pod.Name, level, err.Error())
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cc, events.EventLoggingLevelNotApplied, errMsg)
+			applied = false
+		}
+	}
+
+	return applied
+}
+
+// loggingLevelsInSync returns true if the current levels were set on the node since it started
+func loggingLevelsInSync(cc *v1alpha1.CassandraCluster, pod v1.Pod, previous *v1alpha1.NodeLoggingLevels) bool {
+	if previous == nil {
+		return false
+	}
+
+	startedAt := cassandraStartedAt(pod)
+	if startedAt == nil || !startedAt.Equal(previous.NodeStartedAt) {
+		return false
+	}
+
+	return cmp.Equal(previous.Loggers, cc.Spec.Cassandra.Logging.Loggers)
+}
+
+func nodeLoggingLevelsStatus(cc *v1alpha1.CassandraCluster, podName string) *v1alpha1.NodeLoggingLevels {
+	for i, node := range cc.Status.LoggingLevels {
+		if string(node.Pod) == podName {
+			return &cc.Status.LoggingLevels[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *CassandraClusterReconciler) updateLoggingLevelsStatus(ctx context.Context, cc *v1alpha1.CassandraCluster, nodes []v1alpha1.NodeLoggingLevels) error {
+	if cmp.Equal(cc.Status.LoggingLevels, nodes) {
+		return nil
+	}
+
+	ccStatus := cc.DeepCopy()
+	ccStatus.Status.LoggingLevels = nodes
+	if err := r.Status().Update(ctx, ccStatus); err != nil {
+		return errors.Wrap(err, "failed to update logging levels status")
+	}
+
+	cc.Status.LoggingLevels = nodes
+	cc.ResourceVersion = ccStatus.ResourceVersion
+	return nil
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+const testLogbackConfig = `<configuration scan="true">
+  <appender name="STDOUT" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder>
+      <pattern>%-5level [%thread] %date{ISO8601} - %msg%n</pattern>
+    </encoder>
+  </appender>
+  <appender name="DEBUGLOG" class="ch.qos.logback.core.rolling.RollingFileAppender">
+    <encoder>
+      <pattern>%-5level [%thread] %date{ISO8601} %F:%L - %msg%n</pattern>
+    </encoder>
+  </appender>
+  <root level="${LOG_LEVEL}">
+    <appender-ref ref="STDOUT" />
+  </root>
+</configuration>
+`
+
+func TestLogbackConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	logging := &v1alpha1.CassandraLogging{
+		Loggers: map[string]v1alpha1.LoggerLevel{
+			"org.apache.cassandra.gms":       "WARN",
+			"org.apache.cassandra.db.commit": "DEBUG",
+		},
+	}
+	g.Expect(logbackConfig(testLogbackConfig, logging, false)).To(Equal(testLogbackConfig))
+
+	config := logbackConfig(testLogbackConfig, logging, true)
+	g.Expect(config).To(HaveSuffix(`  <logger name="org.apache.cassandra.db.commit" level="DEBUG"/>
+  <logger name="org.apache.cassandra.gms" level="WARN"/>
+</configuration>
+`))
+
+	logging.Encoder = v1alpha1.LogEncoderJSON
+	logging.SlowQueryLog = true
+	config = logbackConfig(testLogbackConfig, logging, false)
+	g.Expect(config).To(ContainSubstring("<pattern>" + jsonLogPattern + "</pattern>\n    </encoder>\n  </appender>\n  <appender name=\"DEBUGLOG\""))
+	g.Expect(config).To(ContainSubstring("<pattern>%-5level [%thread] %date{ISO8601} %F:%L - %msg%n</pattern>"))
+	g.Expect(config).To(ContainSubstring(`<appender name="SLOWQUERIES" class="ch.qos.logback.core.rolling.RollingFileAppender">`))
+	g.Expect(config).To(ContainSubstring("<file>${cassandra.logdir}/slow_queries.log</file>"))
+	g.Expect(config).To(ContainSubstring(`<logger name="org.apache.cassandra.db.monitoring.MonitoringTask" level="DEBUG" additivity="false">`))
+	g.Expect(config).ToNot(ContainSubstring("AUDIT"))
+	g.Expect(jsonLogPattern).To(ContainSubstring(`"message":"%replace(%replace(%replace(%replace(%msg){'\\','\\\\'}){'"','\\"'}){'\r?\n','\\n'}){'\t','\\t'}"`))
+}
+
+func TestGCLogOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cc := &v1alpha1.CassandraCluster{Spec: v1alpha1.CassandraClusterSpec{Cassandra: &v1alpha1.Cassandra{}}}
+	g.Expect(gcLogOptions(cc)).To(Equal(java8GCLogOptions))
+	cc.Spec.Cassandra.Image = "cassandra:3.11.13"
+	g.Expect(gcLogOptions(cc)).To(Equal(java8GCLogOptions))
+	cc.Spec.Cassandra.Image = "cassandra:4.0.7"
+	g.Expect(gcLogOptions(cc)).To(Equal(java11GCLogOptions))
+}
+
+func TestReconcileLoggingLevels(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	nodectlMock := mocks.NewMockNodectl(mCtrl)
+
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Cassandra: &v1alpha1.Cassandra{
+				Logging: &v1alpha1.CassandraLogging{
+					Loggers: map[string]v1alpha1.LoggerLevel{
+						"org.apache.cassandra.gms":       "WARN",
+						"org.apache.cassandra.db.commit": "DEBUG",
+					},
+				},
+			},
+		},
+	}
+	startedAt := time.Now().Truncate(time.Second)
+	pod0 := runtimeSettingsTestPod("test-cassandra-dc1-0", "10.0.0.1", startedAt)
+	pod1 := runtimeSettingsTestPod("test-cassandra-dc1-1", "10.0.0.2", startedAt)
+	pod2 := runtimeSettingsTestPod("test-cassandra-dc1-2", "10.0.0.3", startedAt)
+	pod2.Status.ContainerStatuses[0].Ready = false
+	fakeRecorder := record.NewFakeRecorder(10)
+	tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc).Build()
+
+	reconciler := &CassandraClusterReconciler{
+		Client: tClient,
+		Events: events.NewEventRecorder(fakeRecorder),
+		Log:    zap.NewNop().Sugar(),
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return nodectlMock
+		},
+	}
+	ctx := context.Background()
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, cc)).To(Succeed())
+	podList := &v1.PodList{Items: []v1.Pod{pod0, pod1, pod2}}
+
+	nodectlMock.EXPECT().LoggingLevels(gomock.Any(), "10.0.0.1").Return(map[string]string{"org.apache.cassandra": "INFO", "org.apache.cassandra.gms": "WARN"}, nil)
+	nodectlMock.EXPECT().SetLoggingLevel(gomock.Any(), "10.0.0.1", "org.apache.cassandra.db.commit", "DEBUG").Return(nil)
+	nodectlMock.EXPECT().LoggingLevels(gomock.Any(), "10.0.0.2").Return(map[string]string{}, nil)
+	nodectlMock.EXPECT().SetLoggingLevel(gomock.Any(), "10.0.0.2", "org.apache.cassandra.db.commit", "DEBUG").Return(nil)
+	nodectlMock.EXPECT().SetLoggingLevel(gomock.Any(), "10.0.0.2", "org.apache.cassandra.gms", "WARN").Return(errors.New("connection refused"))
+	g.Expect(reconciler.reconcileLoggingLevels(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(fakeRecorder.Events).To(HaveLen(1))
+	g.Expect(<-fakeRecorder.Events).To(HavePrefix("Warning LoggingLevelNotApplied Failed to set level of logger org.apache.cassandra.gms of node test-cassandra-dc1-1 to WARN"))
+	g.Expect(cc.Status.LoggingLevels).To(Equal([]v1alpha1.NodeLoggingLevels{
+		{
+			Pod:           "test-cassandra-dc1-0",
+			Loggers:       cc.Spec.Cassandra.Logging.Loggers,
+			NodeStartedAt: &metav1.Time{Time: startedAt},
+		},
+	}))
+
+	// nodes the levels were set on are skipped, the others are retried
+	nodectlMock.EXPECT().LoggingLevels(gomock.Any(), "10.0.0.2").Return(map[string]string{"org.apache.cassandra.db.commit": "DEBUG"}, nil)
+	nodectlMock.EXPECT().SetLoggingLevel(gomock.Any(), "10.0.0.2", "org.apache.cassandra.gms", "WARN").Return(nil)
+	g.Expect(reconciler.reconcileLoggingLevels(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.LoggingLevels).To(HaveLen(2))
+
+	// the levels are checked again after the node restarts
+	podList.Items[0] = runtimeSettingsTestPod("test-cassandra-dc1-0", "10.0.0.4", startedAt.Add(time.Minute))
+	nodectlMock.EXPECT().LoggingLevels(gomock.Any(), "10.0.0.4").Return(map[string]string{"org.apache.cassandra.db.commit": "DEBUG", "org.apache.cassandra.gms": "WARN"}, nil)
+	g.Expect(reconciler.reconcileLoggingLevels(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.LoggingLevels[0].NodeStartedAt.Time).To(Equal(startedAt.Add(time.Minute)))
+
+	// the status is removed with the loggers
+	cc.Spec.Cassandra.Logging = nil
+	g.Expect(reconciler.reconcileLoggingLevels(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.LoggingLevels).To(BeNil())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/labels"
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/pkg/errors"
@@ -14,10 +15,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sort"
+	"time"
 )
 
 func (r *CassandraClusterReconciler) reconcileMaintenance(ctx context.Context, desiredCC *dbv1alpha1.CassandraCluster) error {
-	err := r.processMaintenanceRequest(ctx, desiredCC)
+	now := time.Now()
+	err := r.processMaintenanceRequest(ctx, desiredCC, now)
 	if err != nil {
 		return errors.Wrap(err, "Failed to process maintenance mode request")
 	}
@@ -25,10 +28,21 @@ func (r *CassandraClusterReconciler) reconcileMaintenance(ctx context.Context, d
 	if err != nil {
 		return errors.Wrap(err, "Failed to generate status")
 	}
+	records, err := r.generateMaintenanceRecords(ctx, desiredCC, now)
+	if err != nil {
+		return errors.Wrap(err, "Failed to generate maintenance records")
+	}
 	r.Log.Debugf("Spec: %s, Status: %s", fmt.Sprint(desiredCC.Spec.Maintenance), fmt.Sprint(status))
 	actualCC := desiredCC.DeepCopy()
 	actualCC.Status.MaintenanceState = status
-	return r.Status().Update(ctx, actualCC)
+	actualCC.Status.MaintenancePods = records
+	if err = r.Status().Update(ctx, actualCC); err != nil {
+		return err
+	}
+	desiredCC.Status.MaintenanceState = status
+	desiredCC.Status.MaintenancePods = records
+	desiredCC.ResourceVersion = actualCC.ResourceVersion
+	return nil
 }
 
 func (r *CassandraClusterReconciler) reconcileMaintenanceConfigMap(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
@@ -111,16 +125,22 @@ func (r *CassandraClusterReconciler) checkCassandraRunning(pod v1.Pod) bool {
 	return false
 }
 
-func (r *CassandraClusterReconciler) processMaintenanceRequest(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
+func (r *CassandraClusterReconciler) processMaintenanceRequest(ctx context.Context, cc *dbv1alpha1.CassandraCluster, now time.Time) error {
 	pods, err := r.getCassandraPods(ctx, cc)
 	if err != nil {
 		return err
 	}
 	for _, pod := range pods.Items {
 		isRunning := r.checkMaintenanceRunning(pod)
-		if inMaintenanceSpec(cc.Spec.Maintenance, pod.Name) && !isRunning {
+		requested := cc.MaintenanceRequested(pod.Labels[dbv1alpha1.CassandraClusterDC], pod.Name, now)
+		if requested && !isRunning {
 			return r.updateMaintenanceMode(ctx, cc, true, pod)
-		} else if !inMaintenanceSpec(cc.Spec.Maintenance, pod.Name) && isRunning {
+		} else if !requested && isRunning {
+			if cc.PodMaintenanceRequest(pod.Labels[dbv1alpha1.CassandraClusterDC], pod.Name) != nil {
+				msg := fmt.Sprintf("Maintenance of pod %s expired, taking it out of maintenance", pod.Name)
+				r.Log.Info(msg)
+				r.Events.Normal(cc, events.EventMaintenanceExpired, msg)
+			}
 			return r.updateMaintenanceMode(ctx, cc, false, pod)
 		}
 	}
@@ -188,15 +208,62 @@ func (r *CassandraClusterReconciler) generateMaintenanceStatus(ctx context.Conte
 	return status, nil
 }
 
-func inMaintenanceSpec(m []dbv1alpha1.Maintenance, podName string) bool {
-	for _, entry := range m {
-		for _, pod := range entry.Pods {
-			if string(pod) == podName {
-				return true
+// generateMaintenanceRecords records the time each pod entered and exited maintenance
+func (r *CassandraClusterReconciler) generateMaintenanceRecords(ctx context.Context, cc *dbv1alpha1.CassandraCluster, now time.Time) ([]dbv1alpha1.PodMaintenance, error) {
+	pods, err := r.getCassandraPods(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+	inMaintenance := make(map[string]v1.Pod)
+	for _, pod := range pods.Items {
+		if r.checkMaintenanceRunning(pod) {
+			inMaintenance[pod.Name] = pod
+		}
+	}
+	timestamp := metav1.Time{Time: now.Truncate(time.Second)}
+	records := make([]dbv1alpha1.PodMaintenance, 0, len(cc.Status.MaintenancePods))
+	for _, record := range cc.Status.MaintenancePods {
+		_, running := inMaintenance[string(record.Pod)]
+		request := cc.PodMaintenanceRequest(record.DC, string(record.Pod))
+		switch {
+		case record.ExitedAt == nil && running:
+			if request != nil { // the request can be extended while the pod is in maintenance
+				record.Reason = request.Reason
+				record.RequestedBy = request.RequestedBy
+				record.ExpiresAt = request.ExpiresAt(record.EnteredAt.Time)
 			}
+			delete(inMaintenance, string(record.Pod))
+		case record.ExitedAt == nil && !running:
+			record.ExitedAt = &timestamp
+			record.Expired = request != nil && !cc.MaintenanceRequested(record.DC, string(record.Pod), now)
+		case record.ExitedAt != nil && running:
+			continue // the pod entered maintenance again
+		case request == nil:
+			record.Expired = false
 		}
+		records = append(records, record)
 	}
-	return false
+	for _, pod := range inMaintenance {
+		dcName := pod.Labels[dbv1alpha1.CassandraClusterDC]
+		record := dbv1alpha1.PodMaintenance{
+			Pod:       dbv1alpha1.PodName(pod.Name),
+			DC:        dcName,
+			EnteredAt: timestamp,
+		}
+		if request := cc.PodMaintenanceRequest(dcName, pod.Name); request != nil {
+			record.Reason = request.Reason
+			record.RequestedBy = request.RequestedBy
+			record.ExpiresAt = request.ExpiresAt(now)
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Pod < records[j].Pod
+	})
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records, nil
 }
 
 func containsDc(m []dbv1alpha1.Maintenance, dcName string) bool {
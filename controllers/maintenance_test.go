@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
@@ -779,3 +780,102 @@ func TestGenerateMaintenanceStatus(t *testing.T) {
 		asserts.Expect(maintenance).To(BeEquivalentTo(tc.expectedResult))
 	})
 }
+
+func TestMaintenanceRequested(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	now := time.Date(2022, 10, 22, 12, 0, 0, 0, time.UTC)
+	cc := baseCC.DeepCopy()
+	cc.Spec.Maintenance = []v1alpha1.Maintenance{
+		{
+			DC:       "dc1",
+			Pods:     []v1alpha1.PodName{"test-cassandra-dc1-0"},
+			Duration: &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			DC:    "dc2",
+			Until: &metav1.Time{Time: now},
+		},
+	}
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-0", now)).To(BeTrue())
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-1", now)).To(BeFalse())
+	asserts.Expect(cc.MaintenanceRequested("dc2", "test-cassandra-dc2-1", now.Add(-time.Minute))).To(BeTrue())
+	asserts.Expect(cc.MaintenanceRequested("dc2", "test-cassandra-dc2-1", now)).To(BeFalse())
+
+	// the duration starts when the pod enters maintenance
+	cc.Status.MaintenancePods = []v1alpha1.PodMaintenance{
+		{
+			Pod:       "test-cassandra-dc1-0",
+			DC:        "dc1",
+			EnteredAt: metav1.Time{Time: now.Add(-30 * time.Minute)},
+			ExpiresAt: &metav1.Time{Time: now.Add(30 * time.Minute)},
+		},
+	}
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-0", now)).To(BeTrue())
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-0", now.Add(30*time.Minute))).To(BeFalse())
+
+	// an expired request doesn't put the pod into maintenance again until it's changed
+	cc.Status.MaintenancePods[0].ExitedAt = &metav1.Time{Time: now.Add(30 * time.Minute)}
+	cc.Status.MaintenancePods[0].Expired = true
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-0", now.Add(time.Hour))).To(BeFalse())
+	cc.Spec.Maintenance[0].Duration = &metav1.Duration{Duration: 2 * time.Hour}
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-0", now.Add(time.Hour))).To(BeTrue())
+}
+
+func TestGenerateMaintenanceRecords(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	now := time.Now().Truncate(time.Second)
+	cc := baseCC.DeepCopy()
+	cc.Status.MaintenancePods = nil // set on baseCC by the other maintenance tests
+	cc.Spec.Maintenance = []v1alpha1.Maintenance{
+		{
+			DC:          "dc1",
+			Pods:        []v1alpha1.PodName{"test-cassandra-dc1-0", "test-cassandra-dc1-1"},
+			Reason:      "debugging",
+			RequestedBy: "jane",
+			Duration:    &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	reconciler := initializeReconciler(cc)
+	k8sResources := []client.Object{cc}
+	for _, pod := range mockedRunningCassandraPods(cc) {
+		k8sResources = append(k8sResources, client.Object(pod))
+	}
+	k8sResources[findResource("test-cassandra-dc1-0", k8sResources)] = mockedRunningMaintenancePod(cc, cc.Spec.DCs[0], 0)
+	k8sResources[findResource("test-cassandra-dc1-1", k8sResources)] = mockedRunningMaintenancePod(cc, cc.Spec.DCs[0], 1)
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(k8sResources...).Build()
+
+	records, err := reconciler.generateMaintenanceRecords(context.Background(), cc, now)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(records).To(Equal([]v1alpha1.PodMaintenance{
+		{
+			Pod:         "test-cassandra-dc1-0",
+			DC:          "dc1",
+			Reason:      "debugging",
+			RequestedBy: "jane",
+			EnteredAt:   metav1.Time{Time: now},
+			ExpiresAt:   &metav1.Time{Time: now.Add(time.Hour)},
+		},
+		{
+			Pod:         "test-cassandra-dc1-1",
+			DC:          "dc1",
+			Reason:      "debugging",
+			RequestedBy: "jane",
+			EnteredAt:   metav1.Time{Time: now},
+			ExpiresAt:   &metav1.Time{Time: now.Add(time.Hour)},
+		},
+	}))
+
+	// dc1-0 exits maintenance after its request expired, dc1-1 is removed from the spec
+	cc.Status.MaintenancePods = records
+	cc.Spec.Maintenance[0].Pods = []v1alpha1.PodName{"test-cassandra-dc1-0"}
+	later := now.Add(time.Hour)
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc, mockedRunningCassandraPod(cc, cc.Spec.DCs[0], 0), mockedRunningCassandraPod(cc, cc.Spec.DCs[0], 1)).Build()
+	records, err = reconciler.generateMaintenanceRecords(context.Background(), cc, later)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(records).To(HaveLen(2))
+	asserts.Expect(records[0].ExitedAt).To(Equal(&metav1.Time{Time: later}))
+	asserts.Expect(records[0].Expired).To(BeTrue())
+	asserts.Expect(records[1].ExitedAt).To(Equal(&metav1.Time{Time: later}))
+	asserts.Expect(records[1].Expired).To(BeFalse())
+	asserts.Expect(cc.MaintenanceRequested("dc1", "test-cassandra-dc1-0", later)).To(BeFalse())
+}
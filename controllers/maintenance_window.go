@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+)
+
+// inMaintenanceWindow returns true if now is within one of the maintenance windows.
+// Otherwise it returns the start of the next window, which is zero if none of the windows starts anymore.
+func inMaintenanceWindow(windows []v1alpha1.MaintenanceWindow, now time.Time) (bool, time.Time) {
+	var nextStart time.Time
+	for _, window := range windows {
+		sched, err := v1alpha1.ParseMaintenanceWindow(window)
+		if err != nil { // rejected by the webhook
+			continue
+		}
+
+		// the first start after now-duration is either the start of the current window or of the next one
+		start := sched.Next(now.Add(-window.Duration.Duration))
+		if start.IsZero() {
+			continue
+		}
+
+		if !start.After(now) {
+			return true, time.Time{}
+		}
+
+		if nextStart.IsZero() || start.Before(nextStart) {
+			nextStart = start
+		}
+	}
+
+	return false, nextStart
+}
+
+// maintenanceAllowed returns true if a disruptive operation can start now. Otherwise the operation is queued in the status
+// until the next maintenance window starts. Operations are always allowed if no maintenance windows are set.
+func (r *CassandraClusterReconciler) maintenanceAllowed(ctx context.Context, cc *v1alpha1.CassandraCluster, opType v1alpha1.QueuedOperationType, target string) (bool, error) {
+	if len(cc.Spec.MaintenanceWindows) == 0 {
+		return true, nil
+	}
+
+	now := time.Now()
+	inWindow, nextStart := inMaintenanceWindow(cc.Spec.MaintenanceWindows, now)
+	if inWindow {
+		return true, nil
+	}
+
+	op := v1alpha1.QueuedOperation{
+		Type:   opType,
+		Target: target,
+		Since:  metav1.Time{Time: now.Truncate(time.Second)},
+	}
+	if !nextStart.IsZero() {
+		op.EarliestStartTime = &metav1.Time{Time: nextStart}
+	}
+
+	queue := append([]v1alpha1.QueuedOperation{}, cc.Status.QueuedOperations...)
+	queued := false
+	for i := range queue {
+		if queue[i].Type == opType && queue[i].Target == target {
+			op.Since = queue[i].Since
+			queue[i] = op
+			queued = true
+		}
+	}
+
+	if !queued {
+		msg := fmt.Sprintf("%s of %s is queued until the next maintenance window", opType, target)
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventOperationQueued, msg)
+		queue = append(queue, op)
+	}
+
+	return false, r.updateQueuedOperations(ctx, cc, queue)
+}
+
+// reconcileQueuedOperations clears the queue once a maintenance window starts, as the queued operations are started
+// in the same reconcile or are not needed anymore
+func (r *CassandraClusterReconciler) reconcileQueuedOperations(ctx context.Context, cc *v1alpha1.CassandraCluster) error {
+	if len(cc.Status.QueuedOperations) == 0 {
+		return nil
+	}
+
+	if len(cc.Spec.MaintenanceWindows) != 0 {
+		if inWindow, _ := inMaintenanceWindow(cc.Spec.MaintenanceWindows, time.Now()); !inWindow {
+			return nil
+		}
+	}
+
+	r.Log.Info("Maintenance window started, starting the queued operations")
+	return r.updateQueuedOperations(ctx, cc, nil)
+}
+
+func (r *CassandraClusterReconciler) updateQueuedOperations(ctx context.Context, cc *v1alpha1.CassandraCluster, queue []v1alpha1.QueuedOperation) error {
+	if cmp.Equal(cc.Status.QueuedOperations, queue, cmpopts.EquateEmpty()) {
+		return nil
+	}
+
+	ccStatus := cc.DeepCopy()
+	ccStatus.Status.QueuedOperations = queue
+	if err := r.Status().Update(ctx, ccStatus); err != nil {
+		return errors.Wrap(err, "failed to update queued operations")
+	}
+
+	cc.Status.QueuedOperations = queue
+	cc.ResourceVersion = ccStatus.ResourceVersion
+	return nil
+}
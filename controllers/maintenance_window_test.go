@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+	windows := []v1alpha1.MaintenanceWindow{
+		{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}},                         // Saturdays 2am-6am UTC
+		{Schedule: "0 22 * * 3", TimeZone: "Europe/Berlin", Duration: metav1.Duration{Duration: time.Hour}}, // Wednesdays 10pm-11pm in Berlin
+	}
+
+	saturday := time.Date(2022, 10, 22, 0, 0, 0, 0, time.UTC)
+	inWindow, next := inMaintenanceWindow(windows, saturday.Add(3*time.Hour))
+	g.Expect(inWindow).To(BeTrue())
+	g.Expect(next.IsZero()).To(BeTrue())
+
+	inWindow, next = inMaintenanceWindow(windows, saturday.Add(6*time.Hour))
+	g.Expect(inWindow).To(BeFalse())
+	g.Expect(next).To(BeTemporally("==", time.Date(2022, 10, 26, 20, 0, 0, 0, time.UTC))) // CEST is UTC+2
+
+	inWindow, next = inMaintenanceWindow(windows, saturday.Add(time.Hour))
+	g.Expect(inWindow).To(BeFalse())
+	g.Expect(next).To(BeTemporally("==", saturday.Add(2*time.Hour)))
+
+	inWindow, next = inMaintenanceWindow(nil, saturday)
+	g.Expect(inWindow).To(BeFalse())
+	g.Expect(next.IsZero()).To(BeTrue())
+}
+
+func TestMaintenanceAllowed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc).Build()
+	reconciler := &CassandraClusterReconciler{
+		Client: tClient,
+		Scheme: baseScheme,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		Log:    zap.NewNop().Sugar(),
+	}
+	ctx := context.Background()
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, cc)).To(Succeed())
+
+	allowed, err := reconciler.maintenanceAllowed(ctx, cc, v1alpha1.QueuedOperationDecommission, "test-cassandra-dc1-2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(allowed).To(BeTrue())
+
+	// a window that started an hour ago
+	start := time.Now().UTC().Add(-time.Hour)
+	cc.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{
+		{Schedule: fmt.Sprintf("%d %d * * *", start.Minute(), start.Hour()), Duration: metav1.Duration{Duration: 2 * time.Hour}},
+	}
+	allowed, err = reconciler.maintenanceAllowed(ctx, cc, v1alpha1.QueuedOperationDecommission, "test-cassandra-dc1-2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(allowed).To(BeTrue())
+
+	cc.Spec.MaintenanceWindows[0].Duration.Duration = 30 * time.Minute
+	allowed, err = reconciler.maintenanceAllowed(ctx, cc, v1alpha1.QueuedOperationDecommission, "test-cassandra-dc1-2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(allowed).To(BeFalse())
+	allowed, err = reconciler.maintenanceAllowed(ctx, cc, v1alpha1.QueuedOperationRepair, "my_keyspace")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(allowed).To(BeFalse())
+	allowed, err = reconciler.maintenanceAllowed(ctx, cc, v1alpha1.QueuedOperationDecommission, "test-cassandra-dc1-2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(allowed).To(BeFalse())
+
+	actualCC := &v1alpha1.CassandraCluster{}
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, actualCC)).To(Succeed())
+	g.Expect(actualCC.Status.QueuedOperations).To(HaveLen(2))
+	g.Expect(actualCC.Status.QueuedOperations[0].Type).To(Equal(v1alpha1.QueuedOperationDecommission))
+	g.Expect(actualCC.Status.QueuedOperations[0].Target).To(Equal("test-cassandra-dc1-2"))
+	g.Expect(actualCC.Status.QueuedOperations[0].EarliestStartTime.Time).To(BeTemporally("~", start.Add(24*time.Hour), time.Minute))
+	g.Expect(actualCC.Status.QueuedOperations[1].Type).To(Equal(v1alpha1.QueuedOperationRepair))
+
+	// the queue is cleared once the window starts
+	cc.Spec.MaintenanceWindows[0].Duration.Duration = 2 * time.Hour
+	g.Expect(reconciler.reconcileQueuedOperations(ctx, cc)).To(Succeed())
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, actualCC)).To(Succeed())
+	g.Expect(actualCC.Status.QueuedOperations).To(BeEmpty())
+}
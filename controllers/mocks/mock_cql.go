@@ -104,6 +104,36 @@ func (mr *MockCqlClientMockRecorder) GetRoles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockCqlClient)(nil).GetRoles))
 }
 
+// GetSchemaVersion mocks base method.
+func (m *MockCqlClient) GetSchemaVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockCqlClientMockRecorder) GetSchemaVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockCqlClient)(nil).GetSchemaVersion))
+}
+
+// GetTables mocks base method.
+func (m *MockCqlClient) GetTables() ([]cql.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTables")
+	ret0, _ := ret[0].([]cql.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTables indicates an expected call of GetTables.
+func (mr *MockCqlClientMockRecorder) GetTables() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTables", reflect.TypeOf((*MockCqlClient)(nil).GetTables))
+}
+
 // Query mocks base method.
 func (m *MockCqlClient) Query(stmt string, values ...interface{}) error {
 	m.ctrl.T.Helper()
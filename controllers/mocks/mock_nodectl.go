@@ -49,6 +49,20 @@ func (mr *MockNodectlMockRecorder) Assassinate(ctx, execNodeIP, assassinateNodeI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assassinate", reflect.TypeOf((*MockNodectl)(nil).Assassinate), ctx, execNodeIP, assassinateNodeIP)
 }
 
+// ClearSnapshot mocks base method.
+func (m *MockNodectl) ClearSnapshot(ctx context.Context, nodeIP, tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSnapshot", ctx, nodeIP, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearSnapshot indicates an expected call of ClearSnapshot.
+func (mr *MockNodectlMockRecorder) ClearSnapshot(ctx, nodeIP, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSnapshot", reflect.TypeOf((*MockNodectl)(nil).ClearSnapshot), ctx, nodeIP, tag)
+}
+
 // ClusterView mocks base method.
 func (m *MockNodectl) ClusterView(ctx context.Context, nodeIP string) (nodectl.ClusterView, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +78,36 @@ func (mr *MockNodectlMockRecorder) ClusterView(ctx, nodeIP interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterView", reflect.TypeOf((*MockNodectl)(nil).ClusterView), ctx, nodeIP)
 }
 
+// CompactionThroughput mocks base method.
+func (m *MockNodectl) CompactionThroughput(ctx context.Context, nodeIP string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactionThroughput", ctx, nodeIP)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompactionThroughput indicates an expected call of CompactionThroughput.
+func (mr *MockNodectlMockRecorder) CompactionThroughput(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactionThroughput", reflect.TypeOf((*MockNodectl)(nil).CompactionThroughput), ctx, nodeIP)
+}
+
+// ConcurrentCompactors mocks base method.
+func (m *MockNodectl) ConcurrentCompactors(ctx context.Context, nodeIP string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConcurrentCompactors", ctx, nodeIP)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConcurrentCompactors indicates an expected call of ConcurrentCompactors.
+func (mr *MockNodectlMockRecorder) ConcurrentCompactors(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConcurrentCompactors", reflect.TypeOf((*MockNodectl)(nil).ConcurrentCompactors), ctx, nodeIP)
+}
+
 // Decommission mocks base method.
 func (m *MockNodectl) Decommission(ctx context.Context, nodeIP string) error {
 	m.ctrl.T.Helper()
@@ -78,6 +122,77 @@ func (mr *MockNodectlMockRecorder) Decommission(ctx, nodeIP interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decommission", reflect.TypeOf((*MockNodectl)(nil).Decommission), ctx, nodeIP)
 }
 
+// DisableBinary mocks base method.
+func (m *MockNodectl) DisableBinary(ctx context.Context, nodeIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableBinary", ctx, nodeIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableBinary indicates an expected call of DisableBinary.
+func (mr *MockNodectlMockRecorder) DisableBinary(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableBinary", reflect.TypeOf((*MockNodectl)(nil).DisableBinary), ctx, nodeIP)
+}
+
+// DisableGossip mocks base method.
+func (m *MockNodectl) DisableGossip(ctx context.Context, nodeIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableGossip", ctx, nodeIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableGossip indicates an expected call of DisableGossip.
+func (mr *MockNodectlMockRecorder) DisableGossip(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableGossip", reflect.TypeOf((*MockNodectl)(nil).DisableGossip), ctx, nodeIP)
+}
+
+// Drain mocks base method.
+func (m *MockNodectl) Drain(ctx context.Context, nodeIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", ctx, nodeIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockNodectlMockRecorder) Drain(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockNodectl)(nil).Drain), ctx, nodeIP)
+}
+
+// Flush mocks base method.
+func (m *MockNodectl) Flush(ctx context.Context, nodeIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx, nodeIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockNodectlMockRecorder) Flush(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockNodectl)(nil).Flush), ctx, nodeIP)
+}
+
+// LoggingLevels mocks base method.
+func (m *MockNodectl) LoggingLevels(ctx context.Context, nodeIP string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoggingLevels", ctx, nodeIP)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoggingLevels indicates an expected call of LoggingLevels.
+func (mr *MockNodectlMockRecorder) LoggingLevels(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoggingLevels", reflect.TypeOf((*MockNodectl)(nil).LoggingLevels), ctx, nodeIP)
+}
+
 // OperationMode mocks base method.
 func (m *MockNodectl) OperationMode(ctx context.Context, nodeIP string) (nodectl.OperationMode, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +208,120 @@ func (mr *MockNodectlMockRecorder) OperationMode(ctx, nodeIP interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationMode", reflect.TypeOf((*MockNodectl)(nil).OperationMode), ctx, nodeIP)
 }
 
+// SetCompactionThroughput mocks base method.
+func (m *MockNodectl) SetCompactionThroughput(ctx context.Context, nodeIP string, mbPerSec int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCompactionThroughput", ctx, nodeIP, mbPerSec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCompactionThroughput indicates an expected call of SetCompactionThroughput.
+func (mr *MockNodectlMockRecorder) SetCompactionThroughput(ctx, nodeIP, mbPerSec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompactionThroughput", reflect.TypeOf((*MockNodectl)(nil).SetCompactionThroughput), ctx, nodeIP, mbPerSec)
+}
+
+// SetConcurrentCompactors mocks base method.
+func (m *MockNodectl) SetConcurrentCompactors(ctx context.Context, nodeIP string, compactors int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetConcurrentCompactors", ctx, nodeIP, compactors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetConcurrentCompactors indicates an expected call of SetConcurrentCompactors.
+func (mr *MockNodectlMockRecorder) SetConcurrentCompactors(ctx, nodeIP, compactors interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConcurrentCompactors", reflect.TypeOf((*MockNodectl)(nil).SetConcurrentCompactors), ctx, nodeIP, compactors)
+}
+
+// SetHintedHandoffThrottle mocks base method.
+func (m *MockNodectl) SetHintedHandoffThrottle(ctx context.Context, nodeIP string, kbPerSec int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHintedHandoffThrottle", ctx, nodeIP, kbPerSec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHintedHandoffThrottle indicates an expected call of SetHintedHandoffThrottle.
+func (mr *MockNodectlMockRecorder) SetHintedHandoffThrottle(ctx, nodeIP, kbPerSec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHintedHandoffThrottle", reflect.TypeOf((*MockNodectl)(nil).SetHintedHandoffThrottle), ctx, nodeIP, kbPerSec)
+}
+
+// SetLoggingLevel mocks base method.
+func (m *MockNodectl) SetLoggingLevel(ctx context.Context, nodeIP, logger, level string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoggingLevel", ctx, nodeIP, logger, level)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoggingLevel indicates an expected call of SetLoggingLevel.
+func (mr *MockNodectlMockRecorder) SetLoggingLevel(ctx, nodeIP, logger, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoggingLevel", reflect.TypeOf((*MockNodectl)(nil).SetLoggingLevel), ctx, nodeIP, logger, level)
+}
+
+// SetStreamThroughput mocks base method.
+func (m *MockNodectl) SetStreamThroughput(ctx context.Context, nodeIP string, megabitsPerSec int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStreamThroughput", ctx, nodeIP, megabitsPerSec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStreamThroughput indicates an expected call of SetStreamThroughput.
+func (mr *MockNodectlMockRecorder) SetStreamThroughput(ctx, nodeIP, megabitsPerSec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStreamThroughput", reflect.TypeOf((*MockNodectl)(nil).SetStreamThroughput), ctx, nodeIP, megabitsPerSec)
+}
+
+// SetTraceProbability mocks base method.
+func (m *MockNodectl) SetTraceProbability(ctx context.Context, nodeIP string, probability float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTraceProbability", ctx, nodeIP, probability)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTraceProbability indicates an expected call of SetTraceProbability.
+func (mr *MockNodectlMockRecorder) SetTraceProbability(ctx, nodeIP, probability interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTraceProbability", reflect.TypeOf((*MockNodectl)(nil).SetTraceProbability), ctx, nodeIP, probability)
+}
+
+// StreamThroughput mocks base method.
+func (m *MockNodectl) StreamThroughput(ctx context.Context, nodeIP string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamThroughput", ctx, nodeIP)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamThroughput indicates an expected call of StreamThroughput.
+func (mr *MockNodectlMockRecorder) StreamThroughput(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamThroughput", reflect.TypeOf((*MockNodectl)(nil).StreamThroughput), ctx, nodeIP)
+}
+
+// TraceProbability mocks base method.
+func (m *MockNodectl) TraceProbability(ctx context.Context, nodeIP string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceProbability", ctx, nodeIP)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceProbability indicates an expected call of TraceProbability.
+func (mr *MockNodectlMockRecorder) TraceProbability(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceProbability", reflect.TypeOf((*MockNodectl)(nil).TraceProbability), ctx, nodeIP)
+}
+
 // Version mocks base method.
 func (m *MockNodectl) Version(ctx context.Context, nodeIP string) (int, int, int, error) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,8 @@ package names
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 )
@@ -31,6 +33,10 @@ func ProberServiceAccount(clusterName string) string {
 	return clusterName + "-cassandra-prober-serviceaccount"
 }
 
+func ProberStateConfigMap(clusterName string) string {
+	return clusterName + "-cassandra-prober-state"
+}
+
 func ProberIngress(clusterName string) string {
 	return clusterName + "-cassandra-prober"
 }
@@ -75,6 +81,10 @@ func MaintenanceConfigMap(clusterName string) string {
 	return clusterName + "-maintenance-configmap"
 }
 
+func PlanConfigMap(clusterName string) string {
+	return clusterName + "-plan"
+}
+
 func DC(clusterName, dcName string) string {
 	return clusterName + "-cassandra-" + dcName
 }
@@ -198,3 +208,29 @@ func ProberNetworkPolicyName(clusterName string) string {
 func ReaperNetworkPolicyName(clusterName string) string {
 	return clusterName + "-reaper-policies"
 }
+
+func RestoreLoadJob(restoreName string, index int) string {
+	return fmt.Sprintf("%s-load-%d", restoreName, index)
+}
+
+func PodDecommissionJob(podName string) string {
+	return "pod-decommission-" + podName
+}
+
+func PodShutdownJob(podName string) string {
+	return "pod-shutdown-" + podName
+}
+
+func PodDisruptionBudget(clusterName, dcName string) string {
+	return DC(clusterName, dcName)
+}
+
+// PodOrdinal returns the ordinal of a statefulset pod, e.g. 2 for `cluster-cassandra-dc1-2`, or -1 if the name has no ordinal
+func PodOrdinal(podName string) int {
+	ordinal, err := strconv.Atoi(podName[strings.LastIndex(podName, "-")+1:])
+	if err != nil {
+		return -1
+	}
+
+	return ordinal
+}
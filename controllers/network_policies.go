@@ -23,11 +23,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func (r *CassandraClusterReconciler) reconcileNetworkPolicies(ctx context.Context, cc *dbv1alpha1.CassandraCluster, proberClient prober.ProberClient, podList *v1.PodList) error {
+func (r *CassandraClusterReconciler) reconcileNetworkPolicies(ctx context.Context, cc *dbv1alpha1.CassandraCluster, proberClient prober.ProberClient, podList *v1.PodList, fencedBy string) error {
 	var err error
 
 	if cc.Spec.NetworkPolicies.Enabled {
-		if err = r.reconcileCassandraNetworkPolicies(ctx, cc, proberClient, podList); err != nil {
+		if err = r.reconcileCassandraNetworkPolicies(ctx, cc, proberClient, podList, fencedBy); err != nil {
 			return errors.Wrapf(err, "Failed to reconcile Cassandra Kubernetes Network Policies")
 		}
 
@@ -43,7 +43,7 @@ func (r *CassandraClusterReconciler) reconcileNetworkPolicies(ctx context.Contex
 	return nil
 }
 
-func (r *CassandraClusterReconciler) reconcileCassandraNetworkPolicies(ctx context.Context, cc *dbv1alpha1.CassandraCluster, proberClient prober.ProberClient, podList *v1.PodList) error {
+func (r *CassandraClusterReconciler) reconcileCassandraNetworkPolicies(ctx context.Context, cc *dbv1alpha1.CassandraCluster, proberClient prober.ProberClient, podList *v1.PodList, fencedBy string) error {
 	var err error
 
 	baseCasPolicy := &nwv1.NetworkPolicy{
@@ -75,7 +75,7 @@ func (r *CassandraClusterReconciler) reconcileCassandraNetworkPolicies(ctx conte
 		return errors.Wrap(err, "Failed to reconcile network policy")
 	}
 
-	if err = r.cassandraClusterExtraRulesPolicy(ctx, cc, baseCasPolicy); err != nil {
+	if err = r.cassandraClusterExtraRulesPolicy(ctx, cc, baseCasPolicy, fencedBy); err != nil {
 		return errors.Wrap(err, "Failed to reconcile network policy")
 	}
 
@@ -320,6 +320,17 @@ func (r *CassandraClusterReconciler) cassandraClusterPolicy(ctx context.Context,
 				nwPolicyPeer(map[string]string{dbv1alpha1.CassandraClusterComponent: dbv1alpha1.CassandraClusterComponentReaper}, cc.Namespace),
 			},
 		},
+		// Allow restore jobs, sstableloader streams the SSTables to the nodes
+		{
+			Ports: []nwv1.NetworkPolicyPort{
+				nwPolicyPort(dbv1alpha1.CqlPort),
+				nwPolicyPort(dbv1alpha1.TlsPort),
+				nwPolicyPort(dbv1alpha1.IntraPort),
+			},
+			From: []nwv1.NetworkPolicyPeer{
+				nwPolicyPeer(map[string]string{dbv1alpha1.CassandraClusterComponent: dbv1alpha1.CassandraClusterComponentRestore}, cc.Namespace),
+			},
+		},
 	}
 
 	if err := r.reconcileNetworkPolicy(ctx, cc, desiredClusterCasPolicy); err != nil {
@@ -425,7 +436,26 @@ func (r *CassandraClusterReconciler) cassandraClusterHostPortReaperPolicy(ctx co
 	return nil
 }
 
-func (r *CassandraClusterReconciler) cassandraClusterExtraRulesPolicy(ctx context.Context, cc *dbv1alpha1.CassandraCluster, baseNetworkPolicy *nwv1.NetworkPolicy) error {
+func (r *CassandraClusterReconciler) cassandraClusterExtraRulesPolicy(ctx context.Context, cc *dbv1alpha1.CassandraCluster, baseNetworkPolicy *nwv1.NetworkPolicy, fencedBy string) error {
+	// client connections are blocked while a restore fences the cluster
+	if len(fencedBy) != 0 {
+		extraCasPolicy := &nwv1.NetworkPolicy{}
+		err := r.Get(ctx, types.NamespacedName{Name: names.CassandraExtraRulesPolicyName(cc.Name), Namespace: cc.Namespace}, extraCasPolicy)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		r.Log.Infof("Removing network policy %s while restore %s is running", extraCasPolicy.Name, fencedBy)
+		if err = r.Delete(ctx, extraCasPolicy); err != nil {
+			return errors.Wrapf(err, "Failed to delete network policy %s", extraCasPolicy.Name)
+		}
+
+		return nil
+	}
+
 	if len(cc.Spec.NetworkPolicies.ExtraCassandraRules) > 0 {
 
 		desiredExtraCasPolicy := baseNetworkPolicy.DeepCopy()
@@ -0,0 +1,14 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ClearSnapshot removes the snapshot with the tag from all keyspaces of the node. Clearing a snapshot that doesn't exist is a no-op.
+func (n *client) ClearSnapshot(ctx context.Context, nodeIP, tag string) error {
+	// the signature is required as the operation is overloaded in Cassandra 4
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "clearSnapshot(java.lang.String,[Ljava.lang.String;)", tag, []string{})
+	return errors.Wrapf(err, "failed to clear snapshot %s of node %s", tag, nodeIP)
+}
@@ -0,0 +1,21 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// CompactionThroughput returns the compaction throughput limit of the node in MiB/s, 0 if compactions are not throttled
+func (n *client) CompactionThroughput(ctx context.Context, nodeIP string) (int, error) {
+	var mbPerSec int
+	err := n.readAttribute(ctx, nodeIP, mbeanCassandraDBStorageService, "CompactionThroughputMbPerSec", &mbPerSec)
+	return mbPerSec, errors.Wrapf(err, "failed to get compaction throughput of node %s", nodeIP)
+}
+
+// SetCompactionThroughput limits the compaction throughput of the node. 0 disables throttling.
+func (n *client) SetCompactionThroughput(ctx context.Context, nodeIP string, mbPerSec int) error {
+	n.log.Infof("Setting compaction throughput of node %s to %d MiB/s", nodeIP, mbPerSec)
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "setCompactionThroughputMbPerSec", mbPerSec)
+	return errors.Wrapf(err, "failed to set compaction throughput of node %s", nodeIP)
+}
@@ -0,0 +1,21 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ConcurrentCompactors returns the number of compactions the node runs concurrently
+func (n *client) ConcurrentCompactors(ctx context.Context, nodeIP string) (int, error) {
+	var compactors int
+	err := n.readAttribute(ctx, nodeIP, mbeanCassandraDBStorageService, "ConcurrentCompactors", &compactors)
+	return compactors, errors.Wrapf(err, "failed to get concurrent compactors of node %s", nodeIP)
+}
+
+// SetConcurrentCompactors sets the number of compactions the node runs concurrently
+func (n *client) SetConcurrentCompactors(ctx context.Context, nodeIP string, compactors int) error {
+	n.log.Infof("Setting concurrent compactors of node %s to %d", nodeIP, compactors)
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "setConcurrentCompactors", compactors)
+	return errors.Wrapf(err, "failed to set concurrent compactors of node %s", nodeIP)
+}
@@ -0,0 +1,31 @@
+package nodectl
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+const disableBinaryTimeout = 30 * time.Second
+
+// DisableBinary stops the native transport of the node, so that clients move their connections to other nodes
+func (n *client) DisableBinary(ctx context.Context, nodeIP string) error {
+	ctx, cancel := context.WithTimeout(ctx, disableBinaryTimeout)
+	defer cancel()
+
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "stopNativeTransport",
+	}
+
+	n.log.Infof("Disabling native transport of node %s", nodeIP)
+	if _, err := n.jolokia.Post(ctx, req, nodeIP); err != nil {
+		return errors.Wrapf(err, "failed to disable native transport of node %s", nodeIP)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package nodectl
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+const disableGossipTimeout = 30 * time.Second
+
+// DisableGossip stops gossiping, so that the other nodes mark the node as down and stop sending requests to it
+func (n *client) DisableGossip(ctx context.Context, nodeIP string) error {
+	ctx, cancel := context.WithTimeout(ctx, disableGossipTimeout)
+	defer cancel()
+
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "stopGossiping",
+	}
+
+	n.log.Infof("Disabling gossip of node %s", nodeIP)
+	if _, err := n.jolokia.Post(ctx, req, nodeIP); err != nil {
+		return errors.Wrapf(err, "failed to disable gossip of node %s", nodeIP)
+	}
+
+	return nil
+}
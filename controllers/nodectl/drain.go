@@ -0,0 +1,34 @@
+package nodectl
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+// flushing the memtables of a node with a large heap can take a while
+const drainTimeout = 5 * time.Minute
+
+// Drain flushes all memtables and stops the node from accepting writes, so that the commit log doesn't need to be replayed on restart
+func (n *client) Drain(ctx context.Context, nodeIP string) error {
+	ctx, cancel := context.WithTimeout(ctx, drainTimeout)
+	defer cancel()
+
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "drain",
+	}
+
+	n.log.Infof("Draining node %s", nodeIP)
+	start := time.Now()
+	if _, err := n.jolokia.Post(ctx, req, nodeIP); err != nil {
+		return errors.Wrapf(err, "failed to drain node %s", nodeIP)
+	}
+	n.log.Infof("Node %s drained in %s", nodeIP, time.Since(start).Round(time.Millisecond))
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package nodectl
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+// Flush flushes the memtables of all keyspaces of the node to SSTables
+func (n *client) Flush(ctx context.Context, nodeIP string) error {
+	req := jolokia.JMXRequest{
+		Type:       jmxRequestTypeRead,
+		Mbean:      mbeanCassandraDBStorageService,
+		Attributes: []string{"Keyspaces"},
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return err
+	}
+
+	keyspacesResponse := make(map[string][]string)
+	err = json.Unmarshal(resp.Value, &keyspacesResponse)
+	if err != nil {
+		return errors.Wrapf(err, "can't unmarshal keyspaces, raw body: %s", string(resp.Value))
+	}
+
+	for _, keyspace := range keyspacesResponse["Keyspaces"] {
+		req = jolokia.JMXRequest{
+			Type:      jmxRequestTypeExec,
+			Mbean:     mbeanCassandraDBStorageService,
+			Operation: "forceKeyspaceFlush",
+			Arguments: []interface{}{keyspace, []string{}}, // no tables flushes all tables of the keyspace
+		}
+
+		_, err = n.jolokia.Post(ctx, req, nodeIP)
+		if err != nil {
+			return errors.Wrapf(err, "failed to flush keyspace %s", keyspace)
+		}
+	}
+
+	return nil
+}
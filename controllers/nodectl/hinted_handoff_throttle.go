@@ -0,0 +1,15 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// SetHintedHandoffThrottle limits the throughput of each hint delivery thread of the node. 0 disables throttling.
+// Cassandra doesn't expose the current value.
+func (n *client) SetHintedHandoffThrottle(ctx context.Context, nodeIP string, kbPerSec int) error {
+	n.log.Infof("Setting hinted handoff throttle of node %s to %d KiB/s", nodeIP, kbPerSec)
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "setHintedHandoffThrottleInKB", kbPerSec)
+	return errors.Wrapf(err, "failed to set hinted handoff throttle of node %s", nodeIP)
+}
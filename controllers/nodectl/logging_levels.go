@@ -0,0 +1,21 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// LoggingLevels returns the levels of the loggers of the node that have a level set, by logger name
+func (n *client) LoggingLevels(ctx context.Context, nodeIP string) (map[string]string, error) {
+	levels := make(map[string]string)
+	err := n.readAttribute(ctx, nodeIP, mbeanCassandraDBStorageService, "LoggingLevels", &levels)
+	return levels, errors.Wrapf(err, "failed to get logging levels of node %s", nodeIP)
+}
+
+// SetLoggingLevel sets the level of a logger of the node until it restarts
+func (n *client) SetLoggingLevel(ctx context.Context, nodeIP, logger, level string) error {
+	n.log.Infof("Setting level of logger %s of node %s to %s", logger, nodeIP, level)
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "setLoggingLevel", logger, level)
+	return errors.Wrapf(err, "failed to set level of logger %s of node %s", logger, nodeIP)
+}
@@ -2,8 +2,13 @@ package nodectl
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -21,6 +26,22 @@ type Nodectl interface {
 	Version(ctx context.Context, nodeIP string) (major, minor, patch int, err error)
 	ClusterView(ctx context.Context, nodeIP string) (ClusterView, error)
 	OperationMode(ctx context.Context, nodeIP string) (OperationMode, error)
+	Flush(ctx context.Context, nodeIP string) error
+	ClearSnapshot(ctx context.Context, nodeIP, tag string) error
+	Drain(ctx context.Context, nodeIP string) error
+	DisableBinary(ctx context.Context, nodeIP string) error
+	DisableGossip(ctx context.Context, nodeIP string) error
+	CompactionThroughput(ctx context.Context, nodeIP string) (int, error)
+	SetCompactionThroughput(ctx context.Context, nodeIP string, mbPerSec int) error
+	StreamThroughput(ctx context.Context, nodeIP string) (int, error)
+	SetStreamThroughput(ctx context.Context, nodeIP string, megabitsPerSec int) error
+	ConcurrentCompactors(ctx context.Context, nodeIP string) (int, error)
+	SetConcurrentCompactors(ctx context.Context, nodeIP string, compactors int) error
+	SetHintedHandoffThrottle(ctx context.Context, nodeIP string, kbPerSec int) error
+	TraceProbability(ctx context.Context, nodeIP string) (float64, error)
+	SetTraceProbability(ctx context.Context, nodeIP string, probability float64) error
+	LoggingLevels(ctx context.Context, nodeIP string) (map[string]string, error)
+	SetLoggingLevel(ctx context.Context, nodeIP, logger, level string) error
 }
 
 func NewClient(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) Nodectl {
@@ -30,7 +51,51 @@ func NewClient(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger
 	}
 }
 
+// JolokiaURL returns the URL of the Jolokia agent of the prober which the JMX requests to the Cassandra nodes are sent through
+func JolokiaURL(cc *v1alpha1.CassandraCluster) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/jolokia", names.ProberService(cc.Name), cc.Namespace, v1alpha1.JolokiaContainerPort)
+}
+
 type client struct {
 	jolokia *jolokia.Client
 	log     *zap.SugaredLogger
 }
+
+// readAttribute reads an attribute of the MBean into value
+func (n *client) readAttribute(ctx context.Context, nodeIP, mbean, attribute string, value interface{}) error {
+	req := jolokia.JMXRequest{
+		Type:       jmxRequestTypeRead,
+		Mbean:      mbean,
+		Attributes: []string{attribute},
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return err
+	}
+
+	attributes := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(resp.Value, &attributes); err != nil {
+		return errors.Wrapf(err, "can't unmarshal attributes, raw body: %s", string(resp.Value))
+	}
+
+	rawValue, exists := attributes[attribute]
+	if !exists {
+		return errors.Errorf("couldn't find %s field, raw response: %s", attribute, string(resp.Value))
+	}
+
+	return json.Unmarshal(rawValue, value)
+}
+
+// exec executes an operation of the MBean
+func (n *client) exec(ctx context.Context, nodeIP, mbean, operation string, args ...interface{}) error {
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbean,
+		Operation: operation,
+		Arguments: args,
+	}
+
+	_, err := n.jolokia.Post(ctx, req, nodeIP)
+	return err
+}
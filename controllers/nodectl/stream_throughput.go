@@ -0,0 +1,21 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// StreamThroughput returns the outbound streaming throughput limit of the node in megabits/s, 0 if streaming is not throttled
+func (n *client) StreamThroughput(ctx context.Context, nodeIP string) (int, error) {
+	var megabitsPerSec int
+	err := n.readAttribute(ctx, nodeIP, mbeanCassandraDBStorageService, "StreamThroughputMbPerSec", &megabitsPerSec)
+	return megabitsPerSec, errors.Wrapf(err, "failed to get stream throughput of node %s", nodeIP)
+}
+
+// SetStreamThroughput limits the outbound streaming throughput of the node. 0 disables throttling.
+func (n *client) SetStreamThroughput(ctx context.Context, nodeIP string, megabitsPerSec int) error {
+	n.log.Infof("Setting stream throughput of node %s to %d megabits/s", nodeIP, megabitsPerSec)
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "setStreamThroughputMbPerSec", megabitsPerSec)
+	return errors.Wrapf(err, "failed to set stream throughput of node %s", nodeIP)
+}
@@ -0,0 +1,21 @@
+package nodectl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// TraceProbability returns the probability a request coordinated by the node is traced
+func (n *client) TraceProbability(ctx context.Context, nodeIP string) (float64, error) {
+	var probability float64
+	err := n.readAttribute(ctx, nodeIP, mbeanCassandraDBStorageService, "TraceProbability", &probability)
+	return probability, errors.Wrapf(err, "failed to get trace probability of node %s", nodeIP)
+}
+
+// SetTraceProbability sets the probability a request coordinated by the node is traced, between 0 and 1
+func (n *client) SetTraceProbability(ctx context.Context, nodeIP string, probability float64) error {
+	n.log.Infof("Setting trace probability of node %s to %g", nodeIP, probability)
+	err := n.exec(ctx, nodeIP, mbeanCassandraDBStorageService, "setTraceProbability", probability)
+	return errors.Wrapf(err, "failed to set trace probability of node %s", nodeIP)
+}
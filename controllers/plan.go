@@ -0,0 +1,404 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	nwv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/compare"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/prober"
+)
+
+const (
+	planActionCreate       = "create"
+	planActionUpdate       = "update"
+	planActionPatch        = "patch"
+	planActionDelete       = "delete"
+	planActionScale        = "scale"
+	planActionDecommission = "decommission"
+
+	planSummaryKey = "summary"
+	// annotationPlanGeneration is the generation of the CassandraCluster the plan was computed for
+	annotationPlanGeneration = "db.ibm.com/plan-generation"
+)
+
+// plannedChange is a change the operator would make to a resource or keyspace
+type plannedChange struct {
+	kind    string
+	name    string
+	action  string
+	diff    string
+	restart bool // the pods of the statefulset would be restarted
+}
+
+func (c plannedChange) String() string {
+	s := fmt.Sprintf("%s %s %s", c.action, c.kind, c.name)
+	if c.restart {
+		s += " (restarts pods)"
+	}
+
+	return s
+}
+
+// planClient records the changes made through it instead of applying them. Reads are served by the wrapped client.
+type planClient struct {
+	client.Client
+	scheme  *runtime.Scheme
+	changes []plannedChange
+}
+
+func (c *planClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.record(obj, planActionCreate, "", false)
+	return nil
+}
+
+func (c *planClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	actual := obj.DeepCopyObject().(client.Object)
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), actual); err != nil {
+		return err
+	}
+
+	var diff string
+	restart := false
+	switch desired := obj.(type) {
+	case *appsv1.StatefulSet:
+		actualSts := actual.(*appsv1.StatefulSet)
+		diff = compare.DiffStatefulSet(actualSts, desired)
+		// the statefulset restarts all pods if the pod template changes
+		heldSts := desired.DeepCopy()
+		heldSts.Spec.Template = actualSts.Spec.Template
+		restart = !compare.EqualStatefulSet(heldSts, desired)
+	case *v1.ConfigMap:
+		// compare.DiffConfigMap leaves the data out to keep it out of the logs
+		actualCM := actual.(*v1.ConfigMap)
+		diff = compare.DiffConfigMap(actualCM, desired) + cmp.Diff(actualCM.Data, desired.Data)
+	case *nwv1.NetworkPolicy:
+		diff = compare.DiffNetworkPolicy(actual.(*nwv1.NetworkPolicy), desired)
+	case *v1.Secret:
+		diff = "the content of secrets is not shown"
+	default:
+		diff = cmp.Diff(actual, obj)
+	}
+
+	c.record(obj, planActionUpdate, diff, restart)
+	return nil
+}
+
+func (c *planClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	var diff string
+	if _, isSecret := obj.(*v1.Secret); isSecret {
+		diff = "the content of secrets is not shown"
+	} else if data, err := patch.Data(obj); err == nil {
+		diff = string(data)
+	}
+
+	c.record(obj, planActionPatch, diff, false)
+	return nil
+}
+
+func (c *planClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.record(obj, planActionDelete, "", false)
+	return nil
+}
+
+func (c *planClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.record(obj, planActionDelete, "", false)
+	return nil
+}
+
+func (c *planClient) Status() client.StatusWriter {
+	return planStatusWriter{}
+}
+
+func (c *planClient) record(obj client.Object, action, diff string, restart bool) {
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, err := apiutil.GVKForObject(obj, c.scheme); err == nil {
+		kind = gvk.Kind
+	}
+
+	c.changes = append(c.changes, plannedChange{
+		kind:    kind,
+		name:    obj.GetName(),
+		action:  action,
+		diff:    diff,
+		restart: restart,
+	})
+}
+
+// planStatusWriter ignores status updates, the plan only shows changes of resources
+type planStatusWriter struct{}
+
+func (planStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return nil
+}
+
+func (planStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
+// reconcilePlan computes the changes the operator would make to apply the spec and writes them to the plan ConfigMap.
+// Nothing else is changed while the cluster has the plan annotation.
+func (r *CassandraClusterReconciler) reconcilePlan(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
+	pc := &planClient{Client: r.Client, scheme: r.Scheme}
+	planner := *r
+	planner.Client = pc
+	planner.Log = zap.NewNop().Sugar()
+	planner.Events = events.NewEventRecorder(&record.FakeRecorder{})
+
+	var planErrors []string
+	addError := func(err error) {
+		if err != nil {
+			planErrors = append(planErrors, err.Error())
+		}
+	}
+
+	restartChecksum := checksumContainer{}
+	addError(planner.reconcileCassandraConfigMap(ctx, cc, restartChecksum))
+	addError(planner.reconcilePrometheusConfigMap(ctx, cc))
+	addError(planner.reconcileCollectdConfigMap(ctx, cc))
+
+	for _, dc := range cc.Spec.DCs {
+		addError(planner.reconcileDCService(ctx, cc, dc))
+		_, err := planner.reconcileDCStatefulSet(ctx, cc, dc, restartChecksum, true)
+		addError(err)
+	}
+
+	scalingChanges, err := r.plannedScalingChanges(ctx, cc)
+	addError(err)
+	pc.changes = append(pc.changes, scalingChanges...)
+
+	proberClient, err := r.planProberClient(ctx, cc)
+	if err != nil {
+		addError(err)
+	} else {
+		addError(r.planNetworkPolicies(ctx, &planner, cc, proberClient))
+
+		keyspaceChanges, err := r.plannedKeyspaceChanges(ctx, cc, proberClient)
+		addError(err)
+		pc.changes = append(pc.changes, keyspaceChanges...)
+	}
+
+	return r.reconcilePlanConfigMap(ctx, cc, pc.changes, planErrors)
+}
+
+func (r *CassandraClusterReconciler) planProberClient(ctx context.Context, cc *dbv1alpha1.CassandraCluster) (prober.ProberClient, error) {
+	adminRoleSecret, err := r.adminRoleSecret(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	role, password, err := extractCredentials(adminRoleSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "admin secret %q is invalid", cc.Spec.AdminRoleSecretName)
+	}
+
+	return r.ProberClient(proberURL(cc), role, password), nil
+}
+
+func (r *CassandraClusterReconciler) planNetworkPolicies(ctx context.Context, planner *CassandraClusterReconciler, cc *dbv1alpha1.CassandraCluster, proberClient prober.ProberClient) error {
+	if !cc.Spec.NetworkPolicies.Enabled {
+		return planner.cleanupNetworkPolicies(ctx, cc)
+	}
+
+	podList, err := r.getCassandraPods(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	fencedBy, err := r.restoreFence(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	return planner.reconcileNetworkPolicies(ctx, cc, proberClient, podList, fencedBy)
+}
+
+// plannedScalingChanges returns the statefulsets that would be scaled or decommissioned. Scaling isn't done by updating
+// the statefulsets directly, so it's not recorded by the plan client.
+func (r *CassandraClusterReconciler) plannedScalingChanges(ctx context.Context, cc *dbv1alpha1.CassandraCluster) ([]plannedChange, error) {
+	stsList := &appsv1.StatefulSetList{}
+	err := r.List(ctx, stsList, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.ComponentLabels(cc, dbv1alpha1.CassandraClusterComponentCassandra)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list statefulsets")
+	}
+
+	desiredReplicas := make(map[string]int32, len(cc.Spec.DCs))
+	for _, dc := range cc.Spec.DCs {
+		desiredReplicas[names.DC(cc.Name, dc.Name)] = *dc.Replicas
+	}
+
+	var changes []plannedChange
+	for _, sts := range stsList.Items {
+		actualReplicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			actualReplicas = *sts.Spec.Replicas
+		}
+
+		replicas, found := desiredReplicas[sts.Name]
+		if !found {
+			changes = append(changes, plannedChange{kind: "StatefulSet", name: sts.Name, action: planActionDecommission})
+		} else if replicas != actualReplicas {
+			changes = append(changes, plannedChange{
+				kind:   "StatefulSet",
+				name:   sts.Name,
+				action: planActionScale,
+				diff:   fmt.Sprintf("replicas: %d -> %d", actualReplicas, replicas),
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// plannedKeyspaceChanges compares the replication of the system keyspaces with the desired one. Requires a ready cluster.
+func (r *CassandraClusterReconciler) plannedKeyspaceChanges(ctx context.Context, cc *dbv1alpha1.CassandraCluster, proberClient prober.ProberClient) ([]plannedChange, error) {
+	if !cc.Status.Ready {
+		return nil, errors.New("keyspaces can't be planned until the cluster is ready")
+	}
+
+	allDCs, err := r.getAllDCs(ctx, cc, proberClient)
+	if err != nil {
+		return nil, err
+	}
+
+	adminRoleSecret, err := r.adminRoleSecret(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	role, password, err := extractCredentials(adminRoleSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "admin secret %q is invalid", cc.Spec.AdminRoleSecretName)
+	}
+
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, role, password, r.Log))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to Cassandra")
+	}
+	defer cqlClient.CloseSession()
+
+	currentKeyspaces, err := cqlClient.GetKeyspacesInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get keyspace info")
+	}
+
+	var changes []plannedChange
+	for _, keyspace := range desiredKeyspacesToReconcile(cc) {
+		keyspaceInfo, found := getKeyspaceByName(currentKeyspaces, string(keyspace))
+		if !found {
+			continue
+		}
+
+		desiredOptions := desiredReplicationOptions(cc, string(keyspace), allDCs)
+		if !cmp.Equal(keyspaceInfo.Replication, desiredOptions) {
+			changes = append(changes, plannedChange{
+				kind:   "Keyspace",
+				name:   string(keyspace),
+				action: planActionUpdate,
+				diff:   cmp.Diff(keyspaceInfo.Replication, desiredOptions),
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func (r *CassandraClusterReconciler) reconcilePlanConfigMap(ctx context.Context, cc *dbv1alpha1.CassandraCluster, changes []plannedChange, planErrors []string) error {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].kind != changes[j].kind {
+			return changes[i].kind < changes[j].kind
+		}
+		return changes[i].name < changes[j].name
+	})
+
+	summary := &strings.Builder{}
+	if len(changes) == 0 {
+		summary.WriteString("No changes\n")
+	}
+
+	data := make(map[string]string)
+	for _, change := range changes {
+		summary.WriteString(change.String() + "\n")
+		if len(change.diff) != 0 {
+			data[fmt.Sprintf("%s.%s.%s", change.action, strings.ToLower(change.kind), change.name)] = truncateDiff(change.diff)
+		}
+	}
+
+	if len(planErrors) != 0 {
+		summary.WriteString("\nThe plan is incomplete:\n")
+		for _, planError := range planErrors {
+			summary.WriteString("- " + planError + "\n")
+		}
+	}
+	data[planSummaryKey] = summary.String()
+
+	desiredCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.PlanConfigMap(cc.Name),
+			Namespace:   cc.Namespace,
+			Labels:      labels.CombinedComponentLabels(cc, dbv1alpha1.CassandraClusterComponentCassandra),
+			Annotations: map[string]string{annotationPlanGeneration: strconv.FormatInt(cc.Generation, 10)},
+		},
+		Data: data,
+	}
+	if err := controllerutil.SetControllerReference(cc, desiredCM, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualCM := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredCM.Name, Namespace: desiredCM.Namespace}, actualCM)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "Could not get %s", desiredCM.Name)
+	}
+
+	if err == nil && compare.EqualConfigMap(actualCM, desiredCM) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Plan for generation %d written to ConfigMap %s: %d changes", cc.Generation, desiredCM.Name, len(changes))
+	r.Log.Info(msg)
+	r.Events.Normal(cc, events.EventPlanUpdated, msg)
+
+	return r.reconcileConfigMap(ctx, desiredCM)
+}
+
+// cleanupPlan removes the plan ConfigMap once the plan annotation is removed, as the plan is outdated
+func (r *CassandraClusterReconciler) cleanupPlan(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
+	planCM := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.PlanConfigMap(cc.Name), Namespace: cc.Namespace}, planCM)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "Could not get %s", names.PlanConfigMap(cc.Name))
+	}
+
+	r.Log.Infof("Removing plan ConfigMap %s", planCM.Name)
+	if err = r.Delete(ctx, planCM); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %s", planCM.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+)
+
+func TestPlanClient(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cassandra-dc1", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: proto.Int32(3),
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "cassandra", Image: "cassandra:3.11.13"}}}},
+		},
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cassandra-config", Namespace: "default"},
+		Data:       map[string]string{"cassandra.yaml": "num_tokens: 256"},
+	}
+	tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(sts, cm).Build()
+	pc := &planClient{Client: tClient, scheme: baseScheme}
+
+	updatedSts := sts.DeepCopy()
+	updatedSts.Spec.Template.Spec.Containers[0].Image = "cassandra:3.11.14"
+	g.Expect(pc.Update(ctx, updatedSts)).To(Succeed())
+
+	updatedCM := cm.DeepCopy()
+	updatedCM.Data["cassandra.yaml"] = "num_tokens: 16"
+	g.Expect(pc.Update(ctx, updatedCM)).To(Succeed())
+
+	g.Expect(pc.Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "new-secret", Namespace: "default"}})).To(Succeed())
+	g.Expect(pc.Delete(ctx, cm)).To(Succeed())
+	g.Expect(pc.Status().Update(ctx, updatedSts)).To(Succeed())
+
+	g.Expect(pc.changes).To(HaveLen(4))
+	g.Expect(pc.changes[0].String()).To(Equal("update StatefulSet test-cassandra-dc1 (restarts pods)"))
+	g.Expect(pc.changes[0].diff).To(ContainSubstring("cassandra:3.11.14"))
+	g.Expect(pc.changes[1].String()).To(Equal("update ConfigMap test-cassandra-config"))
+	g.Expect(pc.changes[1].diff).To(ContainSubstring("num_tokens: 16"))
+	g.Expect(pc.changes[2].String()).To(Equal("create Secret new-secret"))
+	g.Expect(pc.changes[3].String()).To(Equal("delete ConfigMap test-cassandra-config"))
+
+	// nothing is changed
+	actualSts := &appsv1.StatefulSet{}
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: sts.Name, Namespace: sts.Namespace}, actualSts)).To(Succeed())
+	g.Expect(actualSts.Spec.Template.Spec.Containers[0].Image).To(Equal("cassandra:3.11.13"))
+	actualCM := &v1.ConfigMap{}
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, actualCM)).To(Succeed())
+	g.Expect(actualCM.Data).To(Equal(cm.Data))
+}
+
+func TestPlannedScalingChanges(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := baseCC.DeepCopy()
+	cc.Spec.DCs[0].Replicas = proto.Int32(5)
+	cc.Spec.DCs = cc.Spec.DCs[:1]
+	stsLabels := labels.CombinedComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra)
+	dc1 := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace, Labels: stsLabels},
+		Spec:       appsv1.StatefulSetSpec{Replicas: proto.Int32(3)},
+	}
+	dc2 := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: names.DC(cc.Name, "dc2"), Namespace: cc.Namespace, Labels: stsLabels},
+		Spec:       appsv1.StatefulSetSpec{Replicas: proto.Int32(3)},
+	}
+	reconciler := initializeReconciler(cc)
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(dc1, dc2).Build()
+
+	changes, err := reconciler.plannedScalingChanges(context.Background(), cc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changes).To(Equal([]plannedChange{
+		{kind: "StatefulSet", name: "test-cassandra-dc1", action: planActionScale, diff: "replicas: 3 -> 5"},
+		{kind: "StatefulSet", name: "test-cassandra-dc2", action: planActionDecommission},
+	}))
+}
+
+func TestReconcilePlanConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	cc := baseCC.DeepCopy()
+	reconciler := initializeReconciler(cc)
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc).Build()
+
+	changes := []plannedChange{
+		{kind: "StatefulSet", name: "test-cassandra-dc1", action: planActionUpdate, diff: "image", restart: true},
+		{kind: "ConfigMap", name: "test-cassandra-config", action: planActionUpdate, diff: "num_tokens"},
+		{kind: "NetworkPolicy", name: "test-cassandra-policy", action: planActionCreate},
+	}
+	g.Expect(reconciler.reconcilePlanConfigMap(ctx, cc, changes, []string{"keyspaces can't be planned until the cluster is ready"})).To(Succeed())
+
+	planCM := &v1.ConfigMap{}
+	g.Expect(reconciler.Get(ctx, types.NamespacedName{Name: names.PlanConfigMap(cc.Name), Namespace: cc.Namespace}, planCM)).To(Succeed())
+	g.Expect(planCM.Data).To(Equal(map[string]string{
+		planSummaryKey: "update ConfigMap test-cassandra-config\n" +
+			"create NetworkPolicy test-cassandra-policy\n" +
+			"update StatefulSet test-cassandra-dc1 (restarts pods)\n" +
+			"\nThe plan is incomplete:\n" +
+			"- keyspaces can't be planned until the cluster is ready\n",
+		"update.configmap.test-cassandra-config": "num_tokens",
+		"update.statefulset.test-cassandra-dc1":  "image",
+	}))
+
+	g.Expect(reconciler.cleanupPlan(ctx, cc)).To(Succeed())
+	g.Expect(reconciler.Get(ctx, types.NamespacedName{Name: names.PlanConfigMap(cc.Name), Namespace: cc.Namespace}, planCM)).ToNot(Succeed())
+}
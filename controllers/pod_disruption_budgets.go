@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/compare"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+)
+
+// reconcilePodDisruptionBudgets creates a PodDisruptionBudget per DC, so that evictions never take down more than
+// maxUnavailable pods of a DC, whatever racks they are in. PodDisruptionBudgets of removed DCs are deleted.
+func (r *CassandraClusterReconciler) reconcilePodDisruptionBudgets(ctx context.Context, cc *v1alpha1.CassandraCluster) error {
+	desiredPDBs := make(map[string]*policyv1.PodDisruptionBudget)
+	if *cc.Spec.PodDisruptionBudget.Enabled {
+		for _, dc := range cc.Spec.DCs {
+			pdb := desiredPodDisruptionBudget(cc, dc)
+			desiredPDBs[pdb.Name] = pdb
+		}
+	}
+
+	actualPDBs := &policyv1.PodDisruptionBudgetList{}
+	err := r.List(ctx, actualPDBs, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.ComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra)))
+	if err != nil {
+		return errors.Wrap(err, "failed to list pod disruption budgets")
+	}
+
+	// the stale budgets are deleted first as evictions fail for pods covered by more than one budget
+	for i, pdb := range actualPDBs.Items {
+		if _, desired := desiredPDBs[pdb.Name]; desired {
+			continue
+		}
+
+		r.Log.Infof("Deleting pod disruption budget %s", pdb.Name)
+		if err = r.Delete(ctx, &actualPDBs.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete pod disruption budget %s", pdb.Name)
+		}
+	}
+
+	pdbNames := make([]string, 0, len(desiredPDBs))
+	for name := range desiredPDBs {
+		pdbNames = append(pdbNames, name)
+	}
+	sort.Strings(pdbNames)
+
+	for _, name := range pdbNames {
+		if err = r.reconcilePodDisruptionBudget(ctx, cc, desiredPDBs[name]); err != nil {
+			return errors.Wrapf(err, "failed to reconcile pod disruption budget %s", name)
+		}
+	}
+
+	return nil
+}
+
+func desiredPodDisruptionBudget(cc *v1alpha1.CassandraCluster, dc v1alpha1.DC) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.PodDisruptionBudget(cc.Name, dc.Name),
+			Namespace: cc.Namespace,
+			Labels:    labels.WithDCLabel(labels.CombinedComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra), dc.Name),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: cc.Spec.PodDisruptionBudget.MaxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: labels.WithDCLabel(labels.Cassandra(cc), dc.Name)},
+		},
+	}
+}
+
+func (r *CassandraClusterReconciler) reconcilePodDisruptionBudget(ctx context.Context, cc *v1alpha1.CassandraCluster, desiredPDB *policyv1.PodDisruptionBudget) error {
+	if err := controllerutil.SetControllerReference(cc, desiredPDB, r.Scheme); err != nil {
+		return err
+	}
+
+	actualPDB := &policyv1.PodDisruptionBudget{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredPDB.Name, Namespace: desiredPDB.Namespace}, actualPDB)
+	if err != nil && apierrors.IsNotFound(err) {
+		r.Log.Infof("Creating pod disruption budget %s", desiredPDB.Name)
+		return r.Create(ctx, desiredPDB)
+	} else if err != nil {
+		return err
+	}
+
+	if !compare.EqualPodDisruptionBudget(actualPDB, desiredPDB) {
+		r.Log.Infof("Updating pod disruption budget %s", desiredPDB.Name)
+		r.Log.Debug(compare.DiffPodDisruptionBudget(actualPDB, desiredPDB))
+		actualPDB.Spec = desiredPDB.Spec
+		actualPDB.Labels = desiredPDB.Labels
+		actualPDB.OwnerReferences = desiredPDB.OwnerReferences
+		return r.Update(ctx, actualPDB)
+	}
+
+	r.Log.Debugf("No updates to pod disruption budget %s", desiredPDB.Name)
+	return nil
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+)
+
+func TestReconcilePodDisruptionBudgets(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+
+	maxUnavailable := intstr.FromInt(1)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs:       []v1alpha1.DC{{Name: "dc1", Replicas: proto.Int32(3)}, {Name: "dc2", Replicas: proto.Int32(2)}},
+			Cassandra: &v1alpha1.Cassandra{},
+			PodDisruptionBudget: v1alpha1.PodDisruptionBudget{
+				Enabled:        proto.Bool(true),
+				MaxUnavailable: &maxUnavailable,
+			},
+		},
+	}
+	// a budget per rack created by an earlier version of the operator
+	rackPDB := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cassandra-dc1-zone-a",
+			Namespace: "default",
+			Labels:    labels.WithDCLabel(labels.CombinedComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra), "dc1"),
+		},
+	}
+	tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc, rackPDB).Build()
+	reconciler := &CassandraClusterReconciler{
+		Client: tClient,
+		Scheme: baseScheme,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		Log:    zap.NewNop().Sugar(),
+	}
+
+	pdbs := func() map[string]policyv1.PodDisruptionBudgetSpec {
+		pdbList := &policyv1.PodDisruptionBudgetList{}
+		g.Expect(tClient.List(ctx, pdbList, client.InNamespace("default"))).To(Succeed())
+		specs := make(map[string]policyv1.PodDisruptionBudgetSpec)
+		for _, pdb := range pdbList.Items {
+			specs[pdb.Name] = pdb.Spec
+		}
+		return specs
+	}
+
+	// a budget covers all pods of the DC, even if zones are used as racks
+	cc.Spec.Cassandra.ZonesAsRacks = true
+	g.Expect(reconciler.reconcilePodDisruptionBudgets(ctx, cc)).To(Succeed())
+	g.Expect(pdbs()).To(Equal(map[string]policyv1.PodDisruptionBudgetSpec{
+		"test-cassandra-dc1": {
+			MaxUnavailable: &maxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: labels.WithDCLabel(labels.Cassandra(cc), "dc1")},
+		},
+		"test-cassandra-dc2": {
+			MaxUnavailable: &maxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: labels.WithDCLabel(labels.Cassandra(cc), "dc2")},
+		},
+	}))
+
+	// the budget of a removed DC is deleted
+	cc.Spec.DCs = cc.Spec.DCs[:1]
+	g.Expect(reconciler.reconcilePodDisruptionBudgets(ctx, cc)).To(Succeed())
+	g.Expect(pdbs()).To(HaveLen(1))
+	g.Expect(pdbs()).To(HaveKey("test-cassandra-dc1"))
+
+	cc.Spec.PodDisruptionBudget.Enabled = proto.Bool(false)
+	g.Expect(reconciler.reconcilePodDisruptionBudgets(ctx, cc)).To(Succeed())
+	g.Expect(pdbs()).To(BeEmpty())
+}
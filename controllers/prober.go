@@ -34,6 +34,10 @@ func (r *CassandraClusterReconciler) reconcileProber(ctx context.Context, cc *db
 		return errors.Wrap(err, "Error reconciling prober rolebinding")
 	}
 
+	if err := r.reconcileProberStateConfigMap(ctx, cc); err != nil {
+		return errors.Wrap(err, "failed to reconcile prober state configmap")
+	}
+
 	if err := r.reconcileProberDeployment(ctx, cc); err != nil {
 		return errors.Wrap(err, "failed to reconcile prober deployment")
 	}
@@ -74,7 +78,7 @@ func (r *CassandraClusterReconciler) reconcileProberDeployment(ctx context.Conte
 			Labels:    labels.CombinedComponentLabels(cc, dbv1alpha1.CassandraClusterComponentProber),
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: proto.Int32(1),
+			Replicas: proto.Int32(cc.Spec.Prober.Replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: proberLabels,
 			},
@@ -104,7 +108,7 @@ func (r *CassandraClusterReconciler) reconcileProberDeployment(ctx context.Conte
 					ImagePullSecrets:              imagePullSecrets(cc),
 					Tolerations:                   cc.Spec.Prober.Tolerations,
 					NodeSelector:                  cc.Spec.Prober.NodeSelector,
-					Affinity:                      cc.Spec.Prober.Affinity,
+					Affinity:                      proberAffinity(cc, proberLabels),
 				},
 			},
 		},
@@ -167,6 +171,34 @@ func (r *CassandraClusterReconciler) reconcileProberDeployment(ctx context.Conte
 	return nil
 }
 
+// reconcileProberStateConfigMap creates the ConfigMap used by prober replicas to share the region state.
+// The data is owned by the prober, so the operator never overwrites it.
+func (r *CassandraClusterReconciler) reconcileProberStateConfigMap(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
+	desiredCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.ProberStateConfigMap(cc.Name),
+			Namespace: cc.Namespace,
+			Labels:    labels.CombinedComponentLabels(cc, dbv1alpha1.CassandraClusterComponentProber),
+		},
+		Data: map[string]string{},
+	}
+	if err := controllerutil.SetControllerReference(cc, desiredCM, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualCM := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredCM.Name, Namespace: desiredCM.Namespace}, actualCM)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Infof("Creating %s", desiredCM.Name)
+			return r.Create(ctx, desiredCM)
+		}
+		return errors.Wrapf(err, "Could not get %s", desiredCM.Name)
+	}
+
+	return nil
+}
+
 func (r *CassandraClusterReconciler) reconcileProberService(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
 	desiredService := &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -233,6 +265,29 @@ func (r *CassandraClusterReconciler) reconcileProberService(ctx context.Context,
 	return nil
 }
 
+// proberAffinity spreads prober replicas across nodes unless the user provided own affinity rules
+func proberAffinity(cc *dbv1alpha1.CassandraCluster, proberLabels map[string]string) *v1.Affinity {
+	if cc.Spec.Prober.Affinity != nil || cc.Spec.Prober.Replicas < 2 {
+		return cc.Spec.Prober.Affinity
+	}
+
+	return &v1.Affinity{
+		PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: v1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: proberLabels,
+						},
+						TopologyKey: v1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 func proberContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 	adminSecret := names.ActiveAdminSecret(cc.Name)
 	if cc.Spec.JMXAuth == jmxAuthenticationLocalFiles {
@@ -253,6 +308,7 @@ func proberContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 			{Name: "JMX_PORT", Value: fmt.Sprintf("%d", dbv1alpha1.JmxPort)},
 			{Name: "ADMIN_SECRET_NAME", Value: adminSecret},
 			{Name: "BASE_ADMIN_SECRET_NAME", Value: cc.Spec.AdminRoleSecretName},
+			{Name: "STATE_CONFIGMAP_NAME", Value: names.ProberStateConfigMap(cc.Name)},
 		},
 		Ports: []v1.ContainerPort{
 			{
@@ -334,8 +390,3 @@ func proberURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
 	proberUrl, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local", names.ProberService(cc.Name), cc.Namespace))
 	return proberUrl
 }
-
-func jolokiaURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
-	jURL, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/jolokia", names.ProberService(cc.Name), cc.Namespace, dbv1alpha1.JolokiaContainerPort))
-	return jURL
-}
@@ -31,6 +31,11 @@ func (r *CassandraClusterReconciler) reconcileProberRole(ctx context.Context, cc
 				Resources: []string{"secrets"},
 				Verbs:     []string{"get", "list", "watch"},
 			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "list", "watch", "update"},
+			},
 		},
 	}
 
@@ -17,6 +17,9 @@ const (
 	RepairStateRunning = "RUNNING"
 	RepairStatePaused  = "PAUSED"
 
+	RepairScheduleStateActive = "ACTIVE"
+	RepairScheduleStatePaused = "PAUSED"
+
 	OwnerCassandraOperator = "cassandra-operator"
 )
 
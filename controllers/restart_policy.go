@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+const (
+	// annotationRestartChecksums stores the checksums of the configs applied to the pod template of the statefulset
+	annotationRestartChecksums = "cassandra-cluster-restart-checksums"
+	// annotationCommitLogRestorePointInTime stores the point in time of the commit log replay applied to the pod template of the statefulset
+	annotationCommitLogRestorePointInTime = "cassandra-cluster-commitlog-restore-point-in-time"
+	// the status of the cluster can't grow indefinitely
+	maxPendingRestartDiffLength = 8 * 1024
+)
+
+// checksums returns the checksum of each config as JSON
+func (c checksumContainer) checksums() string {
+	checksums := make(map[string]string, len(c))
+	for name, value := range c {
+		checksums[name] = util.Sha1(value)
+	}
+
+	b, _ := json.Marshal(checksums) // keys are sorted
+	return string(b)
+}
+
+// changedChecksums returns the names of the configs whose checksums differ
+func changedChecksums(actual, desired string) []string {
+	actualChecksums := map[string]string{}
+	desiredChecksums := map[string]string{}
+	_ = json.Unmarshal([]byte(actual), &actualChecksums)
+	_ = json.Unmarshal([]byte(desired), &desiredChecksums)
+
+	var changes []string
+	for name, checksum := range desiredChecksums {
+		if actualChecksums[name] != checksum {
+			changes = append(changes, name)
+		}
+	}
+	for name := range actualChecksums {
+		if _, found := desiredChecksums[name]; !found {
+			changes = append(changes, name)
+		}
+	}
+	sort.Strings(changes)
+
+	return changes
+}
+
+func truncateDiff(diff string) string {
+	if len(diff) <= maxPendingRestartDiffLength {
+		return diff
+	}
+
+	return diff[:maxPendingRestartDiffLength] + "\n... (truncated)"
+}
+
+// restartAllowed returns true if changes restarting the Cassandra pods can be rolled out according to the restart policy
+func restartAllowed(cc *dbv1alpha1.CassandraCluster, now time.Time) bool {
+	policy := cc.Spec.RestartPolicy
+	if policy == nil || policy.Type == dbv1alpha1.RestartPolicyImmediate || len(policy.Type) == 0 {
+		return true
+	}
+
+	pending := cc.Status.PendingRestart
+	if pending != nil && policy.ApprovedAt != nil && !policy.ApprovedAt.Before(&pending.Since) {
+		return true
+	}
+
+	if policy.Type == dbv1alpha1.RestartPolicyMaintenanceWindow {
+		inWindow, _ := inMaintenanceWindow(cc.Spec.MaintenanceWindows, now)
+		return inWindow
+	}
+
+	return false
+}
+
+// operatorRestart returns true if the operator itself changes the pod template of the statefulset, i.e. a commit log
+// replay of a point-in-time restore starts or ends. Such changes are rolled out regardless of the restart policy,
+// otherwise the restore would wait forever.
+func operatorRestart(cc *dbv1alpha1.CassandraCluster, actualSts *appsv1.StatefulSet) bool {
+	return actualSts.Annotations[annotationCommitLogRestorePointInTime] != cc.Annotations[dbv1alpha1.CommitLogRestorePointInTimeAnnotation]
+}
+
+// statefulSetAnnotations returns the annotations recording the configs applied to the pod template of the statefulset
+func statefulSetAnnotations(cc *dbv1alpha1.CassandraCluster, annotations map[string]string, restartChecksums string) map[string]string {
+	annotations = util.MergeMap(annotations, map[string]string{annotationRestartChecksums: restartChecksums})
+	delete(annotations, annotationCommitLogRestorePointInTime)
+	if pointInTime := cc.Annotations[dbv1alpha1.CommitLogRestorePointInTimeAnnotation]; len(pointInTime) != 0 {
+		annotations[annotationCommitLogRestorePointInTime] = pointInTime
+	}
+
+	return annotations
+}
+
+// reconcilePendingRestart shows the changes held back by the restart policy in the status
+func (r *CassandraClusterReconciler) reconcilePendingRestart(ctx context.Context, cc *dbv1alpha1.CassandraCluster, pendingDCs []dbv1alpha1.PendingRestartDC, now time.Time) error {
+	var pending *dbv1alpha1.PendingRestart
+	if len(pendingDCs) != 0 {
+		pending = &dbv1alpha1.PendingRestart{
+			Since: metav1.Time{Time: now.Truncate(time.Second)},
+			DCs:   pendingDCs,
+		}
+		if cc.Status.PendingRestart != nil {
+			pending.Since = cc.Status.PendingRestart.Since
+		}
+
+		if cc.Spec.RestartPolicy.Type == dbv1alpha1.RestartPolicyMaintenanceWindow {
+			if _, nextStart := inMaintenanceWindow(cc.Spec.MaintenanceWindows, now); !nextStart.IsZero() {
+				pending.EarliestStartTime = &metav1.Time{Time: nextStart}
+			}
+		}
+	}
+
+	if cmp.Equal(cc.Status.PendingRestart, pending) {
+		return nil
+	}
+
+	if cc.Status.PendingRestart == nil {
+		dcNames := make([]string, 0, len(pendingDCs))
+		for _, dc := range pendingDCs {
+			dcNames = append(dcNames, dc.Name)
+		}
+		msg := fmt.Sprintf("Restart of DCs %s is held back by the %s restart policy", strings.Join(dcNames, ", "), cc.Spec.RestartPolicy.Type)
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventRestartPending, msg)
+	} else if pending == nil {
+		msg := "Rolling out the pending restart"
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventPendingRestartApplied, msg)
+	}
+
+	ccStatus := cc.DeepCopy()
+	ccStatus.Status.PendingRestart = pending
+	if err := r.Status().Update(ctx, ccStatus); err != nil {
+		return errors.Wrap(err, "failed to update pending restart status")
+	}
+
+	cc.Status.PendingRestart = pending
+	cc.ResourceVersion = ccStatus.ResourceVersion
+	return nil
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+func TestRestartAllowed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2022, 10, 22, 1, 0, 0, 0, time.UTC)
+	cc := &v1alpha1.CassandraCluster{}
+	g.Expect(restartAllowed(cc, now)).To(BeTrue())
+
+	cc.Spec.RestartPolicy = &v1alpha1.RestartPolicy{Type: v1alpha1.RestartPolicyManual}
+	g.Expect(restartAllowed(cc, now)).To(BeFalse())
+
+	cc.Status.PendingRestart = &v1alpha1.PendingRestart{Since: metav1.Time{Time: now.Add(-time.Hour)}}
+	cc.Spec.RestartPolicy.ApprovedAt = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+	g.Expect(restartAllowed(cc, now)).To(BeFalse())
+
+	cc.Spec.RestartPolicy.ApprovedAt = &metav1.Time{Time: now}
+	g.Expect(restartAllowed(cc, now)).To(BeTrue())
+
+	cc.Spec.RestartPolicy = &v1alpha1.RestartPolicy{Type: v1alpha1.RestartPolicyMaintenanceWindow}
+	cc.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}}
+	g.Expect(restartAllowed(cc, now)).To(BeFalse())
+	g.Expect(restartAllowed(cc, now.Add(90*time.Minute))).To(BeTrue())
+}
+
+func TestChangedChecksums(t *testing.T) {
+	g := NewGomegaWithT(t)
+	actual := checksumContainer{"cassandra.yaml": "a", "jvm.options": "b", "client-node-tls-secret": "c"}.checksums()
+	desired := checksumContainer{"cassandra.yaml": "a", "jvm.options": "changed", "cluster-node-tls-secret": "d"}.checksums()
+
+	g.Expect(changedChecksums(actual, desired)).To(Equal([]string{"client-node-tls-secret", "cluster-node-tls-secret", "jvm.options"}))
+	g.Expect(changedChecksums(actual, actual)).To(BeEmpty())
+	g.Expect(changedChecksums("", desired)).To(HaveLen(3))
+}
+
+func TestOperatorRestart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{}
+	sts := &appsv1.StatefulSet{}
+	sts.Annotations = statefulSetAnnotations(cc, nil, "{}")
+	g.Expect(operatorRestart(cc, sts)).To(BeFalse())
+	g.Expect(sts.Annotations).To(Equal(map[string]string{annotationRestartChecksums: "{}"}))
+
+	cc.Annotations = map[string]string{v1alpha1.CommitLogRestorePointInTimeAnnotation: "2022:10:22 01:00:00"}
+	g.Expect(operatorRestart(cc, sts)).To(BeTrue())
+
+	sts.Annotations = statefulSetAnnotations(cc, sts.Annotations, "{}")
+	g.Expect(operatorRestart(cc, sts)).To(BeFalse())
+	g.Expect(sts.Annotations).To(HaveKeyWithValue(annotationCommitLogRestorePointInTime, "2022:10:22 01:00:00"))
+
+	cc.Annotations = nil
+	g.Expect(operatorRestart(cc, sts)).To(BeTrue())
+
+	sts.Annotations = statefulSetAnnotations(cc, sts.Annotations, "{}")
+	g.Expect(operatorRestart(cc, sts)).To(BeFalse())
+	g.Expect(sts.Annotations).NotTo(HaveKey(annotationCommitLogRestorePointInTime))
+}
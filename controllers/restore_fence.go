@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restoreFence returns the name of the CassandraRestore that fences the cluster or an empty string if the cluster is not fenced.
+// The fence is set by the CassandraRestore controller and holds only while the restore is running.
+func (r *CassandraClusterReconciler) restoreFence(ctx context.Context, cc *v1alpha1.CassandraCluster) (string, error) {
+	restoreName := cc.Annotations[v1alpha1.RestoreFenceAnnotation]
+	if len(restoreName) == 0 {
+		return "", nil
+	}
+
+	cr := &v1alpha1.CassandraRestore{}
+	err := r.Get(ctx, types.NamespacedName{Name: restoreName, Namespace: cc.Namespace}, cr)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get restore %s", restoreName)
+	}
+
+	if icarus.Finished(cr.Status.State) {
+		return "", nil
+	}
+
+	return restoreName, nil
+}
+
+// reconcileRestoreFence pauses the active repair schedules created by the operator while the cluster is fenced by a restore.
+// The paused schedules are recorded on the cluster, so that only those are resumed once the restore is finished.
+// Schedules paused by the user before the fence stay paused. The fence annotations are removed afterwards.
+func (r *CassandraClusterReconciler) reconcileRestoreFence(ctx context.Context, cc *v1alpha1.CassandraCluster, reaperClient reaper.ReaperClient, fencedBy string) error {
+	restoreName, annotated := cc.Annotations[v1alpha1.RestoreFenceAnnotation]
+	if !annotated {
+		return nil
+	}
+
+	repairSchedules, err := reaperClient.RepairSchedules(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair schedules")
+	}
+
+	var pausedIDs []string
+	if value := cc.Annotations[v1alpha1.RestoreFencePausedRepairSchedulesAnnotation]; len(value) != 0 {
+		pausedIDs = strings.Split(value, ",")
+	}
+
+	if len(fencedBy) != 0 {
+		var toPause []reaper.RepairSchedule
+		for _, schedule := range filterOperatorRepairSchedules(repairSchedules) {
+			if schedule.State != reaper.RepairScheduleStatePaused {
+				toPause = append(toPause, schedule)
+				if !util.Contains(pausedIDs, schedule.ID) {
+					pausedIDs = append(pausedIDs, schedule.ID)
+				}
+			}
+		}
+
+		if len(toPause) == 0 {
+			return nil
+		}
+
+		// the schedules are recorded before they're paused, so that none is left paused if the reconcile fails in between
+		patch := client.MergeFrom(cc.DeepCopy())
+		cc.Annotations[v1alpha1.RestoreFencePausedRepairSchedulesAnnotation] = strings.Join(pausedIDs, ",")
+		if err = r.Patch(ctx, cc, patch); err != nil {
+			return errors.Wrap(err, "failed to record the paused repair schedules")
+		}
+
+		for _, schedule := range toPause {
+			r.Log.Infof("Pausing repair schedule for keyspace %s while restore %s is running", schedule.KeyspaceName, fencedBy)
+			if err = reaperClient.SetRepairScheduleState(ctx, schedule.ID, false); err != nil {
+				return errors.Wrapf(err, "failed to pause the repair schedule for keyspace %s", schedule.KeyspaceName)
+			}
+		}
+
+		return nil
+	}
+
+	for _, schedule := range filterOperatorRepairSchedules(repairSchedules) {
+		if schedule.State != reaper.RepairScheduleStatePaused || !util.Contains(pausedIDs, schedule.ID) {
+			continue
+		}
+
+		r.Log.Infof("Resuming repair schedule for keyspace %s", schedule.KeyspaceName)
+		if err = reaperClient.SetRepairScheduleState(ctx, schedule.ID, true); err != nil {
+			return errors.Wrapf(err, "failed to resume the repair schedule for keyspace %s", schedule.KeyspaceName)
+		}
+	}
+
+	patch := client.MergeFrom(cc.DeepCopy())
+	delete(cc.Annotations, v1alpha1.RestoreFenceAnnotation)
+	delete(cc.Annotations, v1alpha1.RestoreFencePausedRepairSchedulesAnnotation)
+	err = r.Patch(ctx, cc, patch)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove the restore fence")
+	}
+
+	msg := fmt.Sprintf("Restore %s is not running anymore, the cluster is not fenced", restoreName)
+	r.Log.Info(msg)
+	r.Events.Normal(cc, events.EventClusterUnfenced, msg)
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 
 func (r *CassandraClusterReconciler) reconcileAdminRole(ctx context.Context, cc *dbv1alpha1.CassandraCluster, auth credentials, allDCs []dbv1alpha1.DC) (cql.CqlClient, error) {
 	r.Log.Debug("Establishing cql session with role " + auth.desiredRole)
-	cqlClient, err := r.CqlClient(newCassandraConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
 	if err == nil { // operator admin role exists
 		if err = r.reconcileSystemAuthKeyspace(ctx, cc, cqlClient, allDCs); err != nil {
 			return nil, err
@@ -26,7 +26,7 @@ func (r *CassandraClusterReconciler) reconcileAdminRole(ctx context.Context, cc
 		return cqlClient, nil
 	}
 
-	defaultUserCQLClient, err := r.CqlClient(newCassandraConfig(cc, dbv1alpha1.CassandraDefaultRole, dbv1alpha1.CassandraDefaultPassword, r.Log))
+	defaultUserCQLClient, err := r.CqlClient(cql.NewClusterConfig(cc, dbv1alpha1.CassandraDefaultRole, dbv1alpha1.CassandraDefaultPassword, r.Log))
 	if err != nil {
 		return nil, errors.Wrap(err, "can't establish cql connection both with default and desired admin roles")
 	}
@@ -40,7 +40,7 @@ func (r *CassandraClusterReconciler) reconcileAdminRole(ctx context.Context, cc
 
 	r.Log.Debug("Establishing cql session with role " + auth.desiredRole)
 	err = r.doWithRetry(func() error {
-		cqlClient, err = r.CqlClient(newCassandraConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
+		cqlClient, err = r.CqlClient(cql.NewClusterConfig(cc, auth.desiredRole, auth.desiredPassword, r.Log))
 		if err != nil {
 			return err
 		}
@@ -60,7 +60,7 @@ func (r *CassandraClusterReconciler) reconcileAdminRole(ctx context.Context, cc
 
 func (r *CassandraClusterReconciler) createAdminRoleInCassandra(ctx context.Context, cc *dbv1alpha1.CassandraCluster, roleName, password string, allDCs []dbv1alpha1.DC) error {
 	r.Log.Info("Establishing cql session with role " + dbv1alpha1.CassandraDefaultRole)
-	cqlClient, err := r.CqlClient(newCassandraConfig(cc, dbv1alpha1.CassandraDefaultRole, dbv1alpha1.CassandraDefaultPassword, r.Log))
+	cqlClient, err := r.CqlClient(cql.NewClusterConfig(cc, dbv1alpha1.CassandraDefaultRole, dbv1alpha1.CassandraDefaultPassword, r.Log))
 	if err != nil {
 		return errors.Wrap(err, "Can't create cql session with role "+dbv1alpha1.CassandraDefaultRole)
 	}
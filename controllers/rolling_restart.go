@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+// reconcileRollingRestart restarts the pods requested by spec.rollingRestart one at a time. It runs only once the cluster is ready,
+// so that the next pod is not restarted until the previous one has been recreated and all DCs report their pods ready.
+// Returns true if a pod is being restarted, in which case the reconcile should be requeued.
+func (r *CassandraClusterReconciler) reconcileRollingRestart(ctx context.Context, cc *v1alpha1.CassandraCluster, podList *v1.PodList, auth credentials) (bool, error) {
+	if cc.Spec.RollingRestart == nil {
+		return false, nil
+	}
+
+	status := cc.Status.RollingRestart.DeepCopy()
+	now := metav1.Time{Time: time.Now().Truncate(time.Second)}
+	if status == nil || status.RequestedAt.Before(&cc.Spec.RollingRestart.RequestedAt) {
+		status = &v1alpha1.RollingRestartStatus{
+			RequestedAt: cc.Spec.RollingRestart.RequestedAt,
+			State:       v1alpha1.RollingRestartStateRunning,
+			PendingPods: rollingRestartPods(cc, podList.Items),
+			StartTime:   &now,
+		}
+		msg := fmt.Sprintf("Starting rolling restart of pods %s", strings.Join(status.PendingPods, ", "))
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventRollingRestartStarted, msg)
+	}
+
+	if status.State == v1alpha1.RollingRestartStateCompleted {
+		return false, nil
+	}
+
+	if len(status.CurrentPod) != 0 {
+		pod := findPod(podList.Items, status.CurrentPod)
+		if pod != nil && !podReady(*pod) {
+			r.Log.Infof("Waiting for pod %s to become ready", pod.Name)
+			return true, nil
+		}
+
+		status.RestartedPods = append(status.RestartedPods, status.CurrentPod)
+		status.CurrentPod = ""
+		status.CurrentPodRestartTime = nil
+	}
+
+	if len(status.PendingPods) != 0 {
+		allowed, err := r.maintenanceAllowed(ctx, cc, v1alpha1.QueuedOperationRestart, status.PendingPods[0])
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			return false, r.updateRollingRestartStatus(ctx, cc, status)
+		}
+	}
+
+	for len(status.PendingPods) != 0 {
+		podName := status.PendingPods[0]
+		status.PendingPods = status.PendingPods[1:]
+		pod := findPod(podList.Items, podName)
+		if pod == nil {
+			r.Log.Infof("Pod %s doesn't exist anymore, skipping its restart", podName)
+			continue
+		}
+
+		// the status is updated before the pod is deleted, so that a new pod is not restarted again if the update fails
+		status.CurrentPod = podName
+		status.CurrentPodRestartTime = &now
+		if err := r.updateRollingRestartStatus(ctx, cc, status); err != nil {
+			return false, err
+		}
+
+		r.Events.Normal(cc, events.EventPodRestarted, fmt.Sprintf("Restarting pod %s", podName))
+		return true, r.restartPod(ctx, cc, pod, auth)
+	}
+
+	status.State = v1alpha1.RollingRestartStateCompleted
+	status.CompletionTime = &now
+	msg := fmt.Sprintf("Rolling restart completed. Restarted pods: %s", strings.Join(status.RestartedPods, ", "))
+	r.Log.Info(msg)
+	r.Events.Normal(cc, events.EventRollingRestartCompleted, msg)
+
+	return false, r.updateRollingRestartStatus(ctx, cc, status)
+}
+
+// reconcileRestartingPod finishes the restart of the current pod of a rolling restart, i.e. deletes the pod once its node is shut down.
+// It doesn't wait for the cluster to be ready, as the node is reported unready as soon as it's drained.
+// Returns true if the pod is not deleted yet, in which case the reconcile should be requeued.
+func (r *CassandraClusterReconciler) reconcileRestartingPod(ctx context.Context, cc *v1alpha1.CassandraCluster, podList *v1.PodList, auth credentials) (bool, error) {
+	status := cc.Status.RollingRestart
+	if status == nil || status.State != v1alpha1.RollingRestartStateRunning || len(status.CurrentPod) == 0 {
+		return false, nil
+	}
+
+	pod := findPod(podList.Items, status.CurrentPod)
+	if pod == nil || !pod.CreationTimestamp.Before(status.CurrentPodRestartTime) { // the pod is already recreated
+		return false, nil
+	}
+
+	if pod.DeletionTimestamp != nil {
+		r.Log.Infof("Waiting for pod %s to terminate", pod.Name)
+		return true, nil
+	}
+
+	// the node is being shut down or the previous deletion attempt failed
+	return true, r.restartPod(ctx, cc, pod, auth)
+}
+
+// restartPod shuts the Cassandra node down in a job and deletes its pod to be recreated by the statefulset once the job finished
+func (r *CassandraClusterReconciler) restartPod(ctx context.Context, cc *v1alpha1.CassandraCluster, pod *v1.Pod, auth credentials) error {
+	jobName := names.PodShutdownJob(pod.Name)
+	if r.Jobs.IsRunning(jobName) {
+		r.Log.Infof("Shutdown of node %s in progress, waiting to finish", pod.Name)
+		return nil
+	}
+
+	if !r.Jobs.Exists(jobName) {
+		nctl := r.NodectlClient(nodectl.JolokiaURL(cc), auth.activeRole, auth.activePassword, r.Log)
+		podName, podIP := pod.Name, pod.Status.PodIP
+		err := r.Jobs.Run(jobName, cc, func() error {
+			shutdownCtx := context.Background() // reconcile context may cancel the job sooner than needed
+			r.shutdownNode(shutdownCtx, cc, nctl, podName, podIP)
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to start job to shut down node %s", pod.Name)
+		}
+
+		return nil
+	}
+
+	r.Log.Infof("Deleting pod %s", pod.Name)
+	if err := r.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete pod %s", pod.Name)
+	}
+
+	if err := r.Jobs.RemoveJob(jobName); err != nil {
+		return errors.Wrap(err, "can't remove job")
+	}
+
+	return nil
+}
+
+// shutdownNode disables the native transport and gossip of the node before draining it, so that clients and the other nodes
+// stop sending requests to it before the pod is deleted. Failed steps are reported, but don't prevent the pod from being deleted.
+func (r *CassandraClusterReconciler) shutdownNode(ctx context.Context, cc *v1alpha1.CassandraCluster, nctl nodectl.Nodectl, podName, podIP string) {
+	steps := []func(ctx context.Context, nodeIP string) error{
+		nctl.DisableBinary,
+		nctl.DisableGossip,
+		nctl.Drain,
+	}
+
+	r.Log.Infof("Shutting down node %s", podName)
+	for _, step := range steps {
+		if err := step(ctx, podIP); err != nil {
+			errMsg := fmt.Sprintf("Node %s didn't shut down cleanly: %s", podName, err.Error())
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cc, events.EventNodeShutdownFailed, errMsg)
+		}
+	}
+}
+
+func (r *CassandraClusterReconciler) updateRollingRestartStatus(ctx context.Context, cc *v1alpha1.CassandraCluster, status *v1alpha1.RollingRestartStatus) error {
+	if cmp.Equal(cc.Status.RollingRestart, status) {
+		return nil
+	}
+
+	ccStatus := cc.DeepCopy()
+	ccStatus.Status.RollingRestart = status
+	if err := r.Status().Update(ctx, ccStatus); err != nil {
+		return errors.Wrap(err, "failed to update rolling restart status")
+	}
+
+	cc.Status.RollingRestart = status
+	cc.ResourceVersion = ccStatus.ResourceVersion
+	return nil
+}
+
+// rollingRestartPods returns the names of the pods to restart, ordered by the DCs of the spec and the pod ordinal.
+// All pods are restarted if neither DCs nor pods are specified.
+func rollingRestartPods(cc *v1alpha1.CassandraCluster, pods []v1.Pod) []string {
+	dcs := map[string]bool{}
+	for _, dc := range cc.Spec.RollingRestart.DCs {
+		dcs[dc] = true
+	}
+	podNames := map[string]bool{}
+	for _, podName := range cc.Spec.RollingRestart.Pods {
+		podNames[string(podName)] = true
+	}
+	dcIndex := map[string]int{}
+	for i, dc := range cc.Spec.DCs {
+		dcIndex[dc.Name] = i
+	}
+
+	var selected []v1.Pod
+	for _, pod := range pods {
+		dc := pod.Labels[v1alpha1.CassandraClusterDC]
+		if (len(dcs) == 0 && len(podNames) == 0) || dcs[dc] || podNames[pod.Name] {
+			selected = append(selected, pod)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		dcI, dcJ := dcIndex[selected[i].Labels[v1alpha1.CassandraClusterDC]], dcIndex[selected[j].Labels[v1alpha1.CassandraClusterDC]]
+		if dcI != dcJ {
+			return dcI < dcJ
+		}
+		return names.PodOrdinal(selected[i].Name) < names.PodOrdinal(selected[j].Name)
+	})
+
+	restartPods := make([]string, 0, len(selected))
+	for _, pod := range selected {
+		restartPods = append(restartPods, pod.Name)
+	}
+
+	return restartPods
+}
+
+func findPod(pods []v1.Pod, name string) *v1.Pod {
+	for i := range pods {
+		if pods[i].Name == name {
+			return &pods[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/jobs"
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+func rollingRestartTestPod(name, dc, ip string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{v1alpha1.CassandraClusterDC: dc},
+		},
+		Status: v1.PodStatus{
+			PodIP:             ip,
+			ContainerStatuses: []v1.ContainerStatus{{Ready: true}},
+		},
+	}
+}
+
+func TestRollingRestartPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs:            []v1alpha1.DC{{Name: "dc2"}, {Name: "dc1"}},
+			RollingRestart: &v1alpha1.RollingRestart{},
+		},
+	}
+	pods := []v1.Pod{
+		rollingRestartTestPod("test-cassandra-dc1-10", "dc1", ""),
+		rollingRestartTestPod("test-cassandra-dc1-2", "dc1", ""),
+		rollingRestartTestPod("test-cassandra-dc2-1", "dc2", ""),
+		rollingRestartTestPod("test-cassandra-dc2-0", "dc2", ""),
+	}
+
+	g.Expect(rollingRestartPods(cc, pods)).To(Equal([]string{"test-cassandra-dc2-0", "test-cassandra-dc2-1", "test-cassandra-dc1-2", "test-cassandra-dc1-10"}))
+
+	cc.Spec.RollingRestart.DCs = []string{"dc1"}
+	g.Expect(rollingRestartPods(cc, pods)).To(Equal([]string{"test-cassandra-dc1-2", "test-cassandra-dc1-10"}))
+
+	cc.Spec.RollingRestart.Pods = []v1alpha1.PodName{"test-cassandra-dc2-1"}
+	g.Expect(rollingRestartPods(cc, pods)).To(Equal([]string{"test-cassandra-dc2-1", "test-cassandra-dc1-2", "test-cassandra-dc1-10"}))
+
+	cc.Spec.RollingRestart.DCs = nil
+	g.Expect(rollingRestartPods(cc, pods)).To(Equal([]string{"test-cassandra-dc2-1"}))
+}
+
+func TestReconcileRollingRestart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	nodectlMock := mocks.NewMockNodectl(mCtrl)
+
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{{Name: "dc1"}},
+			RollingRestart: &v1alpha1.RollingRestart{
+				RequestedAt: metav1.Time{Time: time.Now().Add(-time.Minute).Truncate(time.Second)},
+			},
+		},
+	}
+	pod0 := rollingRestartTestPod("test-cassandra-dc1-0", "dc1", "10.0.0.1")
+	pod1 := rollingRestartTestPod("test-cassandra-dc1-1", "dc1", "10.0.0.2")
+	tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc, &pod0, &pod1).Build()
+
+	reconciler := &CassandraClusterReconciler{
+		Client: tClient,
+		Scheme: baseScheme,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		Log:    zap.NewNop().Sugar(),
+		Jobs:   jobs.NewJobManager(make(chan event.GenericEvent, 2), zap.NewNop().Sugar()),
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return nodectlMock
+		},
+	}
+	ctx := context.Background()
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, cc)).To(Succeed())
+
+	gomock.InOrder(
+		nodectlMock.EXPECT().DisableBinary(gomock.Any(), "10.0.0.1").Return(nil),
+		nodectlMock.EXPECT().DisableGossip(gomock.Any(), "10.0.0.1").Return(nil),
+		nodectlMock.EXPECT().Drain(gomock.Any(), "10.0.0.1").Return(nil),
+	)
+	restarting, err := reconciler.reconcileRollingRestart(ctx, cc, &v1.PodList{Items: []v1.Pod{pod0, pod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeTrue())
+	g.Expect(cc.Status.RollingRestart.State).To(Equal(v1alpha1.RollingRestartStateRunning))
+	g.Expect(cc.Status.RollingRestart.CurrentPod).To(Equal("test-cassandra-dc1-0"))
+	g.Expect(cc.Status.RollingRestart.PendingPods).To(Equal([]string{"test-cassandra-dc1-1"}))
+
+	// the pod is deleted once the node is shut down
+	g.Eventually(func() bool { return reconciler.Jobs.IsRunning(names.PodShutdownJob(pod0.Name)) }).Should(BeFalse())
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: pod0.Name, Namespace: "default"}, &v1.Pod{})).To(Succeed())
+	restarting, err = reconciler.reconcileRestartingPod(ctx, cc, &v1.PodList{Items: []v1.Pod{pod0, pod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeTrue())
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: pod0.Name, Namespace: "default"}, &v1.Pod{})).ToNot(Succeed())
+	g.Expect(reconciler.Jobs.Exists(names.PodShutdownJob(pod0.Name))).To(BeFalse())
+
+	// the pod is recreated but is not ready yet
+	restartedPod0 := rollingRestartTestPod("test-cassandra-dc1-0", "dc1", "10.0.0.3")
+	restartedPod0.CreationTimestamp = metav1.Time{Time: time.Now().Add(time.Second)}
+	restartedPod0.Status.ContainerStatuses[0].Ready = false
+	restarting, err = reconciler.reconcileRestartingPod(ctx, cc, &v1.PodList{Items: []v1.Pod{restartedPod0, pod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeFalse())
+	restarting, err = reconciler.reconcileRollingRestart(ctx, cc, &v1.PodList{Items: []v1.Pod{restartedPod0, pod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeTrue())
+	g.Expect(cc.Status.RollingRestart.CurrentPod).To(Equal("test-cassandra-dc1-0"))
+
+	// the next node is restarted even if it fails to shut down cleanly
+	restartedPod0.Status.ContainerStatuses[0].Ready = true
+	nodectlMock.EXPECT().DisableBinary(gomock.Any(), "10.0.0.2").Return(nil)
+	nodectlMock.EXPECT().DisableGossip(gomock.Any(), "10.0.0.2").Return(context.DeadlineExceeded)
+	nodectlMock.EXPECT().Drain(gomock.Any(), "10.0.0.2").Return(context.DeadlineExceeded)
+	restarting, err = reconciler.reconcileRollingRestart(ctx, cc, &v1.PodList{Items: []v1.Pod{restartedPod0, pod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeTrue())
+	g.Expect(cc.Status.RollingRestart.CurrentPod).To(Equal("test-cassandra-dc1-1"))
+	g.Expect(cc.Status.RollingRestart.RestartedPods).To(Equal([]string{"test-cassandra-dc1-0"}))
+	g.Eventually(func() bool { return reconciler.Jobs.IsRunning(names.PodShutdownJob(pod1.Name)) }).Should(BeFalse())
+	restarting, err = reconciler.reconcileRestartingPod(ctx, cc, &v1.PodList{Items: []v1.Pod{restartedPod0, pod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeTrue())
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: pod1.Name, Namespace: "default"}, &v1.Pod{})).ToNot(Succeed())
+
+	restartedPod1 := rollingRestartTestPod("test-cassandra-dc1-1", "dc1", "10.0.0.4")
+	restartedPod1.CreationTimestamp = metav1.Time{Time: time.Now().Add(time.Second)}
+	restarting, err = reconciler.reconcileRollingRestart(ctx, cc, &v1.PodList{Items: []v1.Pod{restartedPod0, restartedPod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeFalse())
+	g.Expect(cc.Status.RollingRestart.State).To(Equal(v1alpha1.RollingRestartStateCompleted))
+	g.Expect(cc.Status.RollingRestart.CompletionTime).ToNot(BeNil())
+	g.Expect(cc.Status.RollingRestart.RestartedPods).To(Equal([]string{"test-cassandra-dc1-0", "test-cassandra-dc1-1"}))
+
+	// a completed restart is not repeated
+	restarting, err = reconciler.reconcileRollingRestart(ctx, cc, &v1.PodList{Items: []v1.Pod{restartedPod0, restartedPod1}}, credentials{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restarting).To(BeFalse())
+}
@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/coordinator"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+// jmxRequestTimeout bounds a JMX request to a single node, so that an unresponsive node doesn't block the reconcile
+const jmxRequestTimeout = 10 * time.Second
+
+// runtimeSetting is a setting of `.spec.cassandra.runtimeSettings` kept in sync on the nodes through JMX
+type runtimeSetting struct {
+	name string
+	// desired returns the value of the setting, false if it's not set
+	desired func(settings v1alpha1.RuntimeSettings) (float64, bool)
+	// read returns the value the node runs with. Not set if Cassandra doesn't expose the value.
+	read  func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string) (float64, error)
+	write func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string, value float64) error
+}
+
+var runtimeSettings = []runtimeSetting{
+	{
+		name: "compactionThroughputMBPerSec",
+		desired: func(settings v1alpha1.RuntimeSettings) (float64, bool) {
+			return int32RuntimeSetting(settings.CompactionThroughputMBPerSec)
+		},
+		read: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string) (float64, error) {
+			value, err := nctl.CompactionThroughput(ctx, nodeIP)
+			return float64(value), err
+		},
+		write: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string, value float64) error {
+			return nctl.SetCompactionThroughput(ctx, nodeIP, int(value))
+		},
+	},
+	{
+		name: "streamThroughputMegabitsPerSec",
+		desired: func(settings v1alpha1.RuntimeSettings) (float64, bool) {
+			return int32RuntimeSetting(settings.StreamThroughputMegabitsPerSec)
+		},
+		read: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string) (float64, error) {
+			value, err := nctl.StreamThroughput(ctx, nodeIP)
+			return float64(value), err
+		},
+		write: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string, value float64) error {
+			return nctl.SetStreamThroughput(ctx, nodeIP, int(value))
+		},
+	},
+	{
+		name: "concurrentCompactors",
+		desired: func(settings v1alpha1.RuntimeSettings) (float64, bool) {
+			return int32RuntimeSetting(settings.ConcurrentCompactors)
+		},
+		read: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string) (float64, error) {
+			value, err := nctl.ConcurrentCompactors(ctx, nodeIP)
+			return float64(value), err
+		},
+		write: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string, value float64) error {
+			return nctl.SetConcurrentCompactors(ctx, nodeIP, int(value))
+		},
+	},
+	{
+		name: "hintedHandoffThrottleKB",
+		desired: func(settings v1alpha1.RuntimeSettings) (float64, bool) {
+			return int32RuntimeSetting(settings.HintedHandoffThrottleKB)
+		},
+		write: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string, value float64) error {
+			return nctl.SetHintedHandoffThrottle(ctx, nodeIP, int(value))
+		},
+	},
+	{
+		name: "traceProbability",
+		desired: func(settings v1alpha1.RuntimeSettings) (float64, bool) {
+			if len(settings.TraceProbability) == 0 {
+				return 0, false
+			}
+			value, err := strconv.ParseFloat(settings.TraceProbability, 64)
+			return value, err == nil
+		},
+		read: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string) (float64, error) {
+			return nctl.TraceProbability(ctx, nodeIP)
+		},
+		write: func(ctx context.Context, nctl nodectl.Nodectl, nodeIP string, value float64) error {
+			return nctl.SetTraceProbability(ctx, nodeIP, value)
+		},
+	},
+}
+
+func int32RuntimeSetting(value *int32) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+
+	return float64(*value), true
+}
+
+// reconcileRuntimeSettings applies the runtime settings to the ready nodes that don't run with them.
+// Nodes start with the settings of cassandra.yaml, so the settings are re-applied after a node restarts.
+// Nodes the settings were applied to since they started are skipped until the settings change.
+// Settings removed from the spec are left as they are until the node restarts.
+func (r *CassandraClusterReconciler) reconcileRuntimeSettings(ctx context.Context, cc *v1alpha1.CassandraCluster, podList *v1.PodList, auth credentials) error {
+	if cc.Spec.Cassandra.RuntimeSettings == nil {
+		return r.updateRuntimeSettingsStatus(ctx, cc, nil)
+	}
+
+	pods := make([]v1.Pod, len(podList.Items))
+	copy(pods, podList.Items)
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	nctl := r.NodectlClient(nodectl.JolokiaURL(cc), auth.activeRole, auth.activePassword, r.Log)
+	var nodes []v1alpha1.NodeRuntimeSettings
+	for _, pod := range pods {
+		previous := nodeRuntimeSettingsStatus(cc, pod.Name)
+		if !coordinator.Ready(pod) {
+			if previous != nil {
+				nodes = append(nodes, *previous)
+			}
+			continue
+		}
+
+		if runtimeSettingsInSync(cc, pod, previous) {
+			nodes = append(nodes, *previous)
+			continue
+		}
+
+		nodes = append(nodes, r.applyRuntimeSettings(ctx, cc, nctl, pod, previous))
+	}
+
+	return r.updateRuntimeSettingsStatus(ctx, cc, nodes)
+}
+
+// applyRuntimeSettings applies the settings the node doesn't run with and returns the new state of the node.
+// Settings Cassandra doesn't expose are applied if they were not applied since the node started.
+func (r *CassandraClusterReconciler) applyRuntimeSettings(ctx context.Context, cc *v1alpha1.CassandraCluster, nctl nodectl.Nodectl,
+	pod v1.Pod, previous *v1alpha1.NodeRuntimeSettings) v1alpha1.NodeRuntimeSettings {
+	desired := *cc.Spec.Cassandra.RuntimeSettings
+	startedAt := cassandraStartedAt(pod)
+	node := v1alpha1.NodeRuntimeSettings{Pod: v1alpha1.PodName(pod.Name)}
+	restarted := true
+	if previous != nil {
+		node.Applied = previous.Applied
+		node.AppliedAt = previous.AppliedAt
+		node.NodeStartedAt = previous.NodeStartedAt
+		restarted = !startedAt.Equal(previous.NodeStartedAt)
+	}
+
+	var drifted, failed []string
+	for _, setting := range runtimeSettings {
+		value, set := setting.desired(desired)
+		if !set {
+			continue
+		}
+
+		if setting.read != nil {
+			reqCtx, cancel := context.WithTimeout(ctx, jmxRequestTimeout)
+			actual, err := setting.read(reqCtx, nctl, pod.Status.PodIP)
+			cancel()
+			if err != nil {
+				r.Log.Warnf("Failed to read runtime setting %s of node %s: %s", setting.name, pod.Name, err.Error())
+				failed = append(failed, setting.name)
+				continue
+			}
+			if actual == value {
+				continue
+			}
+		} else if applied, wasApplied := setting.desired(node.Applied); !restarted && wasApplied && applied == value {
+			continue
+		}
+
+		drifted = append(drifted, setting.name)
+		reqCtx, cancel := context.WithTimeout(ctx, jmxRequestTimeout)
+		err := setting.write(reqCtx, nctl, pod.Status.PodIP, value)
+		cancel()
+		if err != nil {
+			r.Log.Warnf("Failed to apply runtime setting %s to node %s: %s", setting.name, pod.Name, err.Error())
+			failed = append(failed, setting.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		node.State = v1alpha1.RuntimeSettingsDrifted
+		node.Drifted = failed
+		errMsg := fmt.Sprintf("Node %s doesn't run with runtime settings %s", pod.Name, strings.Join(failed, ", "))
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cc, events.EventRuntimeSettingsDrifted, errMsg)
+		return node
+	}
+
+	node.State = v1alpha1.RuntimeSettingsApplied
+	node.Applied = desired
+	node.NodeStartedAt = startedAt
+	if len(drifted) > 0 {
+		now := metav1.Now()
+		node.AppliedAt = &now
+		msg := fmt.Sprintf("Applied runtime settings %s to node %s", strings.Join(drifted, ", "), pod.Name)
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventRuntimeSettingsApplied, msg)
+	}
+
+	return node
+}
+
+// runtimeSettingsInSync returns true if the current settings were applied to the node since it started
+func runtimeSettingsInSync(cc *v1alpha1.CassandraCluster, pod v1.Pod, previous *v1alpha1.NodeRuntimeSettings) bool {
+	if previous == nil || previous.State != v1alpha1.RuntimeSettingsApplied {
+		return false
+	}
+
+	startedAt := cassandraStartedAt(pod)
+	if startedAt == nil || !startedAt.Equal(previous.NodeStartedAt) {
+		return false
+	}
+
+	return cmp.Equal(previous.Applied, *cc.Spec.Cassandra.RuntimeSettings)
+}
+
+// cassandraStartedAt returns the time the Cassandra container of the pod started
+func cassandraStartedAt(pod v1.Pod) *metav1.Time {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == "cassandra" && containerStatus.State.Running != nil {
+			startedAt := containerStatus.State.Running.StartedAt
+			return &startedAt
+		}
+	}
+
+	return nil
+}
+
+func nodeRuntimeSettingsStatus(cc *v1alpha1.CassandraCluster, podName string) *v1alpha1.NodeRuntimeSettings {
+	for i, node := range cc.Status.RuntimeSettings {
+		if string(node.Pod) == podName {
+			return &cc.Status.RuntimeSettings[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *CassandraClusterReconciler) updateRuntimeSettingsStatus(ctx context.Context, cc *v1alpha1.CassandraCluster, nodes []v1alpha1.NodeRuntimeSettings) error {
+	if cmp.Equal(cc.Status.RuntimeSettings, nodes) {
+		return nil
+	}
+
+	ccStatus := cc.DeepCopy()
+	ccStatus.Status.RuntimeSettings = nodes
+	if err := r.Status().Update(ctx, ccStatus); err != nil {
+		return errors.Wrap(err, "failed to update runtime settings status")
+	}
+
+	cc.Status.RuntimeSettings = nodes
+	cc.ResourceVersion = ccStatus.ResourceVersion
+	return nil
+}
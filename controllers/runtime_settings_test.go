@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+func runtimeSettingsTestPod(name, ip string, startedAt time.Time) v1.Pod {
+	pod := rollingRestartTestPod(name, "dc1", ip)
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{
+		{
+			Name:  "cassandra",
+			Ready: true,
+			State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.Time{Time: startedAt}}},
+		},
+	}
+	return pod
+}
+
+func TestReconcileRuntimeSettings(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	nodectlMock := mocks.NewMockNodectl(mCtrl)
+
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{{Name: "dc1"}},
+			Cassandra: &v1alpha1.Cassandra{
+				RuntimeSettings: &v1alpha1.RuntimeSettings{
+					CompactionThroughputMBPerSec: proto.Int32(64),
+					HintedHandoffThrottleKB:      proto.Int32(2048),
+				},
+			},
+		},
+	}
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	pod0 := runtimeSettingsTestPod("test-cassandra-dc1-0", "10.0.0.1", startedAt)
+	pod1 := runtimeSettingsTestPod("test-cassandra-dc1-1", "10.0.0.2", startedAt)
+	pod1.Status.ContainerStatuses[0].Ready = false
+	tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc).Build()
+
+	reconciler := &CassandraClusterReconciler{
+		Client: tClient,
+		Scheme: baseScheme,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		Log:    zap.NewNop().Sugar(),
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return nodectlMock
+		},
+	}
+	ctx := context.Background()
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, cc)).To(Succeed())
+	podList := &v1.PodList{Items: []v1.Pod{pod1, pod0}}
+
+	// the settings are applied to the ready nodes
+	nodectlMock.EXPECT().CompactionThroughput(gomock.Any(), "10.0.0.1").Return(16, nil)
+	nodectlMock.EXPECT().SetCompactionThroughput(gomock.Any(), "10.0.0.1", 64).Return(nil)
+	nodectlMock.EXPECT().SetHintedHandoffThrottle(gomock.Any(), "10.0.0.1", 2048).Return(nil)
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings).To(HaveLen(1))
+	g.Expect(cc.Status.RuntimeSettings[0].Pod).To(BeEquivalentTo("test-cassandra-dc1-0"))
+	g.Expect(cc.Status.RuntimeSettings[0].State).To(Equal(v1alpha1.RuntimeSettingsApplied))
+	g.Expect(cc.Status.RuntimeSettings[0].Applied).To(Equal(*cc.Spec.Cassandra.RuntimeSettings))
+	g.Expect(cc.Status.RuntimeSettings[0].AppliedAt).ToNot(BeNil())
+	g.Expect(cc.Status.RuntimeSettings[0].NodeStartedAt.Time).To(Equal(startedAt))
+
+	// nodes the settings were applied to are skipped
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings[0].State).To(Equal(v1alpha1.RuntimeSettingsApplied))
+
+	// the settings are re-applied after the node restarts
+	podList.Items[1] = runtimeSettingsTestPod("test-cassandra-dc1-0", "10.0.0.3", startedAt.Add(time.Minute))
+	nodectlMock.EXPECT().CompactionThroughput(gomock.Any(), "10.0.0.3").Return(16, nil)
+	nodectlMock.EXPECT().SetCompactionThroughput(gomock.Any(), "10.0.0.3", 64).Return(nil)
+	nodectlMock.EXPECT().SetHintedHandoffThrottle(gomock.Any(), "10.0.0.3", 2048).Return(nil)
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings[0].NodeStartedAt.Time).To(Equal(startedAt.Add(time.Minute)))
+
+	// nodes that run with the settings are left alone when the settings change
+	cc.Spec.Cassandra.RuntimeSettings.HintedHandoffThrottleKB = proto.Int32(1024)
+	nodectlMock.EXPECT().CompactionThroughput(gomock.Any(), "10.0.0.3").Return(64, nil)
+	nodectlMock.EXPECT().SetHintedHandoffThrottle(gomock.Any(), "10.0.0.3", 1024).Return(nil)
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings[0].Applied.HintedHandoffThrottleKB).To(Equal(proto.Int32(1024)))
+
+	// settings that can't be applied are reported as drifted
+	cc.Spec.Cassandra.RuntimeSettings.CompactionThroughputMBPerSec = proto.Int32(128)
+	nodectlMock.EXPECT().CompactionThroughput(gomock.Any(), "10.0.0.3").Return(64, nil)
+	nodectlMock.EXPECT().SetCompactionThroughput(gomock.Any(), "10.0.0.3", 128).Return(errors.New("connection refused"))
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings[0].State).To(Equal(v1alpha1.RuntimeSettingsDrifted))
+	g.Expect(cc.Status.RuntimeSettings[0].Drifted).To(Equal([]string{"compactionThroughputMBPerSec"}))
+
+	actualCC := &v1alpha1.CassandraCluster{}
+	g.Expect(tClient.Get(ctx, types.NamespacedName{Name: "test", Namespace: "default"}, actualCC)).To(Succeed())
+	g.Expect(actualCC.Status.RuntimeSettings).To(HaveLen(1))
+	g.Expect(actualCC.Status.RuntimeSettings[0].State).To(Equal(v1alpha1.RuntimeSettingsDrifted))
+
+	// drifted nodes are retried
+	nodectlMock.EXPECT().CompactionThroughput(gomock.Any(), "10.0.0.3").Return(64, nil)
+	nodectlMock.EXPECT().SetCompactionThroughput(gomock.Any(), "10.0.0.3", 128).Return(nil)
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings[0].State).To(Equal(v1alpha1.RuntimeSettingsApplied))
+
+	// the status is removed with the settings
+	cc.Spec.Cassandra.RuntimeSettings = nil
+	g.Expect(reconciler.reconcileRuntimeSettings(ctx, cc, podList, credentials{})).To(Succeed())
+	g.Expect(cc.Status.RuntimeSettings).To(BeNil())
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+const azureAPIVersion = "2020-10-02"
+
+// verifyAzure lists the blobs of the container authenticated with the Shared Key of the storage account
+func (c *client) verifyAzure(ctx context.Context, container string) error {
+	resp, err := c.azureRequest(ctx, container, url.Values{"restype": {"container"}, "comp": {"list"}, "maxresults": {"1"}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return c.azureError(resp, container)
+}
+
+// listAzureDirs lists the blob prefixes under the prefix with the List Blobs API, following the markers
+func (c *client) listAzureDirs(ctx context.Context, container, prefix string) ([]string, error) {
+	var dirs []string
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}, "delimiter": {"/"}, "prefix": {prefix}}
+		if len(marker) != 0 {
+			query.Set("marker", marker)
+		}
+
+		resp, err := c.azureRequest(ctx, container, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err = c.azureError(resp, container)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		result := struct {
+			BlobPrefixes []struct {
+				Name string `xml:"Name"`
+			} `xml:"Blobs>BlobPrefix"`
+			NextMarker string `xml:"NextMarker"`
+		}{}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the blobs of container %s", container)
+		}
+
+		for _, blobPrefix := range result.BlobPrefixes {
+			dirs = append(dirs, dirName(blobPrefix.Name, prefix))
+		}
+
+		if len(result.NextMarker) == 0 {
+			return dirs, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// azureRequest sends a request for the container with the query, signed with the storage account key
+func (c *client) azureRequest(ctx context.Context, container string, query url.Values) (*http.Response, error) {
+	account := string(c.secret.Data["azurestorageaccount"])
+	key, err := base64.StdEncoding.DecodeString(string(c.secret.Data["azurestoragekey"]))
+	if err != nil {
+		return nil, errors.Errorf("'azurestoragekey' in secret %s is not a base64 encoded storage account key", c.secret.Name)
+	}
+
+	endpoint := fmt.Sprintf(c.azureEndpoint, account)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"/"+url.PathEscape(container)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	signSharedKey(req, account, key, c.now().UTC().Format(http.TimeFormat))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to reach the storage endpoint %s, check azurestorageaccount in secret %s", endpoint, c.secret.Name)
+	}
+
+	return resp, nil
+}
+
+// azureError returns the error of a failed request with a hint on how to fix it
+func (c *client) azureError(resp *http.Response, container string) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	respErr := xmlError{}
+	_ = xml.Unmarshal(b, &respErr)
+	if len(respErr.Code) == 0 {
+		respErr.Code = resp.Header.Get("x-ms-error-code")
+	}
+	storageErr := &Error{
+		StorageProvider: v1alpha1.StorageProviderAzure,
+		StatusCode:      resp.StatusCode,
+		Code:            respErr.Code,
+		// the message includes the request ID and time on separate lines
+		Message: strings.SplitN(respErr.Message, "\n", 2)[0],
+	}
+
+	switch respErr.Code {
+	case "AuthenticationFailed":
+		storageErr.Hint = fmt.Sprintf("Check azurestorageaccount and azurestoragekey in secret %s", c.secret.Name)
+	case "AuthorizationFailure", "AuthorizationPermissionMismatch":
+		storageErr.Hint = fmt.Sprintf("The storage account key is not allowed to list container %s", container)
+	case "ContainerNotFound":
+		storageErr.Hint = fmt.Sprintf("Container %s doesn't exist. Create it or set createMissingBucket", container)
+	}
+
+	return storageErr
+}
+
+// signSharedKey signs the request with the Shared Key authorization of the Blob service
+func signSharedKey(req *http.Request, account string, key []byte, date string) {
+	req.Header.Set("x-ms-date", date)
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	// the resource includes the account name even if the URL doesn't, as is the case of the public endpoints
+	canonicalResource := "/" + account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		canonicalResource += "\n" + strings.ToLower(name) + ":" + strings.Join(query[name], ",")
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		"", // Content-Encoding
+		"", // Content-Language
+		"", // Content-Length
+		"", // Content-MD5
+		"", // Content-Type
+		"", // Date
+		"", // If-Modified-Since
+		"", // If-Match
+		"", // If-None-Match
+		"", // If-Unmodified-Since
+		"", // Range
+		"x-ms-date:" + date,
+		"x-ms-version:" + azureAPIVersion,
+		canonicalResource,
+	}, "\n")
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", account, base64.StdEncoding.EncodeToString(h.Sum(nil))))
+}
@@ -0,0 +1,235 @@
+package storage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+const (
+	defaultGCPTokenURI = "https://oauth2.googleapis.com/token"
+	gcpReadOnlyScope   = "https://www.googleapis.com/auth/devstorage.read_only"
+)
+
+// serviceAccountKey is the JSON key of a GCP service account
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// verifyGCP obtains an access token for the service account and lists the objects of the bucket
+func (c *client) verifyGCP(ctx context.Context, bucket string) error {
+	accountKey, token, err := c.gcpCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.gcpRequest(ctx, token, bucket, url.Values{"maxResults": {"1"}, "fields": {"kind"}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return c.gcpError(resp, accountKey, bucket)
+}
+
+// listGCPDirs lists the prefixes under the prefix with the objects list API, following the page tokens
+func (c *client) listGCPDirs(ctx context.Context, bucket, prefix string) ([]string, error) {
+	accountKey, token, err := c.gcpCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	pageToken := ""
+	for {
+		query := url.Values{"delimiter": {"/"}, "prefix": {prefix}, "fields": {"prefixes,nextPageToken"}}
+		if len(pageToken) != 0 {
+			query.Set("pageToken", pageToken)
+		}
+
+		resp, err := c.gcpRequest(ctx, token, bucket, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err = c.gcpError(resp, accountKey, bucket)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		result := struct {
+			Prefixes      []string `json:"prefixes"`
+			NextPageToken string   `json:"nextPageToken"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the objects of bucket %s", bucket)
+		}
+
+		for _, objectPrefix := range result.Prefixes {
+			dirs = append(dirs, dirName(objectPrefix, prefix))
+		}
+
+		if len(result.NextPageToken) == 0 {
+			return dirs, nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
+// gcpCredentials returns the service account key from the secret and an access token for it
+func (c *client) gcpCredentials(ctx context.Context) (serviceAccountKey, string, error) {
+	accountKey := serviceAccountKey{}
+	err := json.Unmarshal(c.secret.Data["gcp"], &accountKey)
+	if err != nil || len(accountKey.ClientEmail) == 0 || len(accountKey.PrivateKey) == 0 {
+		return accountKey, "", errors.Errorf("'gcp' in secret %s is not a service account key in the JSON format", c.secret.Name)
+	}
+
+	token, err := c.gcpAccessToken(ctx, accountKey)
+	return accountKey, token, err
+}
+
+// gcpRequest sends an authorized request listing the objects of the bucket with the query
+func (c *client) gcpRequest(ctx context.Context, token, bucket string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.gcpEndpoint+"/storage/v1/b/"+url.PathEscape(bucket)+"/o?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to reach the storage endpoint %s", c.gcpEndpoint)
+	}
+
+	return resp, nil
+}
+
+// gcpError returns the error of a failed request with a hint on how to fix it
+func (c *client) gcpError(resp *http.Response, accountKey serviceAccountKey, bucket string) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	respErr := struct {
+		Error struct {
+			Message string `json:"message"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}{}
+	_ = json.Unmarshal(b, &respErr)
+	storageErr := &Error{
+		StorageProvider: v1alpha1.StorageProviderGCP,
+		StatusCode:      resp.StatusCode,
+		Message:         respErr.Error.Message,
+	}
+	if len(respErr.Error.Errors) != 0 {
+		storageErr.Code = respErr.Error.Errors[0].Reason
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		storageErr.Hint = fmt.Sprintf("Check the service account key in secret %s", c.secret.Name)
+	case http.StatusForbidden:
+		storageErr.Hint = fmt.Sprintf("Service account %s is not allowed to list bucket %s. Grant it the storage.objects.list permission or set skipBucketVerification",
+			accountKey.ClientEmail, bucket)
+	case http.StatusNotFound:
+		storageErr.Hint = fmt.Sprintf("Bucket %s doesn't exist. Create it or set createMissingBucket", bucket)
+	}
+
+	return storageErr
+}
+
+// gcpAccessToken exchanges a JWT signed with the service account key for an OAuth2 access token
+func (c *client) gcpAccessToken(ctx context.Context, accountKey serviceAccountKey) (string, error) {
+	block, _ := pem.Decode([]byte(accountKey.PrivateKey))
+	if block == nil {
+		return "", errors.Errorf("the private key of the service account key in secret %s is not PEM encoded", c.secret.Name)
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsedKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	privateKey, ok := parsedKey.(*rsa.PrivateKey)
+	if err != nil || !ok {
+		return "", errors.Errorf("the private key of the service account key in secret %s is not an RSA key", c.secret.Name)
+	}
+
+	tokenURI := accountKey.TokenURI
+	if len(tokenURI) == 0 {
+		tokenURI = defaultGCPTokenURI
+	}
+
+	now := c.now().Unix()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss":   accountKey.ClientEmail,
+		"scope": gcpReadOnlyScope,
+		"aud":   tokenURI,
+		"iat":   now,
+		"exp":   now + 3600,
+	})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hashed := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign the access token request")
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to reach the token endpoint %s", tokenURI)
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	tokenResp := struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	_ = json.Unmarshal(b, &tokenResp)
+	if resp.StatusCode != http.StatusOK || len(tokenResp.AccessToken) == 0 {
+		return "", &Error{
+			StorageProvider: v1alpha1.StorageProviderGCP,
+			StatusCode:      resp.StatusCode,
+			Code:            tokenResp.Error,
+			Message:         tokenResp.ErrorDescription,
+			Hint:            fmt.Sprintf("Check the service account key in secret %s", c.secret.Name),
+		}
+	}
+
+	return tokenResp.AccessToken, nil
+}
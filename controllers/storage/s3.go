@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+const (
+	defaultAWSRegion = "us-east-1"
+	// the SHA256 hash of an empty request body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// xmlError is the error response of S3-like and Azure storage
+type xmlError struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// s3Config holds the endpoint and credentials of S3-like storage
+type s3Config struct {
+	endpoint        string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// verifyS3 lists the bucket with the ListObjectsV2 API. Works for AWS and S3 compatible storage like MinIO or Ceph.
+func (c *client) verifyS3(ctx context.Context, storageProvider v1alpha1.StorageProvider, bucket string) error {
+	cfg, err := c.s3Config(storageProvider)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.s3Request(ctx, cfg, bucket, url.Values{"list-type": {"2"}, "max-keys": {"1"}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return c.s3Error(resp, storageProvider, bucket)
+}
+
+// listS3Dirs lists the common prefixes under the prefix with the ListObjectsV2 API, following the continuation tokens
+func (c *client) listS3Dirs(ctx context.Context, storageProvider v1alpha1.StorageProvider, bucket, prefix string) ([]string, error) {
+	cfg, err := c.s3Config(storageProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	continuationToken := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "delimiter": {"/"}, "prefix": {prefix}}
+		if len(continuationToken) != 0 {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		resp, err := c.s3Request(ctx, cfg, bucket, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err = c.s3Error(resp, storageProvider, bucket)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		result := struct {
+			CommonPrefixes []struct {
+				Prefix string `xml:"Prefix"`
+			} `xml:"CommonPrefixes"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}{}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the objects of bucket %s", bucket)
+		}
+
+		for _, commonPrefix := range result.CommonPrefixes {
+			dirs = append(dirs, dirName(commonPrefix.Prefix, prefix))
+		}
+
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return dirs, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// s3Config returns the endpoint and credentials from the secret.
+// Returns ErrNotVerifiable if the credentials are not set, e.g. if Icarus obtains them from its environment.
+func (c *client) s3Config(storageProvider v1alpha1.StorageProvider) (s3Config, error) {
+	cfg := s3Config{
+		accessKeyID:     string(c.secret.Data["awsaccesskeyid"]),
+		secretAccessKey: string(c.secret.Data["awssecretaccesskey"]),
+		region:          string(c.secret.Data["awsregion"]),
+		endpoint:        string(c.secret.Data["awsendpoint"]),
+	}
+	if len(cfg.accessKeyID) == 0 || len(cfg.secretAccessKey) == 0 {
+		return cfg, ErrNotVerifiable
+	}
+
+	if len(cfg.region) == 0 {
+		cfg.region = defaultAWSRegion
+	}
+
+	if len(cfg.endpoint) == 0 {
+		if storageProvider != v1alpha1.StorageProviderS3 {
+			return cfg, ErrNotVerifiable
+		}
+		cfg.endpoint = fmt.Sprintf("s3.%s.amazonaws.com", cfg.region)
+	}
+
+	if !strings.Contains(cfg.endpoint, "://") {
+		if c.insecure {
+			cfg.endpoint = "http://" + cfg.endpoint
+		} else {
+			cfg.endpoint = "https://" + cfg.endpoint
+		}
+	}
+
+	return cfg, nil
+}
+
+// s3Request sends a signed GET request for the bucket with the query
+func (c *client) s3Request(ctx context.Context, cfg s3Config, bucket string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(cfg.endpoint, "/")+"/"+url.PathEscape(bucket)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	signV4(req, cfg.accessKeyID, cfg.secretAccessKey, cfg.region, "s3", c.now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to reach the storage endpoint %s, check awsendpoint in secret %s", cfg.endpoint, c.secret.Name)
+	}
+
+	return resp, nil
+}
+
+// s3Error returns the error of a failed request with a hint on how to fix it
+func (c *client) s3Error(resp *http.Response, storageProvider v1alpha1.StorageProvider, bucket string) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	respErr := xmlError{}
+	_ = xml.Unmarshal(b, &respErr)
+	storageErr := &Error{
+		StorageProvider: storageProvider,
+		StatusCode:      resp.StatusCode,
+		Code:            respErr.Code,
+		Message:         respErr.Message,
+	}
+
+	switch respErr.Code {
+	case "InvalidAccessKeyId":
+		storageErr.Hint = fmt.Sprintf("Check awsaccesskeyid in secret %s", c.secret.Name)
+	case "SignatureDoesNotMatch":
+		storageErr.Hint = fmt.Sprintf("Check awssecretaccesskey in secret %s", c.secret.Name)
+	case "AccessDenied":
+		storageErr.Hint = fmt.Sprintf("The credentials are not allowed to list bucket %s. Grant them the s3:ListBucket permission or set skipBucketVerification", bucket)
+	case "NoSuchBucket":
+		storageErr.Hint = fmt.Sprintf("Bucket %s doesn't exist. Create it or set createMissingBucket", bucket)
+	case "AuthorizationHeaderMalformed", "PermanentRedirect", "IllegalLocationConstraintException":
+		if bucketRegion := resp.Header.Get("x-amz-bucket-region"); len(bucketRegion) != 0 {
+			storageErr.Hint = fmt.Sprintf("Bucket %s is in region %s, set awsregion in secret %s accordingly", bucket, bucketRegion, c.secret.Name)
+		} else {
+			storageErr.Hint = fmt.Sprintf("Check awsregion and awsendpoint in secret %s", c.secret.Name)
+		}
+	}
+
+	return storageErr
+}
+
+// signV4 signs the request with AWS Signature Version 4. All headers of the request are signed.
+func signV4(req *http.Request, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", emptyPayloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	canonicalHeaders := ""
+	for _, name := range headerNames {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if len(canonicalURI) == 0 {
+		canonicalURI = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex(canonicalRequest)}, "\n")
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{amzDate[:8], region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query sorted by parameter names with values encoded as required by AWS
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	sort.Strings(params)
+
+	return strings.Join(params, "&")
+}
+
+func uriEncode(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(url.QueryEscape(s), "+", "%20"), "%7E", "~")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hashHex(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
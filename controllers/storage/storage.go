@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+const (
+	ReasonVerified           = "Verified"
+	ReasonVerificationFailed = "VerificationFailed"
+	ReasonNotVerifiable      = "NotVerifiable"
+)
+
+// ErrNotVerifiable is returned if the credentials can't be verified by the operator,
+// e.g. if Icarus obtains the AWS credentials from its environment
+var ErrNotVerifiable = errors.New("the storage credentials are not set in the secret and can't be verified by the operator")
+
+// Storage verifies the storage credentials against the storage provider and lists the backups in the storage
+type Storage interface {
+	// Verify makes an authenticated request listing at most one object of the bucket of the storage location
+	Verify(ctx context.Context, storageProvider v1alpha1.StorageProvider, storageLocation string) error
+	// ListDirs returns the names of the directories directly under the path of the storage location,
+	// e.g. the DCs of a cluster for `s3://bucket/cluster`. Returns ErrNotVerifiable if the storage can't be accessed by the operator.
+	ListDirs(ctx context.Context, storageProvider v1alpha1.StorageProvider, storageLocation string) ([]string, error)
+}
+
+type client struct {
+	httpClient    *http.Client
+	secret        *v1.Secret
+	insecure      bool
+	azureEndpoint string // format string of the blob service endpoint with the storage account
+	gcpEndpoint   string
+	now           func() time.Time
+}
+
+// New returns a client using the credentials from the storage secret.
+// If insecure is set, S3-like endpoints without a scheme are accessed over HTTP.
+func New(secret *v1.Secret, insecure bool) Storage {
+	return &client{
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		secret:        secret,
+		insecure:      insecure,
+		azureEndpoint: "https://%s.blob.core.windows.net",
+		gcpEndpoint:   "https://storage.googleapis.com",
+		now:           time.Now,
+	}
+}
+
+// Error is an error response of the storage provider
+type Error struct {
+	StorageProvider v1alpha1.StorageProvider
+	StatusCode      int
+	Code            string
+	Message         string
+	// Hint tells what should be fixed to make the request succeed
+	Hint string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s storage responded with %d", e.StorageProvider, e.StatusCode)
+	if len(e.Code) != 0 {
+		msg += " " + e.Code
+	}
+	if len(e.Message) != 0 {
+		msg += ": " + strings.TrimSuffix(e.Message, ".")
+	}
+	if len(e.Hint) != 0 {
+		msg += ". " + e.Hint
+	}
+
+	return msg
+}
+
+// IsBucketNotFound returns true if the storage provider responded that the bucket doesn't exist
+func IsBucketNotFound(err error) bool {
+	storageErr, ok := errors.Cause(err).(*Error)
+	return ok && storageErr.StatusCode == http.StatusNotFound
+}
+
+func (c *client) Verify(ctx context.Context, storageProvider v1alpha1.StorageProvider, storageLocation string) error {
+	bucket := Bucket(storageLocation)
+	if len(bucket) == 0 {
+		return errors.Errorf("storage location %q has no bucket", storageLocation)
+	}
+
+	switch storageProvider {
+	case v1alpha1.StorageProviderS3, v1alpha1.StorageProviderMinio, v1alpha1.StorageProviderCeph, v1alpha1.StorageProviderOracle:
+		return c.verifyS3(ctx, storageProvider, bucket)
+	case v1alpha1.StorageProviderAzure:
+		return c.verifyAzure(ctx, bucket)
+	case v1alpha1.StorageProviderGCP:
+		return c.verifyGCP(ctx, bucket)
+	}
+
+	return ErrNotVerifiable
+}
+
+func (c *client) ListDirs(ctx context.Context, storageProvider v1alpha1.StorageProvider, storageLocation string) ([]string, error) {
+	bucket := Bucket(storageLocation)
+	if len(bucket) == 0 {
+		return nil, errors.Errorf("storage location %q has no bucket", storageLocation)
+	}
+
+	prefix := Path(storageLocation)
+	if len(prefix) != 0 {
+		prefix += "/"
+	}
+
+	switch storageProvider {
+	case v1alpha1.StorageProviderS3, v1alpha1.StorageProviderMinio, v1alpha1.StorageProviderCeph, v1alpha1.StorageProviderOracle:
+		return c.listS3Dirs(ctx, storageProvider, bucket, prefix)
+	case v1alpha1.StorageProviderAzure:
+		return c.listAzureDirs(ctx, bucket, prefix)
+	case v1alpha1.StorageProviderGCP:
+		return c.listGCPDirs(ctx, bucket, prefix)
+	}
+
+	return nil, ErrNotVerifiable
+}
+
+// Bucket returns the bucket of a storage location, e.g. `bucket` for `s3://bucket/path`
+func Bucket(storageLocation string) string {
+	index := strings.Index(storageLocation, "://")
+	if index < 0 {
+		return ""
+	}
+
+	return strings.SplitN(storageLocation[index+3:], "/", 2)[0]
+}
+
+// Path returns the path of a storage location in its bucket without the surrounding slashes, e.g. `path/to` for `s3://bucket/path/to/`
+func Path(storageLocation string) string {
+	index := strings.Index(storageLocation, "://")
+	if index < 0 {
+		return ""
+	}
+
+	parts := strings.SplitN(storageLocation[index+3:], "/", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return strings.Trim(parts[1], "/")
+}
+
+// dirName returns the name of a listed prefix without the parent prefix and the trailing slash
+func dirName(listedPrefix, parentPrefix string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(listedPrefix, parentPrefix), "/")
+}
+
+// Verified returns true if the storage has been verified for the current generation of the resource
+func Verified(conditions []metav1.Condition, generation int64) bool {
+	condition := meta.FindStatusCondition(conditions, v1alpha1.ConditionTypeStorageVerified)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == generation
+}
+
+// SetCondition sets the StorageVerified condition based on the result of the verification
+func SetCondition(conditions *[]metav1.Condition, err error, generation int64) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeStorageVerified,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonVerified,
+		Message:            "The storage credentials are valid",
+		ObservedGeneration: generation,
+	}
+
+	if err == ErrNotVerifiable {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = ReasonNotVerifiable
+		condition.Message = err.Error()
+	} else if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonVerificationFailed
+		condition.Message = err.Error()
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
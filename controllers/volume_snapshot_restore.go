@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pendingVolumeSnapshotRestore returns the name of the CassandraRestore that is about to provision the volumes of the cluster
+// from VolumeSnapshots or an empty string if there's none. The StatefulSets are not created until the volumes are provisioned,
+// otherwise they would start with empty volumes. A restore that has failed, e.g. because the backup doesn't match the cluster,
+// doesn't block the StatefulSets anymore.
+func (r *CassandraClusterReconciler) pendingVolumeSnapshotRestore(ctx context.Context, cc *v1alpha1.CassandraCluster) (string, error) {
+	restoreList := &v1alpha1.CassandraRestoreList{}
+	err := r.List(ctx, restoreList, client.InNamespace(cc.Namespace))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list restores")
+	}
+
+	for _, cr := range restoreList.Items {
+		if cr.Spec.CassandraCluster != cc.Name || cr.Spec.Mode != v1alpha1.RestoreModeVolumeSnapshot {
+			continue
+		}
+
+		if len(cr.Status.State) == 0 && !cr.Spec.Cancel && cr.DeletionTimestamp == nil {
+			return cr.Name, nil
+		}
+	}
+
+	return "", nil
+}
@@ -64,6 +64,8 @@ func CreateValidatingWebhookConf(namespace string, clusterRole *rbac.ClusterRole
 		namespacedScope   = admissionv1.NamespacedScope
 		ccWebhookPath     = "/validate-db-ibm-com-v1alpha1-cassandracluster"
 		cbWebhookPath     = "/validate-db-ibm-com-v1alpha1-cassandrabackup"
+		cbsWebhookPath    = "/validate-db-ibm-com-v1alpha1-cassandrabackupschedule"
+		cbcWebhookPath    = "/validate-db-ibm-com-v1alpha1-cassandrabackupcatalog"
 		crWebhookPath     = "/validate-db-ibm-com-v1alpha1-cassandrarestore"
 	)
 
@@ -145,6 +147,68 @@ func CreateValidatingWebhookConf(namespace string, clusterRole *rbac.ClusterRole
 				TimeoutSeconds:          nil,
 				AdmissionReviewVersions: []string{"v1", "v1beta1"},
 			},
+			{
+				Name: "vcassandrabackupschedule.kb.io",
+				ClientConfig: admissionv1.WebhookClientConfig{
+					URL: nil,
+					Service: &admissionv1.ServiceReference{
+						Namespace: namespace,
+						Name:      names.WebhooksServiceName(),
+						Path:      &cbsWebhookPath,
+						Port:      proto.Int32(443),
+					},
+					CABundle: caCrtBytes,
+				},
+				Rules: []admissionv1.RuleWithOperations{
+					{
+						Operations: []admissionv1.OperationType{admissionv1.Create, admissionv1.Update},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{"db.ibm.com"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"cassandrabackupschedules"},
+							Scope:       &namespacedScope,
+						},
+					},
+				},
+				FailurePolicy:           &failurePolicyType,
+				MatchPolicy:             nil,
+				NamespaceSelector:       nil,
+				ObjectSelector:          nil,
+				SideEffects:             &sideEffectNone,
+				TimeoutSeconds:          nil,
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+			},
+			{
+				Name: "vcassandrabackupcatalog.kb.io",
+				ClientConfig: admissionv1.WebhookClientConfig{
+					URL: nil,
+					Service: &admissionv1.ServiceReference{
+						Namespace: namespace,
+						Name:      names.WebhooksServiceName(),
+						Path:      &cbcWebhookPath,
+						Port:      proto.Int32(443),
+					},
+					CABundle: caCrtBytes,
+				},
+				Rules: []admissionv1.RuleWithOperations{
+					{
+						Operations: []admissionv1.OperationType{admissionv1.Create, admissionv1.Update},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{"db.ibm.com"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"cassandrabackupcatalogs"},
+							Scope:       &namespacedScope,
+						},
+					},
+				},
+				FailurePolicy:           &failurePolicyType,
+				MatchPolicy:             nil,
+				NamespaceSelector:       nil,
+				ObjectSelector:          nil,
+				SideEffects:             &sideEffectNone,
+				TimeoutSeconds:          nil,
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+			},
 			{
 				Name: "vcassandrarestore.kb.io",
 				ClientConfig: admissionv1.WebhookClientConfig{
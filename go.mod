@@ -15,6 +15,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_model v0.2.0
 	github.com/prometheus/common v0.37.0
+	github.com/robfig/cron/v3 v3.0.1
 	go.uber.org/zap v1.21.0
 	k8s.io/api v0.24.3
 	k8s.io/apiextensions-apiserver v0.24.3
@@ -43,6 +43,8 @@ import (
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers"
 	"github.com/ibm/cassandra-operator/controllers/cassandrabackup"
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackupcatalog"
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackupschedule"
 	"github.com/ibm/cassandra-operator/controllers/cassandrarestore"
 	operatorCfg "github.com/ibm/cassandra-operator/controllers/config"
 	"github.com/ibm/cassandra-operator/controllers/cql"
@@ -53,6 +55,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
 	"github.com/ibm/cassandra-operator/controllers/prober"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	"github.com/ibm/cassandra-operator/controllers/webhooks"
 )
 
@@ -124,6 +127,8 @@ func main() {
 
 	eventRecorder := events.NewEventRecorder(mgr.GetEventRecorderFor(events.EventRecorderNameCassandraCluster))
 	reconcileChan := make(chan event.GenericEvent)
+	jobManager := jobs.NewJobManager(reconcileChan, logr)
+	cqlClient := func(cluster *gocql.ClusterConfig) (cql.CqlClient, error) { return cql.NewCQLClient(cluster) }
 
 	cassandraReconciler := &controllers.CassandraClusterReconciler{
 		Client: mgr.GetClient(),
@@ -134,14 +139,17 @@ func main() {
 		ProberClient: func(url *url.URL, user, password string) prober.ProberClient {
 			return prober.NewProberClient(url, httpClient, user, password)
 		},
-		CqlClient: func(cluster *gocql.ClusterConfig) (cql.CqlClient, error) { return cql.NewCQLClient(cluster) },
+		CqlClient: cqlClient,
 		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
 			return nodectl.NewClient(jolokiaAddr, jmxUser, jmxPassword, logr)
 		},
 		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
 			return reaper.NewReaperClient(url, clusterName, httpClient, defaultRepairThreadCount)
 		},
-		Jobs: jobs.NewJobManager(reconcileChan, logr),
+		IcarusClient: func(icarusURL string) icarus.Icarus {
+			return icarus.New(icarusURL)
+		},
+		Jobs: jobManager,
 	}
 	err = controllers.SetupCassandraReconciler(cassandraReconciler, mgr, logr, reconcileChan)
 	if err != nil {
@@ -158,6 +166,10 @@ func main() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return icarus.New(coordinatorPodURL)
 		},
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return nodectl.NewClient(jolokiaAddr, jmxUser, jmxPassword, logr)
+		},
+		StorageClient: storage.New,
 	}
 	err = cassandrabackup.SetupCassandraBackupReconciler(cassandraBackupReconciler, mgr)
 	if err != nil {
@@ -165,6 +177,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	cassandraBackupScheduleReconciler := &cassandrabackupschedule.CassandraBackupScheduleReconciler{
+		Client: mgr.GetClient(),
+		Log:    logr,
+		Scheme: mgr.GetScheme(),
+		Cfg:    *operatorConfig,
+		Events: eventRecorder,
+		Now:    time.Now,
+	}
+	err = cassandrabackupschedule.SetupCassandraBackupScheduleReconciler(cassandraBackupScheduleReconciler, mgr)
+	if err != nil {
+		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraBackupSchedule")
+		os.Exit(1)
+	}
+
+	cassandraBackupCatalogReconciler := &cassandrabackupcatalog.CassandraBackupCatalogReconciler{
+		Client: mgr.GetClient(),
+		Log:    logr,
+		Scheme: mgr.GetScheme(),
+		Cfg:    *operatorConfig,
+		Events: eventRecorder,
+		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
+			return icarus.New(coordinatorPodURL)
+		},
+		StorageClient: storage.New,
+	}
+	err = cassandrabackupcatalog.SetupCassandraBackupCatalogReconciler(cassandraBackupCatalogReconciler, mgr)
+	if err != nil {
+		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraBackupCatalog")
+		os.Exit(1)
+	}
+
 	cassandraRestoreReconciler := &cassandrarestore.CassandraRestoreReconciler{
 		Client: mgr.GetClient(),
 		Log:    logr,
@@ -174,6 +217,9 @@ func main() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return icarus.New(coordinatorPodURL)
 		},
+		CqlClient:     cqlClient,
+		Jobs:          jobManager,
+		StorageClient: storage.New,
 	}
 	err = cassandrarestore.SetupCassandraRestoreReconciler(cassandraRestoreReconciler, mgr)
 	if err != nil {
@@ -207,6 +253,14 @@ func main() {
 			logr.With(zap.Error(err)).Fatal("failed to setup webhook with manager for cassandrabackup")
 			os.Exit(1)
 		}
+		if err = (&dbv1alpha1.CassandraBackupSchedule{}).SetupWebhookWithManager(mgr); err != nil {
+			logr.With(zap.Error(err)).Fatal("failed to setup webhook with manager for cassandrabackupschedule")
+			os.Exit(1)
+		}
+		if err = (&dbv1alpha1.CassandraBackupCatalog{}).SetupWebhookWithManager(mgr); err != nil {
+			logr.With(zap.Error(err)).Fatal("failed to setup webhook with manager for cassandrabackupcatalog")
+			os.Exit(1)
+		}
 		if err = (&dbv1alpha1.CassandraRestore{}).SetupWebhookWithManager(mgr); err != nil {
 			logr.With(zap.Error(err)).Fatal("failed to setup webhook with manager for cassandrarestore")
 			os.Exit(1)
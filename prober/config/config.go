@@ -12,6 +12,7 @@ type Config struct {
 	PodNamespace            string        `env:"POD_NAMESPACE,required"`
 	AdminRoleSecretName     string        `env:"ADMIN_SECRET_NAME,required"`      // Active Admin Secret
 	BaseAdminRoleSecretName string        `env:"BASE_ADMIN_SECRET_NAME,required"` // User's Admin Secret
+	StateConfigMapName      string        `env:"STATE_CONFIGMAP_NAME"`            // State shared between prober replicas
 	JmxPollingInterval      time.Duration `env:"JMX_POLLING_INTERVAL" envDefault:"10s"`
 	JmxPort                 int           `env:"JMX_PORT" envDefault:"7199"`
 	JolokiaPort             int           `env:"JOLOKIA_PORT" envDefault:"8080"`
@@ -31,7 +31,10 @@ func (p *Prober) ping(w http.ResponseWriter, _ *http.Request, _ httprouter.Param
 }
 
 func (p *Prober) getRegionReady(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
-	p.write(w, []byte(strconv.FormatBool(p.state.regionReady)))
+	p.stateLock.RLock()
+	ready := p.state.regionReady
+	p.stateLock.RUnlock()
+	p.write(w, []byte(strconv.FormatBool(ready)))
 }
 
 func (p *Prober) putRegionReady(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -44,12 +47,18 @@ func (p *Prober) putRegionReady(w http.ResponseWriter, r *http.Request, _ httpro
 		p.log.Error(err, "can't parse region readiness state")
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
+		p.stateLock.Lock()
 		p.state.regionReady = ready
+		p.stateLock.Unlock()
+		p.saveState(w, stateKeyRegionReady, ready)
 	}
 }
 
 func (p *Prober) getReaperReady(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
-	p.write(w, []byte(strconv.FormatBool(p.state.reaperReady)))
+	p.stateLock.RLock()
+	ready := p.state.reaperReady
+	p.stateLock.RUnlock()
+	p.write(w, []byte(strconv.FormatBool(ready)))
 }
 
 func (p *Prober) putReaperReady(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -62,12 +71,17 @@ func (p *Prober) putReaperReady(w http.ResponseWriter, r *http.Request, _ httpro
 		p.log.Error(err, "can't parse reaper readiness state")
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
+		p.stateLock.Lock()
 		p.state.reaperReady = ready
+		p.stateLock.Unlock()
+		p.saveState(w, stateKeyReaperReady, ready)
 	}
 }
 
 func (p *Prober) getSeeds(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateLock.RLock()
 	response, _ := json.Marshal(p.state.seeds)
+	p.stateLock.RUnlock()
 	p.write(w, response)
 }
 
@@ -79,12 +93,17 @@ func (p *Prober) putSeeds(w http.ResponseWriter, r *http.Request, _ httprouter.P
 	} else if json.Unmarshal(body, &s) != nil {
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
+		p.stateLock.Lock()
 		p.state.seeds = s
+		p.stateLock.Unlock()
+		p.saveState(w, stateKeySeeds, s)
 	}
 }
 
 func (p *Prober) getDCs(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateLock.RLock()
 	response, _ := json.Marshal(p.state.dcs)
+	p.stateLock.RUnlock()
 	p.write(w, response)
 }
 
@@ -96,7 +115,18 @@ func (p *Prober) putDCs(w http.ResponseWriter, r *http.Request, _ httprouter.Par
 	} else if json.Unmarshal(body, &dcs) != nil {
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
+		p.stateLock.Lock()
 		p.state.dcs = dcs
+		p.stateLock.Unlock()
+		p.saveState(w, stateKeyDCs, dcs)
+	}
+}
+
+// saveState shares the updated state with other prober replicas
+func (p *Prober) saveState(w http.ResponseWriter, key string, value interface{}) {
+	if err := p.persistState(key, value); err != nil {
+		p.log.Errorf("can't persist %s state: %s", key, err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
 
@@ -108,7 +138,9 @@ func (p *Prober) write(writer io.Writer, data []byte) {
 }
 
 func (p *Prober) getRegionIPs(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateLock.RLock()
 	response, _ := json.Marshal(p.state.regionIPs)
+	p.stateLock.RUnlock()
 	p.write(w, response)
 }
 
@@ -122,12 +154,17 @@ func (p *Prober) putRegionIPs(w http.ResponseWriter, r *http.Request, _ httprout
 		p.log.Error(err)
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
+		p.stateLock.Lock()
 		p.state.regionIPs = ips
+		p.stateLock.Unlock()
+		p.saveState(w, stateKeyRegionIPs, ips)
 	}
 }
 
 func (p *Prober) getReaperIPs(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateLock.RLock()
 	response, _ := json.Marshal(p.state.reaperIPs)
+	p.stateLock.RUnlock()
 	p.write(w, response)
 }
 
@@ -141,6 +178,9 @@ func (p *Prober) putReaperIPs(w http.ResponseWriter, r *http.Request, _ httprout
 		p.log.Error(err)
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
+		p.stateLock.Lock()
 		p.state.reaperIPs = ips
+		p.stateLock.Unlock()
+		p.saveState(w, stateKeyReaperIPs, ips)
 	}
 }
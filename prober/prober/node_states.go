@@ -19,16 +19,28 @@ type nodeState struct {
 
 func (p *Prober) processReadinessProbe(podIP string, broadcastIP string) (bool, map[string]string) {
 	broadcastIP = fmt.Sprintf("/%s", broadcastIP)
-	if _, ok := p.state.nodes[broadcastIP]; !ok {
+	p.stateLock.Lock()
+	_, known := p.state.nodes[broadcastIP]
+	if !known {
 		p.log.Infow("new ip from readiness probe", "remoteIP", podIP)
 		p.state.nodes[broadcastIP] = nodeState{}
 		p.state.podIPs[broadcastIP] = fmt.Sprintf("/%s", podIP)
 	}
+	p.stateLock.Unlock()
 
+	if !known {
+		if err := p.persistPodIPs(map[string]string{broadcastIP: fmt.Sprintf("/%s", podIP)}, nil); err != nil {
+			p.log.Errorf("can't persist discovered node %s: %s", broadcastIP, err.Error())
+		}
+	}
+
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
 	return p.isNodeReady(broadcastIP)
 }
 
-// isNodeReady checks if all nodes (including the one being checked) see the node as ready
+// isNodeReady checks if all nodes (including the one being checked) see the node as ready.
+// The caller must hold the state lock.
 func (p *Prober) isNodeReady(ip string) (bool, map[string]string) {
 	var peersUnreadyView []string // nodes that see the questioned node as not ready
 	var ignoredPeerNodes []string // nodes that are not ready, so we don't take their view into account
@@ -70,7 +82,11 @@ func (p *Prober) isNodeReady(ip string) (bool, map[string]string) {
 }
 
 func (p *Prober) updateNodeStates() {
-	if len(p.state.nodes) > 0 {
+	p.stateLock.RLock()
+	nodesCount := len(p.state.nodes)
+	p.stateLock.RUnlock()
+
+	if nodesCount > 0 {
 		p.updateNodesRequest()
 	} else {
 		p.log.Info("0 discovered nodes...")
@@ -80,6 +96,7 @@ func (p *Prober) updateNodeStates() {
 func (p *Prober) updateNodesRequest() {
 	responses := p.allNodesStates()
 
+	p.stateLock.Lock()
 	newNodeStates := make(map[string]nodeState)
 	for polledIP, nodeStateResponse := range responses {
 		newNodeState := nodeState{}
@@ -107,10 +124,12 @@ func (p *Prober) updateNodesRequest() {
 		newNodeStates[polledIP] = newNodeState
 	}
 
+	var removedNodes []string
 	for ip, newNodeState := range newNodeStates {
 		if newNodeState.DC == "" {
 			p.log.Infow("removing unreferenced node", "ip", ip)
 			delete(newNodeStates, ip)
+			removedNodes = append(removedNodes, ip)
 			continue
 		}
 		newNodeStates[ip] = newNodeState
@@ -121,13 +140,26 @@ func (p *Prober) updateNodesRequest() {
 		p.log.Debug(cmp.Diff(p.state.nodes, newNodeStates))
 		p.state.nodes = newNodeStates
 	}
+	p.stateLock.Unlock()
+
+	if err := p.persistPodIPs(nil, removedNodes); err != nil {
+		p.log.Errorf("can't remove unreferenced nodes from shared state: %s", err.Error())
+	}
 }
 
 // allNodesStates returns JMX response for each discovered node, including failed requests
 func (p *Prober) allNodesStates() map[string]jolokia.CassandraResponse {
-	responses := make(map[string]jolokia.CassandraResponse)
+	// the nodes are polled without holding the lock, so that the readiness checks aren't blocked by slow nodes
+	p.stateLock.RLock()
+	podIPs := make(map[string]string, len(p.state.nodes))
 	for nodeIP := range p.state.nodes {
-		response, err := p.jolokia.CassandraNodeState(p.state.podIPs[nodeIP])
+		podIPs[nodeIP] = p.state.podIPs[nodeIP]
+	}
+	p.stateLock.RUnlock()
+
+	responses := make(map[string]jolokia.CassandraResponse)
+	for nodeIP, podIP := range podIPs {
+		response, err := p.jolokia.CassandraNodeState(podIP)
 		if err != nil {
 			p.log.Errorf("jolokia request for IP %q failed: %s", nodeIP, err.Error())
 			response = jolokia.CassandraResponse{
@@ -146,6 +178,7 @@ func (p *Prober) allNodesStates() map[string]jolokia.CassandraResponse {
 	return responses
 }
 
+// ownedDC checks if the DC is owned by the prober. The caller must hold the state lock.
 func (p *Prober) ownedDC(dc string) bool {
 	for _, ownedDC := range p.state.dcs {
 		if ownedDC.Name == dc {
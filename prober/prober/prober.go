@@ -3,6 +3,7 @@ package prober
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -17,9 +18,11 @@ type Prober struct {
 	cfg        config.Config
 	jolokia    jolokia.Jolokia
 	auth       UserAuth
-	kubeClient *kubernetes.Clientset
+	kubeClient kubernetes.Interface
 	log        *zap.SugaredLogger
 	state      state
+	// stateLock guards the state written by the HTTP handlers, the node polling and the shared state watch
+	stateLock sync.RWMutex
 }
 
 type state struct {
@@ -43,7 +46,7 @@ type UserAuth struct {
 	Password string
 }
 
-func NewProber(cfg config.Config, jolokiaClient jolokia.Jolokia, auth UserAuth, clientset *kubernetes.Clientset, logr *zap.SugaredLogger) *Prober {
+func NewProber(cfg config.Config, jolokiaClient jolokia.Jolokia, auth UserAuth, clientset kubernetes.Interface, logr *zap.SugaredLogger) *Prober {
 	return &Prober{
 		cfg:        cfg,
 		jolokia:    jolokiaClient,
@@ -67,6 +70,14 @@ func (p *Prober) Run() error {
 	baseSecretCh := p.WatchBaseSecret()
 	defer close(baseSecretCh)
 
+	if len(p.cfg.StateConfigMapName) > 0 {
+		stateCh := p.WatchStateConfigMap()
+		defer close(stateCh)
+
+		// poll nodes known from the shared state before serving readiness checks
+		p.updateNodeStates()
+	}
+
 	go p.pollNodeStates()
 
 	p.log.Infow("Cassandra's prober listening", "serverPort", p.cfg.ServerPort)
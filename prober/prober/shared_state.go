@@ -0,0 +1,204 @@
+package prober
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the state ConfigMap shared between prober replicas
+const (
+	stateKeySeeds       = "seeds"
+	stateKeyRegionReady = "region-ready"
+	stateKeyReaperReady = "reaper-ready"
+	stateKeyDCs         = "dcs"
+	stateKeyRegionIPs   = "region-ips"
+	stateKeyReaperIPs   = "reaper-ips"
+	stateKeyPodIPs      = "pod-ips"
+)
+
+// WatchStateConfigMap keeps the local state in sync with the state persisted by all prober replicas.
+// It blocks until the initial state is loaded so that a new replica doesn't serve requests with an empty state.
+func (p *Prober) WatchStateConfigMap() chan struct{} {
+	p.log.Info("Watching ConfigMap " + p.cfg.StateConfigMapName + "...")
+
+	watchList := cache.NewListWatchFromClient(
+		p.kubeClient.CoreV1().RESTClient(),
+		v1.ResourceConfigMaps.String(),
+		p.cfg.PodNamespace,
+		fields.OneTermEqualSelector("metadata.name", p.cfg.StateConfigMapName),
+	)
+
+	_, controller := cache.NewInformer(
+		watchList,
+		&v1.ConfigMap{},
+		time.Second*1,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: p.handleAddStateConfigMap,
+			UpdateFunc: func(_, newObj interface{}) {
+				p.handleAddStateConfigMap(newObj)
+			},
+		})
+	stopCh := make(chan struct{})
+	go controller.Run(stopCh)
+	cache.WaitForCacheSync(stopCh, controller.HasSynced)
+	return stopCh
+}
+
+func (p *Prober) handleAddStateConfigMap(obj interface{}) {
+	cm := obj.(*v1.ConfigMap)
+	p.loadSharedState(cm.Data)
+}
+
+// loadSharedState replaces the local state with the state persisted by the prober replicas.
+// Values that can't be parsed are left as they are.
+func (p *Prober) loadSharedState(data map[string]string) {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+
+	if value, ok := data[stateKeySeeds]; ok {
+		var seeds []string
+		if err := json.Unmarshal([]byte(value), &seeds); err != nil {
+			p.log.Errorf("can't parse shared seeds state: %s", err.Error())
+		} else {
+			p.state.seeds = seeds
+		}
+	}
+
+	if value, ok := data[stateKeyRegionReady]; ok {
+		if ready, err := strconv.ParseBool(value); err != nil {
+			p.log.Errorf("can't parse shared region readiness state: %s", err.Error())
+		} else {
+			p.state.regionReady = ready
+		}
+	}
+
+	if value, ok := data[stateKeyReaperReady]; ok {
+		if ready, err := strconv.ParseBool(value); err != nil {
+			p.log.Errorf("can't parse shared reaper readiness state: %s", err.Error())
+		} else {
+			p.state.reaperReady = ready
+		}
+	}
+
+	if value, ok := data[stateKeyDCs]; ok {
+		var dcs []dc
+		if err := json.Unmarshal([]byte(value), &dcs); err != nil {
+			p.log.Errorf("can't parse shared dcs state: %s", err.Error())
+		} else {
+			p.state.dcs = dcs
+		}
+	}
+
+	if value, ok := data[stateKeyRegionIPs]; ok {
+		var ips []string
+		if err := json.Unmarshal([]byte(value), &ips); err != nil {
+			p.log.Errorf("can't parse shared region ips state: %s", err.Error())
+		} else {
+			p.state.regionIPs = ips
+		}
+	}
+
+	if value, ok := data[stateKeyReaperIPs]; ok {
+		var ips []string
+		if err := json.Unmarshal([]byte(value), &ips); err != nil {
+			p.log.Errorf("can't parse shared reaper ips state: %s", err.Error())
+		} else {
+			p.state.reaperIPs = ips
+		}
+	}
+
+	if value, ok := data[stateKeyPodIPs]; ok {
+		podIPs := make(map[string]string)
+		if err := json.Unmarshal([]byte(value), &podIPs); err != nil {
+			p.log.Errorf("can't parse shared pod ips state: %s", err.Error())
+		} else {
+			// the polled states of the known nodes are kept, nodes removed by other replicas are dropped
+			nodes := make(map[string]nodeState, len(podIPs))
+			for broadcastIP := range podIPs {
+				if node, known := p.state.nodes[broadcastIP]; known {
+					nodes[broadcastIP] = node
+				} else {
+					p.log.Infow("new ip from shared state", "broadcastIP", broadcastIP)
+					nodes[broadcastIP] = nodeState{}
+				}
+			}
+			for broadcastIP := range p.state.nodes {
+				if _, shared := podIPs[broadcastIP]; !shared {
+					p.log.Infow("removing node dropped from shared state", "broadcastIP", broadcastIP)
+				}
+			}
+			p.state.nodes = nodes
+			p.state.podIPs = podIPs
+		}
+	}
+}
+
+// persistState saves a state value in the ConfigMap shared between prober replicas
+func (p *Prober) persistState(key string, value interface{}) error {
+	if len(p.cfg.StateConfigMapName) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return p.updateStateConfigMap(func(cm *v1.ConfigMap) {
+		cm.Data[key] = string(data)
+	})
+}
+
+// persistPodIPs merges discovered and removed nodes into the shared state.
+// Replicas discover nodes independently, so the persisted list is never overwritten as a whole.
+func (p *Prober) persistPodIPs(added map[string]string, removed []string) error {
+	if len(p.cfg.StateConfigMapName) == 0 || (len(added) == 0 && len(removed) == 0) {
+		return nil
+	}
+
+	return p.updateStateConfigMap(func(cm *v1.ConfigMap) {
+		podIPs := make(map[string]string)
+		if value, ok := cm.Data[stateKeyPodIPs]; ok {
+			if err := json.Unmarshal([]byte(value), &podIPs); err != nil {
+				p.log.Errorf("can't parse shared pod ips state, overwriting it: %s", err.Error())
+			}
+		}
+
+		for broadcastIP, podIP := range added {
+			podIPs[broadcastIP] = podIP
+		}
+
+		for _, broadcastIP := range removed {
+			delete(podIPs, broadcastIP)
+		}
+
+		data, _ := json.Marshal(podIPs)
+		cm.Data[stateKeyPodIPs] = string(data)
+	})
+}
+
+func (p *Prober) updateStateConfigMap(mutate func(cm *v1.ConfigMap)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := p.kubeClient.CoreV1().ConfigMaps(p.cfg.PodNamespace).Get(context.Background(), p.cfg.StateConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+
+		mutate(cm)
+
+		_, err = p.kubeClient.CoreV1().ConfigMaps(p.cfg.PodNamespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+		return err
+	})
+}
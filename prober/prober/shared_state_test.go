@@ -0,0 +1,94 @@
+package prober
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ibm/cassandra-operator/prober/config"
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPersistState(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	kubeClient := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prober-state", Namespace: "default"},
+		Data: map[string]string{
+			stateKeyPodIPs: `{"/10.0.0.1":"/10.0.0.1","/10.0.0.2":"/10.0.0.2"}`,
+		},
+	})
+	testProber := &Prober{
+		cfg:        config.Config{PodNamespace: "default", StateConfigMapName: "prober-state"},
+		kubeClient: kubeClient,
+		log:        zap.NewNop().Sugar(),
+	}
+
+	asserts.Expect(testProber.persistState(stateKeySeeds, []string{"10.0.0.1"})).To(gomega.Succeed())
+	asserts.Expect(testProber.persistState(stateKeyRegionReady, true)).To(gomega.Succeed())
+	asserts.Expect(testProber.persistState(stateKeyDCs, []dc{{Name: "dc1", Replicas: 3}})).To(gomega.Succeed())
+	asserts.Expect(testProber.persistPodIPs(map[string]string{"/10.0.0.3": "/10.0.0.3"}, []string{"/10.0.0.2"})).To(gomega.Succeed())
+
+	cm, err := kubeClient.CoreV1().ConfigMaps("default").Get(context.Background(), "prober-state", metav1.GetOptions{})
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	asserts.Expect(cm.Data).To(gomega.Equal(map[string]string{
+		stateKeySeeds:       `["10.0.0.1"]`,
+		stateKeyRegionReady: "true",
+		stateKeyDCs:         `[{"name":"dc1","replicas":3}]`,
+		stateKeyPodIPs:      `{"/10.0.0.1":"/10.0.0.1","/10.0.0.3":"/10.0.0.3"}`,
+	}))
+}
+
+func TestPersistStateDisabled(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	testProber := &Prober{
+		log: zap.NewNop().Sugar(),
+	}
+
+	asserts.Expect(testProber.persistState(stateKeySeeds, []string{"10.0.0.1"})).To(gomega.Succeed())
+	asserts.Expect(testProber.persistPodIPs(map[string]string{"/10.0.0.3": "/10.0.0.3"}, nil)).To(gomega.Succeed())
+}
+
+func TestLoadSharedState(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	testProber := &Prober{
+		log: zap.NewNop().Sugar(),
+		state: state{
+			seeds: []string{"10.0.0.5"},
+			nodes: map[string]nodeState{
+				"/10.0.0.1": {SimpleStates: map[string]string{"/10.0.0.1": "UP"}},
+				"/10.0.0.3": {SimpleStates: map[string]string{"/10.0.0.3": "UP"}},
+			},
+			podIPs: map[string]string{
+				"/10.0.0.1": "/10.0.0.1",
+				"/10.0.0.3": "/10.0.0.3",
+			},
+		},
+	}
+
+	testProber.loadSharedState(map[string]string{
+		stateKeySeeds:       `["10.0.0.1","10.0.0.2"]`,
+		stateKeyRegionReady: "true",
+		stateKeyReaperReady: "invalid",
+		stateKeyDCs:         `[{"name":"dc1","replicas":3}]`,
+		stateKeyRegionIPs:   `["10.1.0.1"]`,
+		stateKeyPodIPs:      `{"/10.0.0.1":"/10.0.0.1","/10.0.0.2":"/10.0.2.2"}`,
+	})
+
+	asserts.Expect(testProber.state.seeds).To(gomega.Equal([]string{"10.0.0.1", "10.0.0.2"}))
+	asserts.Expect(testProber.state.regionReady).To(gomega.BeTrue())
+	asserts.Expect(testProber.state.reaperReady).To(gomega.BeFalse())
+	asserts.Expect(testProber.state.dcs).To(gomega.Equal([]dc{{Name: "dc1", Replicas: 3}}))
+	asserts.Expect(testProber.state.regionIPs).To(gomega.Equal([]string{"10.1.0.1"}))
+	asserts.Expect(testProber.state.reaperIPs).To(gomega.BeNil())
+	asserts.Expect(testProber.state.nodes).To(gomega.Equal(map[string]nodeState{
+		"/10.0.0.1": {SimpleStates: map[string]string{"/10.0.0.1": "UP"}}, // known node keeps its polled state
+		"/10.0.0.2": {},
+	})) // node removed by another replica is dropped
+	asserts.Expect(testProber.state.podIPs).To(gomega.Equal(map[string]string{
+		"/10.0.0.1": "/10.0.0.1",
+		"/10.0.0.2": "/10.0.2.2",
+	}))
+}
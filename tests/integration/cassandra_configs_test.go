@@ -9,9 +9,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/yaml"
 	"strings"
+	"time"
 )
 
 var _ = Describe("cassandra cluster configs", func() {
@@ -27,7 +30,7 @@ var _ = Describe("cassandra cluster configs", func() {
 			Cassandra: &v1alpha1.Cassandra{
 				ConfigOverrides: `concurrent_reads: 40
 concurrent_writes: 45
-non_existing_config_in_default_file: something
+concurrent_compactors: 4
 back_pressure_strategy:
 - class_name: org.apache.cassandra.net.RateBasedBackPressure
   parameters:
@@ -53,7 +56,7 @@ back_pressure_strategy:
 
 		Expect(cassandraYaml).To(HaveKeyWithValue("concurrent_reads", float64(40)))
 		Expect(cassandraYaml).To(HaveKeyWithValue("concurrent_writes", float64(45)))
-		Expect(cassandraYaml).To(HaveKeyWithValue("non_existing_config_in_default_file", "something"))
+		Expect(cassandraYaml).To(HaveKeyWithValue("concurrent_compactors", float64(4)))
 		Expect(cassandraYaml).To(HaveKeyWithValue("back_pressure_strategy", []interface{}{
 			map[string]interface{}{
 				"class_name": "org.apache.cassandra.net.RateBasedBackPressure",
@@ -69,6 +72,47 @@ back_pressure_strategy:
 	})
 })
 
+var _ = Describe("cassandra 4.1 configs", func() {
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			Cassandra: &v1alpha1.Cassandra{
+				Image: "cassandra:4.1.3",
+				Config: &v1alpha1.CassandraConfig{
+					ConcurrentReads: proto.Int32(64),
+					MaxHintWindow:   &metav1.Duration{Duration: time.Hour},
+				},
+				ConfigOverrides: `commitlog_segment_size: 64MiB`,
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	It("should use the settings names of the version", func() {
+		createReadyCluster(cc)
+
+		cm := &v1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ConfigMap(cc.Name), Namespace: cc.Namespace}, cm)).To(Succeed())
+		cassandraYaml := make(map[string]interface{})
+		Expect(yaml.Unmarshal([]byte(cm.Data["cassandra.yaml"]), &cassandraYaml)).To(Succeed())
+
+		Expect(cassandraYaml).To(HaveKeyWithValue("concurrent_reads", float64(64)))
+		Expect(cassandraYaml).To(HaveKeyWithValue("max_hint_window", "3600000ms"))
+		Expect(cassandraYaml).To(HaveKeyWithValue("commitlog_segment_size", "64MiB"))
+		Expect(cassandraYaml).To(HaveKeyWithValue("hinted_handoff_throttle", "1024KiB"))
+		Expect(cassandraYaml).ToNot(HaveKey("max_hint_window_in_ms"))
+		Expect(cassandraYaml).ToNot(HaveKey("commitlog_segment_size_in_mb"))
+		Expect(cassandraYaml).ToNot(HaveKey("rpc_port"))
+	})
+})
+
 var _ = Describe("cassandra jvm configs", func() {
 	cc := &v1alpha1.CassandraCluster{
 		ObjectMeta: cassandraObjectMeta,
@@ -114,3 +158,81 @@ var _ = Describe("cassandra jvm configs", func() {
 		}
 	})
 })
+
+var _ = Describe("cassandra jvm configs derived from the container resources", func() {
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			Cassandra: &v1alpha1.Cassandra{
+				Image: "cassandra:3.11.13",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi"), v1.ResourceCPU: resource.MustParse("2")},
+					Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+				JVMOptions: []string{"-Xmn400M"},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	It("should set the heap sizes not set by the user", func() {
+		createReadyCluster(cc)
+
+		cm := &v1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ConfigMap(cc.Name), Namespace: cc.Namespace}, cm)).To(Succeed())
+		jvmOptions := strings.Split(cm.Data["jvm.options"], "\n")
+		Expect(jvmOptions).To(ContainElements("-Xmx2G", "-Xms1G", "-Xmn400M", "-XX:+UseConcMarkSweepGC"))
+		Expect(jvmOptions).ToNot(ContainElement("-Xmn200M"))
+	})
+})
+
+var _ = Describe("cassandra logging configs", func() {
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			Cassandra: &v1alpha1.Cassandra{
+				Image: "cassandra:4.0.7",
+				Logging: &v1alpha1.CassandraLogging{
+					Loggers:  map[string]v1alpha1.LoggerLevel{"org.apache.cassandra.gms": "DEBUG"},
+					Encoder:  v1alpha1.LogEncoderJSON,
+					AuditLog: true,
+					GCLog:    true,
+				},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	It("should be rendered into logback.xml and applied to the nodes", func() {
+		createReadyCluster(cc)
+
+		cm := &v1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ConfigMap(cc.Name), Namespace: cc.Namespace}, cm)).To(Succeed())
+		Expect(cm.Data["logback.xml"]).To(ContainSubstring(`<pattern>{"timestamp":`))
+		Expect(cm.Data["logback.xml"]).To(ContainSubstring(`<logger name="org.apache.cassandra.audit" level="INFO" additivity="false">`))
+		Expect(cm.Data["logback.xml"]).To(ContainSubstring(`<logger name="org.apache.cassandra.gms" level="DEBUG"/>`))
+		Expect(cm.Data["jvm.options"]).To(ContainSubstring("-Xlog:gc=info"))
+
+		for i := 0; i < 3; i++ {
+			Eventually(func() map[string]string {
+				levels, err := mockNodectlClient.LoggingLevels(ctx, fmt.Sprintf("172.0.0.%d", i))
+				Expect(err).ToNot(HaveOccurred())
+				return levels
+			}, longTimeout, mediumRetry).Should(HaveKeyWithValue("org.apache.cassandra.gms", "DEBUG"))
+		}
+	})
+})
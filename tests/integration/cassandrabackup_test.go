@@ -1,12 +1,20 @@
 package integration
 
 import (
+	"errors"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackup"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -63,15 +71,38 @@ var _ = Describe("created cassandrabackup", func() {
 			return cb.Status.Progress
 		}, mediumTimeout, mediumRetry).Should(Equal(0))
 
+		Expect(cb.Status.StartTime).ToNot(BeNil())
+		Expect(cb.Status.SchemaVersion).To(Equal("schema-1"))
+		Expect(meta.IsStatusConditionTrue(cb.Status.Conditions, v1alpha1.ConditionTypeProgressing)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(cb.Status.Conditions, v1alpha1.ConditionTypeStorageVerified)).To(BeTrue())
+		Expect(mockStorageClient.verifiedLocations).To(ConsistOf(cb.Spec.StorageLocation))
+
 		mockIcarusClient.backups[0].Progress = 0.43253
+		nodeBackup := mockIcarusClient.backups[0]
+		nodeBackup.GlobalRequest = false
+		nodeBackup.Progress = 0.5
+		nodeBackup.TransferredBytes = 512
+		nodeBackup.TotalBytes = 1024
+		mockIcarusClient.backups = append(mockIcarusClient.backups, nodeBackup)
 
 		Eventually(func() int {
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
 			return cb.Status.Progress
 		}, mediumTimeout, mediumRetry).Should(Equal(43))
+		Eventually(func() []v1alpha1.NodeProgress {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+			return cb.Status.Nodes
+		}, mediumTimeout, mediumRetry).Should(HaveLen(6))
+		Expect(cb.Status.Nodes[0].Name).To(Equal(names.DC(cc.Name, "dc1") + "-0"))
+		Expect(cb.Status.Nodes[0].State).To(Equal(icarus.StateRunning))
+		Expect(cb.Status.Nodes[0].Progress).To(Equal(50))
+		Expect(cb.Status.Nodes[0].TransferredBytes).To(Equal(int64(512)))
+		Expect(cb.Status.UploadedBytes).To(Equal(int64(6 * 512)))
+		Expect(cb.Status.TotalBytes).To(Equal(int64(6 * 1024)))
 
 		mockIcarusClient.backups[0].Progress = 1.0
 		mockIcarusClient.backups[0].State = icarus.StateCompleted
+		mockIcarusClient.backups[0].CompletionTime = time.Now().Format(time.RFC3339)
 
 		Eventually(func() string {
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
@@ -81,6 +112,9 @@ var _ = Describe("created cassandrabackup", func() {
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
 			return cb.Status.Progress
 		}, mediumTimeout, mediumRetry).Should(Equal(100))
+		Expect(cb.Status.CompletionTime).ToNot(BeNil())
+		Expect(meta.IsStatusConditionTrue(cb.Status.Conditions, v1alpha1.ConditionTypeComplete)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(cb.Status.Conditions, v1alpha1.ConditionTypeProgressing)).To(BeTrue())
 	})
 
 	Context("with failed backup", func() {
@@ -138,4 +172,302 @@ var _ = Describe("created cassandrabackup", func() {
 			}))
 		})
 	})
+	Context("with delete deletion policy", func() {
+		It("should remove the backup from the storage before the resource is deleted", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cb.Spec.DeletionPolicy = v1alpha1.DeletionPolicyDelete
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Finalizers
+			}, mediumTimeout, mediumRetry).Should(ContainElement(cassandrabackup.BackupFinalizer))
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.SnapshotTag
+			}, mediumTimeout, mediumRetry).Should(Equal(mockIcarusClient.backups[0].SnapshotTag))
+
+			Expect(k8sClient.Delete(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.RemoveBackup {
+				return mockIcarusClient.removedBackups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(mockIcarusClient.removedBackups[0].BackupName).To(Equal(cb.Status.SnapshotTag))
+			Expect(mockIcarusClient.removedBackups[0].StorageLocation).To(Equal("s3://bucket/" + cc.Name + "/dc1/1"))
+			Expect(mockIcarusClient.removedBackups[0].K8sSecretName).To(Equal(storageSecretTpl.Name))
+			Expect(mockIcarusClient.removedBackups[0].GlobalRequest).To(BeTrue())
+
+			Consistently(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)
+			}, shortTimeout, mediumRetry).Should(Succeed())
+
+			mockIcarusClient.removedBackups[0].State = icarus.StateCompleted
+			expectResourceIsDeleted(types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, &v1alpha1.CassandraBackup{})
+			Expect(deleteResource(types.NamespacedName{Name: storageSecretTpl.Name, Namespace: storageSecretTpl.Namespace}, &v1.Secret{})).To(Succeed())
+		})
+	})
+	Context("when cancelled", func() {
+		It("should cancel the icarus operations and block the deletion until they stop", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Finalizers
+			}, mediumTimeout, mediumRetry).Should(ContainElement(cassandrabackup.CancelFinalizer))
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+			mockIcarusClient.error = errors.New("icarus unavailable")
+			Expect(k8sClient.Delete(ctx, cb)).To(Succeed())
+			Consistently(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)
+			}, shortTimeout, mediumRetry).Should(Succeed())
+
+			mockIcarusClient.error = nil
+			expectResourceIsDeleted(types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, &v1alpha1.CassandraBackup{})
+			Expect(mockIcarusClient.cancelledOperations).To(ContainElement(mockIcarusClient.backups[0].ID))
+			Expect(mockIcarusClient.backups[0].State).To(Equal(icarus.StateCancelled))
+		})
+
+		It("should move the backup to the cancelled state", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				cb.Spec.Cancel = true
+				return k8sClient.Update(ctx, cb)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCancelled))
+			Expect(mockIcarusClient.backups[0].State).To(Equal(icarus.StateCancelled))
+			Expect(mockNodectlClient.clearedSnapshots).ToNot(BeEmpty())
+			for _, tags := range mockNodectlClient.clearedSnapshots {
+				Expect(tags).To(ContainElement(cb.Status.SnapshotTag))
+			}
+			Expect(meta.IsStatusConditionTrue(cb.Status.Conditions, v1alpha1.ConditionTypeProgressing)).To(BeFalse())
+			Expect(meta.IsStatusConditionTrue(cb.Status.Conditions, v1alpha1.ConditionTypeFailed)).To(BeFalse())
+			Eventually(func() []string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Finalizers
+			}, mediumTimeout, mediumRetry).ShouldNot(ContainElement(cassandrabackup.CancelFinalizer))
+		})
+	})
+
+	Context("when the coordinator becomes unavailable", func() {
+		It("should move the backup to another ready pod", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			firstPod := names.DC(cc.Name, "dc1") + "-0"
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.Coordinator
+			}, mediumTimeout, mediumRetry).Should(Equal(firstPod))
+
+			Eventually(func() error {
+				pod := &v1.Pod{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: firstPod}, pod)).To(Succeed())
+				pod.Status.ContainerStatuses[0].Ready = false
+				return k8sClient.Status().Update(ctx, pod)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.Coordinator
+			}, mediumTimeout, mediumRetry).Should(Equal(names.DC(cc.Name, "dc1") + "-1"))
+			Expect(cb.Status.State).To(Equal(icarus.StateRunning))
+		})
+	})
+
+	Context("when the storage rejects the credentials", func() {
+		It("should not start the backup until the credentials are fixed", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			mockStorageClient.err = &storage.Error{
+				StorageProvider: v1alpha1.StorageProviderS3,
+				StatusCode:      403,
+				Code:            "InvalidAccessKeyId",
+				Hint:            "Check awsaccesskeyid in secret storage-credentials",
+			}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return meta.IsStatusConditionFalse(cb.Status.Conditions, v1alpha1.ConditionTypeStorageVerified)
+			}, mediumTimeout, mediumRetry).Should(BeTrue())
+			condition := meta.FindStatusCondition(cb.Status.Conditions, v1alpha1.ConditionTypeStorageVerified)
+			Expect(condition.Reason).To(Equal(storage.ReasonVerificationFailed))
+			Expect(condition.Message).To(ContainSubstring("Check awsaccesskeyid"))
+			Consistently(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, shortTimeout, shortRetry).Should(BeEmpty())
+
+			mockStorageClient.err = nil
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return meta.IsStatusConditionTrue(cb.Status.Conditions, v1alpha1.ConditionTypeStorageVerified)
+			}, mediumTimeout, mediumRetry).Should(BeTrue())
+		})
+
+		It("should ignore a missing bucket if it's created by icarus", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cb.Spec.CreateMissingBucket = true
+			mockStorageClient.err = &storage.Error{StorageProvider: v1alpha1.StorageProviderS3, StatusCode: 404, Code: "NoSuchBucket"}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+		})
+
+		It("should not verify the storage if bucket verification is skipped", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cb.Spec.SkipBucketVerification = true
+			mockStorageClient.err = &storage.Error{StorageProvider: v1alpha1.StorageProviderS3, StatusCode: 403, Code: "AccessDenied"}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(mockStorageClient.verifiedLocations).To(BeEmpty())
+		})
+	})
+
+	Context("with file storage provider", func() {
+		It("should mount the backup volume and not require a secret", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.Icarus.BackupVolume = &v1alpha1.IcarusBackupVolume{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "backups"},
+			}
+			cb := cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = "file://daily"
+			cb.Spec.SecretName = ""
+			createReadyCluster(cc)
+
+			sts := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}, sts)).To(Succeed())
+			volume, found := getVolumeByName(sts.Spec.Template.Spec.Volumes, "icarus-backups")
+			Expect(found).To(BeTrue())
+			Expect(volume.PersistentVolumeClaim).To(Equal(cc.Spec.Icarus.BackupVolume.PersistentVolumeClaim))
+			icarusContainer, found := getContainerByName(sts.Spec.Template.Spec, "icarus")
+			Expect(found).To(BeTrue())
+			Expect(icarusContainer.VolumeMounts).To(ContainElement(v1.VolumeMount{Name: "icarus-backups", MountPath: v1alpha1.IcarusBackupVolumeMountPath}))
+
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(mockIcarusClient.backups[0].StorageLocation).To(Equal("file://" + v1alpha1.IcarusBackupVolumeMountPath + "/daily/" + cc.Name + "/dc1/1"))
+			Expect(mockIcarusClient.backups[0].K8sSecretName).To(BeEmpty())
+		})
+
+		It("should require a storage location in the backup volume", func() {
+			cb := cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = "file://"
+			cb.Spec.SecretName = ""
+			Expect(k8sClient.Create(ctx, cb)).ToNot(Succeed())
+		})
+	})
+
+	Context("with encryption", func() {
+		It("should pass the key of the secret to icarus", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cb.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "backup-key", KeyType: v1alpha1.EncryptionKeyTypeKeyEncryptionKey}
+			encryptionSecret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup-key", Namespace: cc.Namespace},
+				Data:       map[string][]byte{"key": []byte("0123456789abcdef0123456789abcdef")},
+			}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, encryptionSecret)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(deleteResource(types.NamespacedName{Name: encryptionSecret.Name, Namespace: encryptionSecret.Namespace}, &v1.Secret{})).To(Succeed())
+			})
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(mockIcarusClient.backups[0].Encryption).To(Equal(&icarus.Encryption{
+				K8sSecretName: "backup-key",
+				K8sSecretKey:  "key",
+				KeyType:       "KeyEncryptionKey",
+			}))
+		})
+
+		It("should not allow changing the encryption", func() {
+			cb := cbTpl.DeepCopy()
+			cb.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "backup-key"}
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+			cb.Spec.Encryption = nil
+			Expect(k8sClient.Update(ctx, cb)).ToNot(Succeed())
+		})
+	})
+
+	Context("with VolumeSnapshot mode", func() {
+		It("should not require a storage location", func() {
+			cb := cbTpl.DeepCopy()
+			cb.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot
+			cb.Spec.StorageLocation = ""
+			cb.Spec.SecretName = ""
+			cb.Spec.VolumeSnapshotClassName = "csi-snapclass"
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+		})
+
+		It("should not allow a storage location or a partial backup", func() {
+			cb := cbTpl.DeepCopy()
+			cb.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot
+			Expect(k8sClient.Create(ctx, cb)).ToNot(Succeed())
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot
+			cb.Spec.StorageLocation = ""
+			cb.Spec.SecretName = ""
+			cb.Spec.Entities = "ks1"
+			Expect(k8sClient.Create(ctx, cb)).ToNot(Succeed())
+		})
+
+		It("should not allow a volume snapshot class in the Icarus mode", func() {
+			cb := cbTpl.DeepCopy()
+			cb.Spec.VolumeSnapshotClassName = "csi-snapclass"
+			Expect(k8sClient.Create(ctx, cb)).ToNot(Succeed())
+		})
+	})
 })
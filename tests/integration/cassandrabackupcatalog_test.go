@@ -0,0 +1,199 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("created cassandrabackupcatalog", func() {
+	ccTpl := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	storageSecretTpl := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-credentials", Namespace: cassandraObjectMeta.Namespace},
+		Data: map[string][]byte{
+			"awsaccesskeyid":     []byte("key-id"),
+			"awssecretaccesskey": []byte("access-key"),
+			"awsregion":          []byte("us-east"),
+		},
+	}
+
+	cbcTpl := &v1alpha1.CassandraBackupCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cassandraObjectMeta.Namespace,
+			Name:      "test-backup-catalog",
+		},
+		Spec: v1alpha1.CassandraBackupCatalogSpec{
+			CassandraCluster: cassandraObjectMeta.Name,
+			StorageLocation:  "s3://bucket",
+			SecretName:       storageSecretTpl.Name,
+			SourceCluster:    "prod",
+		},
+	}
+
+	It("should list the backups of each node and restore from the catalog", func() {
+		cc := ccTpl.DeepCopy()
+		cbc := cbcTpl.DeepCopy()
+		createReadyCluster(cc)
+		Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+		DeferCleanup(func() {
+			Expect(deleteResource(types.NamespacedName{Name: storageSecretTpl.Name, Namespace: storageSecretTpl.Namespace}, &v1.Secret{})).To(Succeed())
+		})
+		Expect(k8sClient.Create(ctx, cbc)).To(Succeed())
+
+		Eventually(func() []icarus.List {
+			return mockIcarusClient.lists
+		}, mediumTimeout, mediumRetry).Should(HaveLen(3))
+
+		for i := range mockIcarusClient.lists {
+			Expect(mockIcarusClient.lists[i].StorageLocation).To(HavePrefix("s3://bucket/prod/dc1/prod-cassandra-dc1-"))
+			Expect(mockIcarusClient.lists[i].K8sSecretName).To(Equal(storageSecretTpl.Name))
+			mockIcarusClient.lists[i].Response.Reports = []icarus.ManifestReport{
+				{Name: "daily-schema1-1664000000000", SchemaVersion: "schema1", Size: 100, UnixTimestamp: 1664000000000},
+			}
+			mockIcarusClient.lists[i].Progress = 1.0
+			mockIcarusClient.lists[i].State = icarus.StateCompleted
+		}
+
+		Eventually(func() []v1alpha1.CatalogBackup {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cbc.Namespace, Name: cbc.Name}, cbc)).To(Succeed())
+			return cbc.Status.Backups
+		}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+		backup := cbc.Status.Backups[0]
+		Expect(backup.SnapshotTag).To(Equal("daily"))
+		Expect(backup.SchemaVersion).To(Equal("schema1"))
+		Expect(backup.Size).To(Equal(int64(300)))
+		Expect(backup.DCs).To(HaveLen(1))
+		Expect(backup.DCs[0].Nodes).To(HaveLen(3))
+		Expect(cbc.Status.LastSyncTime).ToNot(BeNil())
+		Expect(meta.IsStatusConditionTrue(cbc.Status.Conditions, v1alpha1.ConditionTypeSynced)).To(BeTrue())
+
+		cr := &v1alpha1.CassandraRestore{
+			ObjectMeta: cassandraRestoreObjectMeta,
+			Spec: v1alpha1.CassandraRestoreSpec{
+				CassandraCluster: cassandraObjectMeta.Name,
+				BackupCatalog: &v1alpha1.BackupCatalogReference{
+					Name:        cbc.Name,
+					SnapshotTag: "daily",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+		Eventually(func() []icarus.Restore {
+			return mockIcarusClient.restores
+		}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+		Expect(mockIcarusClient.restores[0].SnapshotTag).To(Equal(backup.Name))
+		Expect(mockIcarusClient.restores[0].StorageLocation).To(HavePrefix(cbc.Spec.StorageLocation))
+	})
+
+	Context("when the storage can be listed", func() {
+		It("should list the backups of the nodes found in the storage", func() {
+			cc := ccTpl.DeepCopy()
+			cbc := cbcTpl.DeepCopy()
+			mockStorageClient.dirs = map[string][]string{
+				"s3://bucket/prod":     {"dc1", "dc2"},
+				"s3://bucket/prod/dc1": {"prod-cassandra-dc1-0"},
+				"s3://bucket/prod/dc2": {"prod-cassandra-dc2-0", "prod-cassandra-dc2-1"},
+			}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			DeferCleanup(func() {
+				Expect(deleteResource(types.NamespacedName{Name: storageSecretTpl.Name, Namespace: storageSecretTpl.Namespace}, &v1.Secret{})).To(Succeed())
+			})
+			Expect(k8sClient.Create(ctx, cbc)).To(Succeed())
+
+			Eventually(func() []icarus.List {
+				return mockIcarusClient.lists
+			}, mediumTimeout, mediumRetry).Should(HaveLen(3))
+
+			var storageLocations []string
+			for i := range mockIcarusClient.lists {
+				storageLocations = append(storageLocations, mockIcarusClient.lists[i].StorageLocation)
+				mockIcarusClient.lists[i].Response.Reports = []icarus.ManifestReport{
+					{Name: "daily-schema1-1664000000000", SchemaVersion: "schema1", Size: 100, UnixTimestamp: 1664000000000},
+				}
+				mockIcarusClient.lists[i].State = icarus.StateCompleted
+			}
+			Expect(storageLocations).To(ConsistOf(
+				"s3://bucket/prod/dc1/prod-cassandra-dc1-0",
+				"s3://bucket/prod/dc2/prod-cassandra-dc2-0",
+				"s3://bucket/prod/dc2/prod-cassandra-dc2-1",
+			))
+
+			Eventually(func() []v1alpha1.CatalogBackup {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cbc.Namespace, Name: cbc.Name}, cbc)).To(Succeed())
+				return cbc.Status.Backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(cbc.Status.Backups[0].DCs).To(Equal([]v1alpha1.CatalogDC{
+				{Name: "dc1", Nodes: []string{"prod-cassandra-dc1-0"}},
+				{Name: "dc2", Nodes: []string{"prod-cassandra-dc2-0", "prod-cassandra-dc2-1"}},
+			}))
+		})
+	})
+
+	Context("when a node can't be listed", func() {
+		It("should report the failure and keep the catalog", func() {
+			cc := ccTpl.DeepCopy()
+			cbc := cbcTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			DeferCleanup(func() {
+				Expect(deleteResource(types.NamespacedName{Name: storageSecretTpl.Name, Namespace: storageSecretTpl.Namespace}, &v1.Secret{})).To(Succeed())
+			})
+			Expect(k8sClient.Create(ctx, cbc)).To(Succeed())
+
+			Eventually(func() []icarus.List {
+				return mockIcarusClient.lists
+			}, mediumTimeout, mediumRetry).Should(HaveLen(3))
+
+			for i := range mockIcarusClient.lists {
+				mockIcarusClient.lists[i].State = icarus.StateCompleted
+			}
+			mockIcarusClient.lists[0].State = icarus.StateFailed
+			mockIcarusClient.lists[0].Errors = []icarus.Error{{Source: "prod-cassandra-dc1-rack1-0", Message: "access denied"}}
+
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cbc.Namespace, Name: cbc.Name}, cbc)).To(Succeed())
+				return meta.IsStatusConditionFalse(cbc.Status.Conditions, v1alpha1.ConditionTypeSynced)
+			}, mediumTimeout, mediumRetry).Should(BeTrue())
+			Expect(cbc.Status.Backups).To(BeEmpty())
+			Expect(meta.FindStatusCondition(cbc.Status.Conditions, v1alpha1.ConditionTypeSynced).Message).To(ContainSubstring("access denied"))
+		})
+	})
+
+	Context("with a non positive refresh interval", func() {
+		It("should not pass validation", func() {
+			cbc := cbcTpl.DeepCopy()
+			cbc.Spec.RefreshInterval = &metav1.Duration{}
+			Expect(k8sClient.Create(ctx, cbc)).ToNot(Succeed())
+		})
+	})
+
+	Context("with invalid storage location", func() {
+		It("should not pass validation", func() {
+			cbc := cbcTpl.DeepCopy()
+			cbc.Spec.StorageLocation = "bucket"
+			Expect(k8sClient.Create(ctx, cbc)).ToNot(Succeed())
+		})
+	})
+})
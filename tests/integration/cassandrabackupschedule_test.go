@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("created cassandrabackupschedule", func() {
+	ccTpl := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	storageSecretTpl := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-credentials", Namespace: cassandraObjectMeta.Namespace},
+		Data: map[string][]byte{
+			"awsaccesskeyid":     []byte("key-id"),
+			"awssecretaccesskey": []byte("access-key"),
+			"awsregion":          []byte("us-east"),
+		},
+	}
+
+	cbsTpl := &v1alpha1.CassandraBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cassandraObjectMeta.Namespace,
+			Name:      "test-backup-schedule",
+		},
+		Spec: v1alpha1.CassandraBackupScheduleSpec{
+			Schedule: "0 3 * * *",
+			BackupTemplate: v1alpha1.CassandraBackupSpec{
+				CassandraCluster: cassandraObjectMeta.Name,
+				StorageLocation:  "s3://bucket",
+				SecretName:       storageSecretTpl.Name,
+				SnapshotTag:      "daily",
+			},
+		},
+	}
+
+	It("should create timestamped backups and report the result", func() {
+		cc := ccTpl.DeepCopy()
+		cbs := cbsTpl.DeepCopy()
+		createReadyCluster(cc)
+		Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+		DeferCleanup(func() {
+			Expect(deleteResource(types.NamespacedName{Name: storageSecretTpl.Name, Namespace: storageSecretTpl.Namespace}, &v1.Secret{})).To(Succeed())
+		})
+
+		clockShift = 24 * time.Hour // the next scheduled run is missed
+		Expect(k8sClient.Create(ctx, cbs)).To(Succeed())
+
+		backups := &v1alpha1.CassandraBackupList{}
+		Eventually(func() []v1alpha1.CassandraBackup {
+			Expect(k8sClient.List(ctx, backups, client.InNamespace(cbs.Namespace), client.MatchingLabels{v1alpha1.CassandraBackupScheduleLabel: cbs.Name})).To(Succeed())
+			return backups.Items
+		}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+		backup := backups.Items[0]
+		Expect(strings.HasPrefix(backup.Name, cbs.Name+"-")).To(BeTrue())
+		Expect(backup.Spec.SnapshotTag).To(Equal("daily-" + strings.TrimPrefix(backup.Name, cbs.Name+"-")))
+		Expect(backup.Spec.StorageLocation).To(Equal(cbs.Spec.BackupTemplate.StorageLocation))
+		Expect(backup.Annotations).To(HaveKey(v1alpha1.CassandraBackupScheduledAtAnnotation))
+		Expect(backup.OwnerReferences[0].Controller).To(Equal(proto.Bool(true)))
+		Expect(backup.OwnerReferences[0].Kind).To(Equal("CassandraBackupSchedule"))
+		Expect(backup.OwnerReferences[0].Name).To(Equal(cbs.Name))
+
+		Eventually(func() []string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cbs.Namespace, Name: cbs.Name}, cbs)).To(Succeed())
+			return cbs.Status.Active
+		}, mediumTimeout, mediumRetry).Should(Equal([]string{backup.Name}))
+		Expect(cbs.Status.LastScheduleTime).ToNot(BeNil())
+		Expect(cbs.Status.NextScheduleTime).ToNot(BeNil())
+
+		Eventually(func() []icarus.Backup {
+			return mockIcarusClient.backups
+		}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+		Expect(mockIcarusClient.backups[0].SnapshotTag).To(Equal(backup.Spec.SnapshotTag))
+
+		mockIcarusClient.backups[0].Progress = 1.0
+		mockIcarusClient.backups[0].State = icarus.StateCompleted
+
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cbs.Namespace, Name: cbs.Name}, cbs)).To(Succeed())
+			return cbs.Status.LastSuccessfulBackup
+		}, mediumTimeout, mediumRetry).Should(Equal(backup.Name))
+		Expect(cbs.Status.LastSuccessfulTime).ToNot(BeNil())
+		Expect(cbs.Status.Active).To(BeEmpty())
+	})
+
+	Context("when suspended", func() {
+		It("should not create backups", func() {
+			cbs := cbsTpl.DeepCopy()
+			cbs.Spec.Suspend = true
+			clockShift = 24 * time.Hour
+			Expect(k8sClient.Create(ctx, cbs)).To(Succeed())
+
+			backups := &v1alpha1.CassandraBackupList{}
+			Consistently(func() []v1alpha1.CassandraBackup {
+				Expect(k8sClient.List(ctx, backups, client.InNamespace(cbs.Namespace), client.MatchingLabels{v1alpha1.CassandraBackupScheduleLabel: cbs.Name})).To(Succeed())
+				return backups.Items
+			}, shortTimeout, mediumRetry).Should(BeEmpty())
+		})
+	})
+
+	Context("with invalid schedule", func() {
+		It("should not pass validation", func() {
+			cbs := cbsTpl.DeepCopy()
+			cbs.Spec.Schedule = "0 3 * *"
+			Expect(k8sClient.Create(ctx, cbs)).ToNot(Succeed())
+		})
+	})
+})
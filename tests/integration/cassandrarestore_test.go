@@ -1,14 +1,25 @@
 package integration
 
 import (
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cassandrarestore"
+	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("created cassandrarestore", func() {
@@ -164,4 +175,480 @@ var _ = Describe("created cassandrarestore", func() {
 			}))
 		})
 	})
+
+	Context("with restorePointInTime", func() {
+		It("should restore the latest backup and replay the archived commit logs", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{
+					StorageLocation: "s3://bucket",
+					SecretName:      storageSecretTpl.Name,
+				},
+			}
+			cr := crTpl.DeepCopy()
+			cr.Spec.CassandraBackup = ""
+			cr.Spec.RestorePointInTime = &metav1.Time{Time: time.Now().Add(time.Hour).Truncate(time.Second)}
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+
+			firstPod := names.DC(cc.Name, cc.Spec.DCs[0].Name) + "-0"
+			Eventually(func() []icarus.CommitLogBackup {
+				return mockIcarusClient.commitLogBackups
+			}, mediumTimeout, mediumRetry).ShouldNot(BeEmpty())
+			Expect(mockIcarusClient.commitLogBackups[0].StorageLocation).To(Equal("s3://bucket/" + cc.Name + "/dc1/" + firstPod))
+			Expect(mockIcarusClient.commitLogBackups[0].CommitLogArchiveOverride).To(Equal(v1alpha1.CommitLogArchiveDir))
+
+			cm := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ConfigMap(cc.Name), Namespace: cc.Namespace}, cm)).To(Succeed())
+			Expect(cm.Data["commitlog_archiving.properties"]).To(ContainSubstring("archive_command="))
+			Expect(cm.Data["commitlog_archiving.properties"]).ToNot(ContainSubstring("restore_point_in_time="))
+
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			Eventually(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+			Expect(cr.Status.CassandraBackup).To(Equal(cb.Name))
+			Expect(mockIcarusClient.restores[0].SnapshotTag).To(Equal(cb.Name))
+
+			mockIcarusClient.restores[0].Progress = 1
+			mockIcarusClient.restores[0].State = icarus.StateCompleted
+
+			By("downloading the commit logs on each node")
+			Eventually(func() []icarus.CommitLogRestore {
+				return mockIcarusClient.commitLogRestores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(6))
+			Expect(mockIcarusClient.commitLogRestores[0].StorageLocation).To(Equal("s3://bucket/" + cc.Name + "/dc1/" + firstPod))
+			Expect(mockIcarusClient.commitLogRestores[0].CommitlogDownloadDir).To(Equal(v1alpha1.CommitLogRestoreDir))
+			Expect(mockIcarusClient.commitLogRestores[0].TimestampEnd).To(Equal(cr.Spec.RestorePointInTime.UnixMilli()))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+			Expect(cr.Status.State).To(Equal(icarus.StateRunning))
+			Expect(cr.Status.CommitLogReplay).To(Equal(v1alpha1.CommitLogReplayDownloading))
+
+			for i := range mockIcarusClient.commitLogRestores {
+				mockIcarusClient.commitLogRestores[i].State = icarus.StateCompleted
+			}
+
+			By("restarting the pods with the restore point in time")
+			pointInTime := cr.Spec.RestorePointInTime.UTC().Format(time.RFC3339)
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: cc.Name}, cc)).To(Succeed())
+				return cc.Annotations[v1alpha1.CommitLogRestorePointInTimeAnnotation]
+			}, mediumTimeout, mediumRetry).Should(Equal(pointInTime))
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ConfigMap(cc.Name), Namespace: cc.Namespace}, cm)).To(Succeed())
+				return cm.Data["commitlog_archiving.properties"]
+			}, mediumTimeout, mediumRetry).Should(ContainSubstring("restore_point_in_time=" + cr.Spec.RestorePointInTime.UTC().Format("2006:01:02 15:04:05")))
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.CommitLogReplay
+			}, mediumTimeout, mediumRetry).Should(Equal(v1alpha1.CommitLogReplayReplaying))
+			Expect(cr.Status.State).To(Equal(icarus.StateRunning))
+
+			// no statefulset controller in envtest, so the restart is simulated
+			Expect(k8sClient.DeleteAllOf(ctx, &v1.Pod{}, client.InNamespace(cc.Namespace), client.GracePeriodSeconds(0),
+				client.MatchingLabels(labels.ComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra)))).To(Succeed())
+			Eventually(func() []v1.Pod {
+				pods := &v1.PodList{}
+				Expect(k8sClient.List(ctx, pods, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.ComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra)))).To(Succeed())
+				return pods.Items
+			}, mediumTimeout, mediumRetry).Should(BeEmpty())
+			createCassandraPods(cc)
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cr.Status.CommitLogReplay).To(Equal(v1alpha1.CommitLogReplayCompleted))
+			Expect(cr.Status.CompletionTime).ToNot(BeNil())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: cc.Name}, cc)).To(Succeed())
+			Expect(cc.Annotations).ToNot(HaveKey(v1alpha1.CommitLogRestorePointInTimeAnnotation))
+		})
+	})
+
+	Context("with pre-flight checks and fence", func() {
+		It("should wait for the keyspaces and fence the cluster while the restore is running", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cr.Spec.Entities = "ks1"
+			cr.Spec.Fence = true
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				condition := meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.ConditionTypePreflightChecksPassed)
+				if condition == nil || condition.Status != metav1.ConditionFalse {
+					return ""
+				}
+				return condition.Message
+			}, mediumTimeout, mediumRetry).Should(ContainSubstring("keyspace ks1 not found"))
+			Consistently(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, shortTimeout, shortRetry).Should(BeEmpty())
+
+			mockCQLClient.keyspaces = append(mockCQLClient.keyspaces, cql.Keyspace{Name: "ks1"})
+			Eventually(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, v1alpha1.ConditionTypePreflightChecksPassed)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: cc.Name}, cc)).To(Succeed())
+			Expect(cc.Annotations).To(HaveKeyWithValue(v1alpha1.RestoreFenceAnnotation, cr.Name))
+
+			mockIcarusClient.restores[0].Progress = 1
+			mockIcarusClient.restores[0].State = icarus.StateCompleted
+
+			Eventually(func() map[string]string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: cc.Name}, cc)).To(Succeed())
+				return cc.Annotations
+			}, longTimeout, mediumRetry).ShouldNot(HaveKey(v1alpha1.RestoreFenceAnnotation))
+		})
+	})
+
+	Context("when cancelled", func() {
+		It("should cancel the icarus operations and lift the fence", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cr.Spec.Fence = true
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateRunning))
+			Expect(cr.Finalizers).To(ContainElement(cassandrarestore.CancelFinalizer))
+
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				cr.Spec.Cancel = true
+				return k8sClient.Update(ctx, cr)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCancelled))
+			Expect(mockIcarusClient.restores[0].State).To(Equal(icarus.StateCancelled))
+			Eventually(func() map[string]string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: cc.Name}, cc)).To(Succeed())
+				return cc.Annotations
+			}, longTimeout, mediumRetry).ShouldNot(HaveKey(v1alpha1.RestoreFenceAnnotation))
+
+			Expect(k8sClient.Delete(ctx, cr)).To(Succeed())
+			expectResourceIsDeleted(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &v1alpha1.CassandraRestore{})
+		})
+	})
+
+	Context("with Load mode", func() {
+		It("should load the SSTables of each backed up node of the mapped DCs with a job", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.DCs = []v1alpha1.DC{{Name: "staging", Replicas: proto.Int32(3)}}
+			cb := cbTpl.DeepCopy()
+			cb.Spec.CassandraCluster = "prod"
+			cr := crTpl.DeepCopy()
+			cr.Spec.Mode = v1alpha1.RestoreModeLoad
+			cr.Spec.DCMapping = map[string]string{"dc1": "staging"}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+
+			// the backup was taken from another cluster
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				cb.Status = v1alpha1.CassandraBackupStatus{
+					State: icarus.StateCompleted,
+					Nodes: []v1alpha1.NodeProgress{
+						{Name: "prod-cassandra-dc1-rack1-0", State: icarus.StateCompleted},
+						{Name: "prod-cassandra-dc1-rack1-1", State: icarus.StateCompleted},
+						{Name: "prod-cassandra-dc2-rack1-0", State: icarus.StateCompleted},
+					},
+				}
+				return k8sClient.Status().Update(ctx, cb)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			jobs := &batchv1.JobList{}
+			Eventually(func() []batchv1.Job {
+				Expect(k8sClient.List(ctx, jobs, client.InNamespace(cr.Namespace), client.MatchingLabels{v1alpha1.CassandraRestoreLabel: cr.Name})).To(Succeed())
+				return jobs.Items
+			}, mediumTimeout, mediumRetry).Should(HaveLen(2))
+			Expect(mockIcarusClient.restores).To(BeEmpty())
+
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.RestoreLoadJob(cr.Name, 0), Namespace: cr.Namespace}, job)).To(Succeed())
+			Expect(job.Spec.Template.Spec.InitContainers[0].Command).To(ContainElements(
+				"--storage-location=s3://bucket/prod/dc1/prod-cassandra-dc1-rack1-0",
+				"--snapshot-tag="+cb.Name,
+				"--k8s-secret-name="+storageSecretTpl.Name,
+			))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{
+				Name:  "LOAD_HOSTS",
+				Value: names.DCService(cc.Name, "staging") + "." + cc.Namespace + ".svc.cluster.local",
+			}))
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateRunning))
+
+			// no job controller in envtest, so the jobs are completed by the test
+			for i := range jobs.Items {
+				now := metav1.Now()
+				jobs.Items[i].Status.StartTime = &now
+				jobs.Items[i].Status.CompletionTime = &now
+				jobs.Items[i].Status.Succeeded = 1
+				jobs.Items[i].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+				Expect(k8sClient.Status().Update(ctx, &jobs.Items[i])).To(Succeed())
+			}
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cr.Status.Progress).To(Equal(100))
+			Expect(cr.Status.Nodes).To(HaveLen(2))
+			Expect(cr.Status.CompletionTime).ToNot(BeNil())
+		})
+
+		It("should fail the restore if the DC mapping is invalid", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cr.Spec.Mode = v1alpha1.RestoreModeLoad
+			cr.Spec.DCMapping = map[string]string{"dc1": "unknown"}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				cb.Status = v1alpha1.CassandraBackupStatus{State: icarus.StateCompleted}
+				return k8sClient.Status().Update(ctx, cb)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateFailed))
+			Expect(cr.Status.Errors).To(HaveLen(1))
+			Expect(cr.Status.Errors[0].Message).To(ContainSubstring("Invalid DC mapping"))
+			Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, v1alpha1.ConditionTypeFailed)).To(BeTrue())
+		})
+	})
+
+	Context("with encryption", func() {
+		It("should not restore an unencrypted backup", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cr.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "backup-key"}
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			Consistently(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, shortTimeout, shortRetry).Should(BeEmpty())
+		})
+	})
+
+	Context("when the storage rejects the credentials", func() {
+		It("should not start the restore and report the error", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cr.Spec.StorageLocation = "s3://other-bucket"
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+
+			mockStorageClient.err = &storage.Error{StorageProvider: v1alpha1.StorageProviderS3, StatusCode: 404, Code: "NoSuchBucket"}
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return meta.IsStatusConditionFalse(cr.Status.Conditions, v1alpha1.ConditionTypeStorageVerified)
+			}, mediumTimeout, mediumRetry).Should(BeTrue())
+			Expect(mockStorageClient.verifiedLocations).To(ContainElement("s3://other-bucket"))
+			Consistently(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, shortTimeout, shortRetry).Should(BeEmpty())
+		})
+	})
+
+	Context("with VolumeSnapshot mode", func() {
+		It("should provision the volumes of the cluster from the snapshots before creating the statefulsets", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.DCs[0].Replicas = proto.Int32(2)
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Persistence: v1alpha1.Persistence{
+					Enabled: true,
+					DataVolumeClaimSpec: v1.PersistentVolumeClaimSpec{
+						AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			}
+			cb := cbTpl.DeepCopy()
+			cb.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot
+			cb.Spec.StorageLocation = ""
+			cb.Spec.SecretName = ""
+			cr := crTpl.DeepCopy()
+			cr.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+
+			dcName := names.DC(cc.Name, "dc1")
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				cb.Status.State = icarus.StateCompleted
+				cb.Status.VolumeSnapshots = []v1alpha1.BackupVolumeSnapshot{
+					{Name: cb.Name + "-data-" + dcName + "-0", Volume: "data", PVC: "data-" + dcName + "-0", Pod: dcName + "-0", DC: "dc1", ReadyToUse: true, RestoreSize: "20Gi"},
+					{Name: cb.Name + "-data-" + dcName + "-1", Volume: "data", PVC: "data-" + dcName + "-1", Pod: dcName + "-1", DC: "dc1", ReadyToUse: true},
+				}
+				return k8sClient.Status().Update(ctx, cb)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			createAdminSecret(cc)
+			Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+			markMocksAsReady(cc)
+
+			for i, snapshot := range cb.Status.VolumeSnapshots {
+				pvc := &v1.PersistentVolumeClaim{}
+				waitForResourceToBeCreated(types.NamespacedName{Name: snapshot.PVC, Namespace: cc.Namespace}, pvc)
+				DeferCleanup(func() {
+					Expect(deleteResource(types.NamespacedName{Name: snapshot.PVC, Namespace: cc.Namespace}, &v1.PersistentVolumeClaim{})).To(Succeed())
+				})
+				Expect(pvc.Spec.DataSource).ToNot(BeNil())
+				Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+				Expect(pvc.Spec.DataSource.Name).To(Equal(snapshot.Name))
+				Expect(pvc.Annotations).To(HaveKeyWithValue(v1alpha1.VolumeSnapshotRestoreAnnotation, cr.Name))
+				if i == 0 {
+					Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+				}
+			}
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateRunning))
+			Expect(cr.Status.Nodes).To(HaveLen(2))
+
+			waitForDCsToBeCreated(cc)
+			markAllDCsReady(cc)
+			createNodes(nodeIPs)
+			createCassandraPods(cc)
+			createReaperPods(cc)
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cr.Status.Progress).To(Equal(100))
+		})
+
+		It("should fail the restore and create the statefulsets if the backup doesn't match the cluster", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.DCs[0].Replicas = proto.Int32(3)
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Persistence: v1alpha1.Persistence{
+					Enabled: true,
+					DataVolumeClaimSpec: v1.PersistentVolumeClaimSpec{
+						AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			}
+			cb := cbTpl.DeepCopy()
+			cb.Spec.Mode = v1alpha1.BackupModeVolumeSnapshot
+			cb.Spec.StorageLocation = ""
+			cb.Spec.SecretName = ""
+			cr := crTpl.DeepCopy()
+			cr.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+
+			dcName := names.DC(cc.Name, "dc1")
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				cb.Status.State = icarus.StateCompleted
+				cb.Status.VolumeSnapshots = []v1alpha1.BackupVolumeSnapshot{
+					{Name: cb.Name + "-data-" + dcName + "-0", Volume: "data", PVC: "data-" + dcName + "-0", Pod: dcName + "-0", DC: "dc1", ReadyToUse: true},
+				}
+				return k8sClient.Status().Update(ctx, cb)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+			createAdminSecret(cc)
+			Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+			markMocksAsReady(cc)
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateFailed))
+			Expect(cr.Status.Errors).To(HaveLen(1))
+			Expect(cr.Status.Errors[0].Message).To(ContainSubstring("should have the same number of replicas"))
+
+			waitForDCsToBeCreated(cc)
+		})
+
+		It("should require a backup and not allow a storage location", func() {
+			cr := crTpl.DeepCopy()
+			cr.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+			cr.Spec.CassandraBackup = ""
+			Expect(k8sClient.Create(ctx, cr)).ToNot(Succeed())
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+			cr.Spec.StorageLocation = "s3://bucket"
+			Expect(k8sClient.Create(ctx, cr)).ToNot(Succeed())
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ibm/cassandra-operator/controllers/icarus"
@@ -13,6 +14,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 
 	"github.com/gocql/gocql"
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
@@ -34,6 +36,8 @@ type proberMock struct {
 
 type cqlMock struct {
 	keyspaces      []cql.Keyspace
+	tables         []cql.Table
+	schemaVersion  string
 	cassandraRoles []cql.Role
 	err            error
 }
@@ -50,9 +54,21 @@ type reaperMock struct {
 	err             error
 }
 
+type storageMock struct {
+	verifiedLocations []string
+	err               error
+	// directories by storage location, the storage can't be listed if not set
+	dirs map[string][]string
+}
+
 type icarusMock struct {
-	backups  []icarus.Backup
-	restores []icarus.Restore
+	backups             []icarus.Backup
+	restores            []icarus.Restore
+	removedBackups      []icarus.RemoveBackup
+	lists               []icarus.List
+	commitLogBackups    []icarus.CommitLogBackup
+	commitLogRestores   []icarus.CommitLogRestore
+	cancelledOperations []string
 	error
 }
 
@@ -85,6 +101,7 @@ func (i *icarusMock) Backup(ctx context.Context, req icarus.BackupRequest) (icar
 		DataDirs:               req.DataDirs,
 		Bandwidth:              req.Bandwidth,
 		GlobalRequest:          req.GlobalRequest,
+		Encryption:             req.Encryption,
 	}
 
 	i.backups = append(i.backups, backup)
@@ -127,6 +144,7 @@ func (i *icarusMock) Restore(ctx context.Context, req icarus.RestoreRequest) err
 		K8sNamespace:              req.K8sNamespace,
 		K8sSecretName:             req.K8sSecretName,
 		Rename:                    req.Rename,
+		Encryption:                req.Encryption,
 	}
 	i.restores = append(i.restores, restore)
 	return i.error
@@ -136,6 +154,128 @@ func (i *icarusMock) Restores(ctx context.Context) ([]icarus.Restore, error) {
 	return i.restores, i.error
 }
 
+func (s *storageMock) Verify(ctx context.Context, storageProvider dbv1alpha1.StorageProvider, storageLocation string) error {
+	s.verifiedLocations = append(s.verifiedLocations, storageLocation)
+	return s.err
+}
+
+func (s *storageMock) ListDirs(ctx context.Context, storageProvider dbv1alpha1.StorageProvider, storageLocation string) ([]string, error) {
+	if s.dirs == nil {
+		return nil, storage.ErrNotVerifiable
+	}
+
+	return s.dirs[storageLocation], s.err
+}
+
+var _ storage.Storage = &storageMock{}
+
+func (i *icarusMock) Cancel(ctx context.Context, operationID string) error {
+	for j := range i.backups {
+		if i.backups[j].ID == operationID {
+			i.backups[j].State = icarus.StateCancelled
+		}
+	}
+
+	for j := range i.restores {
+		if i.restores[j].Id == operationID {
+			i.restores[j].State = icarus.StateCancelled
+		}
+	}
+
+	i.cancelledOperations = append(i.cancelledOperations, operationID)
+	return i.error
+}
+
+func (i *icarusMock) RemoveBackup(ctx context.Context, req icarus.RemoveBackupRequest) (icarus.RemoveBackup, error) {
+	removal := icarus.RemoveBackup{
+		ID:                     "random_id",
+		CreationTime:           time.Now().Format(time.RFC3339),
+		State:                  icarus.StateRunning,
+		Errors:                 nil,
+		Progress:               0.0,
+		StartTime:              time.Now().Format(time.RFC3339),
+		Type:                   "remove-backup",
+		StorageLocation:        req.StorageLocation,
+		BackupName:             req.BackupName,
+		GlobalRequest:          req.GlobalRequest,
+		ResolveNodes:           req.ResolveNodes,
+		K8sNamespace:           req.K8sNamespace,
+		K8sSecretName:          req.K8sSecretName,
+		Insecure:               req.Insecure,
+		SkipBucketVerification: req.SkipBucketVerification,
+		Dry:                    req.Dry,
+	}
+	i.removedBackups = append(i.removedBackups, removal)
+	return removal, i.error
+}
+
+func (i *icarusMock) RemoveBackups(ctx context.Context) ([]icarus.RemoveBackup, error) {
+	return i.removedBackups, i.error
+}
+
+func (i *icarusMock) List(ctx context.Context, req icarus.ListRequest) (icarus.List, error) {
+	list := icarus.List{
+		ID:                     "random_id",
+		CreationTime:           time.Now().Format(time.RFC3339),
+		State:                  icarus.StateRunning,
+		Errors:                 nil,
+		Progress:               0.0,
+		StartTime:              time.Now().Format(time.RFC3339),
+		Type:                   "list",
+		StorageLocation:        req.StorageLocation,
+		K8sNamespace:           req.K8sNamespace,
+		K8sSecretName:          req.K8sSecretName,
+		Insecure:               req.Insecure,
+		SkipBucketVerification: req.SkipBucketVerification,
+	}
+	i.lists = append(i.lists, list)
+	return list, i.error
+}
+
+func (i *icarusMock) Lists(ctx context.Context) ([]icarus.List, error) {
+	return i.lists, i.error
+}
+
+func (i *icarusMock) BackupCommitLogs(ctx context.Context, req icarus.CommitLogBackupRequest) error {
+	i.commitLogBackups = append(i.commitLogBackups, icarus.CommitLogBackup{
+		ID:                       "random_id",
+		CreationTime:             time.Now().Format(time.RFC3339),
+		State:                    icarus.StateRunning,
+		StartTime:                time.Now().Format(time.RFC3339),
+		Type:                     "commitlog-backup",
+		StorageLocation:          req.StorageLocation,
+		CommitLogArchiveOverride: req.CommitLogArchiveOverride,
+		K8sNamespace:             req.K8sNamespace,
+		K8sSecretName:            req.K8sSecretName,
+	})
+	return i.error
+}
+
+func (i *icarusMock) CommitLogBackups(ctx context.Context) ([]icarus.CommitLogBackup, error) {
+	return i.commitLogBackups, i.error
+}
+
+func (i *icarusMock) RestoreCommitLogs(ctx context.Context, req icarus.CommitLogRestoreRequest) error {
+	i.commitLogRestores = append(i.commitLogRestores, icarus.CommitLogRestore{
+		ID:                   "random_id",
+		CreationTime:         time.Now().Format(time.RFC3339),
+		State:                icarus.StateRunning,
+		StartTime:            time.Now().Format(time.RFC3339),
+		Type:                 "commitlog-restore",
+		StorageLocation:      req.StorageLocation,
+		CommitlogDownloadDir: req.CommitlogDownloadDir,
+		TimestampStart:       req.TimestampStart,
+		TimestampEnd:         req.TimestampEnd,
+		K8sNamespace:         req.K8sNamespace,
+		K8sSecretName:        req.K8sSecretName,
+	})
+	return i.error
+}
+
+func (i *icarusMock) CommitLogRestores(ctx context.Context) ([]icarus.CommitLogRestore, error) {
+	return i.commitLogRestores, i.error
+}
+
 func (r proberMock) Ready(ctx context.Context) (bool, error) {
 	return r.ready, r.err
 }
@@ -204,6 +344,14 @@ func (c *cqlMock) GetKeyspacesInfo() ([]cql.Keyspace, error) {
 	return c.keyspaces, c.err
 }
 
+func (c *cqlMock) GetTables() ([]cql.Table, error) {
+	return c.tables, c.err
+}
+
+func (c *cqlMock) GetSchemaVersion() (string, error) {
+	return c.schemaVersion, c.err
+}
+
 func (c *cqlMock) GetRoles() ([]cql.Role, error) {
 	return c.cassandraRoles, c.err
 }
@@ -376,7 +524,18 @@ type mockNode struct {
 }
 
 type nodectlMock struct {
-	nodesState map[string]mockNode
+	nodesState   map[string]mockNode
+	flushedNodes []string
+	flushLock    sync.Mutex
+	drainedNodes []string
+	// called when a node is drained
+	onDrain func(nodeIP string)
+	// snapshots cleared on each node, by node IP
+	clearedSnapshots map[string][]string
+	// runtime settings set on each node, by node IP and setting name
+	runtimeSettings map[string]map[string]float64
+	// levels of the loggers set on each node, by node IP and logger name
+	loggingLevels map[string]map[string]string
 }
 
 func (n *nodectlMock) Decommission(ctx context.Context, nodeIP string) error {
@@ -399,6 +558,108 @@ func (n *nodectlMock) OperationMode(ctx context.Context, nodeIP string) (nodectl
 	return n.nodesState[nodeIP].opMode, nil
 }
 
+func (n *nodectlMock) Flush(ctx context.Context, nodeIP string) error {
+	// the nodes are flushed concurrently
+	n.flushLock.Lock()
+	defer n.flushLock.Unlock()
+	n.flushedNodes = append(n.flushedNodes, nodeIP)
+	return nil
+}
+
+func (n *nodectlMock) ClearSnapshot(ctx context.Context, nodeIP, tag string) error {
+	if n.clearedSnapshots == nil {
+		n.clearedSnapshots = make(map[string][]string)
+	}
+	n.clearedSnapshots[nodeIP] = append(n.clearedSnapshots[nodeIP], tag)
+	return nil
+}
+
+func (n *nodectlMock) Drain(ctx context.Context, nodeIP string) error {
+	n.drainedNodes = append(n.drainedNodes, nodeIP)
+	if n.onDrain != nil {
+		n.onDrain(nodeIP)
+	}
+	return nil
+}
+
+func (n *nodectlMock) DisableBinary(ctx context.Context, nodeIP string) error {
+	return nil
+}
+
+func (n *nodectlMock) DisableGossip(ctx context.Context, nodeIP string) error {
+	return nil
+}
+
+func (n *nodectlMock) CompactionThroughput(ctx context.Context, nodeIP string) (int, error) {
+	return int(n.runtimeSettings[nodeIP]["compactionThroughput"]), nil
+}
+
+func (n *nodectlMock) SetCompactionThroughput(ctx context.Context, nodeIP string, mbPerSec int) error {
+	n.setRuntimeSetting(nodeIP, "compactionThroughput", float64(mbPerSec))
+	return nil
+}
+
+func (n *nodectlMock) StreamThroughput(ctx context.Context, nodeIP string) (int, error) {
+	return int(n.runtimeSettings[nodeIP]["streamThroughput"]), nil
+}
+
+func (n *nodectlMock) SetStreamThroughput(ctx context.Context, nodeIP string, megabitsPerSec int) error {
+	n.setRuntimeSetting(nodeIP, "streamThroughput", float64(megabitsPerSec))
+	return nil
+}
+
+func (n *nodectlMock) ConcurrentCompactors(ctx context.Context, nodeIP string) (int, error) {
+	return int(n.runtimeSettings[nodeIP]["concurrentCompactors"]), nil
+}
+
+func (n *nodectlMock) SetConcurrentCompactors(ctx context.Context, nodeIP string, compactors int) error {
+	n.setRuntimeSetting(nodeIP, "concurrentCompactors", float64(compactors))
+	return nil
+}
+
+func (n *nodectlMock) SetHintedHandoffThrottle(ctx context.Context, nodeIP string, kbPerSec int) error {
+	n.setRuntimeSetting(nodeIP, "hintedHandoffThrottle", float64(kbPerSec))
+	return nil
+}
+
+func (n *nodectlMock) TraceProbability(ctx context.Context, nodeIP string) (float64, error) {
+	return n.runtimeSettings[nodeIP]["traceProbability"], nil
+}
+
+func (n *nodectlMock) SetTraceProbability(ctx context.Context, nodeIP string, probability float64) error {
+	n.setRuntimeSetting(nodeIP, "traceProbability", probability)
+	return nil
+}
+
+func (n *nodectlMock) LoggingLevels(ctx context.Context, nodeIP string) (map[string]string, error) {
+	levels := make(map[string]string)
+	for logger, level := range n.loggingLevels[nodeIP] {
+		levels[logger] = level
+	}
+	return levels, nil
+}
+
+func (n *nodectlMock) SetLoggingLevel(ctx context.Context, nodeIP, logger, level string) error {
+	if n.loggingLevels == nil {
+		n.loggingLevels = make(map[string]map[string]string)
+	}
+	if n.loggingLevels[nodeIP] == nil {
+		n.loggingLevels[nodeIP] = make(map[string]string)
+	}
+	n.loggingLevels[nodeIP][logger] = level
+	return nil
+}
+
+func (n *nodectlMock) setRuntimeSetting(nodeIP, setting string, value float64) {
+	if n.runtimeSettings == nil {
+		n.runtimeSettings = make(map[string]map[string]float64)
+	}
+	if n.runtimeSettings[nodeIP] == nil {
+		n.runtimeSettings[nodeIP] = make(map[string]float64)
+	}
+	n.runtimeSettings[nodeIP][setting] = value
+}
+
 func markMocksAsReady(cc *dbv1alpha1.CassandraCluster) {
 	for i, externalRegion := range cc.Spec.ExternalRegions.Managed {
 		mockProberClient.readyClusters[externalRegion.Domain] = true
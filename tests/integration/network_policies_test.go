@@ -238,6 +238,32 @@ var _ = Describe("network policies", func() {
 							},
 						},
 					},
+					{
+						Ports: []nwv1.NetworkPolicyPort{
+							{
+								Port:     &intstr.IntOrString{IntVal: dbv1alpha1.CqlPort},
+								Protocol: &protocolTCP,
+							},
+							{
+								Port:     &intstr.IntOrString{IntVal: dbv1alpha1.TlsPort},
+								Protocol: &protocolTCP,
+							},
+							{
+								Port:     &intstr.IntOrString{IntVal: dbv1alpha1.IntraPort},
+								Protocol: &protocolTCP,
+							},
+						},
+						From: []nwv1.NetworkPolicyPeer{
+							{
+								PodSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{dbv1alpha1.CassandraClusterComponent: dbv1alpha1.CassandraClusterComponentRestore},
+								},
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{v1.LabelMetadataName: cc.Namespace},
+								},
+							},
+						},
+					},
 				},
 				PolicyTypes: []nwv1.PolicyType{"Ingress"},
 			}
@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("plan", func() {
+	It("should show the changes without applying them", func() {
+		cc := &v1alpha1.CassandraCluster{
+			ObjectMeta: cassandraObjectMeta,
+			Spec: v1alpha1.CassandraClusterSpec{
+				DCs: []v1alpha1.DC{
+					{
+						Name:     "dc1",
+						Replicas: proto.Int32(3),
+					},
+				},
+				AdminRoleSecretName: "admin-role",
+				ImagePullSecretName: "pull-secret-name",
+			},
+		}
+		createReadyCluster(cc)
+
+		sts := &appsv1.StatefulSet{}
+		stsName := types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, stsName, sts)
+		}, mediumTimeout, mediumRetry).Should(Succeed())
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+		cc.Annotations = map[string]string{v1alpha1.PlanAnnotation: "true"}
+		cc.Spec.Cassandra = &v1alpha1.Cassandra{LogLevel: "debug"}
+		Expect(k8sClient.Update(ctx, cc)).To(Succeed())
+
+		planCM := &v1.ConfigMap{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: names.PlanConfigMap(cc.Name), Namespace: cc.Namespace}, planCM)
+		}, mediumTimeout, mediumRetry).Should(Succeed())
+		Expect(planCM.Data["summary"]).To(ContainSubstring("update StatefulSet " + stsName.Name + " (restarts pods)"))
+		Expect(planCM.Data["update.statefulset."+stsName.Name]).To(ContainSubstring("debug"))
+
+		Expect(k8sClient.Get(ctx, stsName, sts)).To(Succeed())
+		Expect(sts.Spec.Template.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{Name: "LOG_LEVEL", Value: "info"}))
+
+		By("removing the plan annotation")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+		delete(cc.Annotations, v1alpha1.PlanAnnotation)
+		Expect(k8sClient.Update(ctx, cc)).To(Succeed())
+
+		Eventually(func() []v1.EnvVar {
+			Expect(k8sClient.Get(ctx, stsName, sts)).To(Succeed())
+			return sts.Spec.Template.Spec.Containers[0].Env
+		}, mediumTimeout, mediumRetry).Should(ContainElement(v1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}))
+
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: names.PlanConfigMap(cc.Name), Namespace: cc.Namespace}, planCM)
+		}, mediumTimeout, mediumRetry).ShouldNot(Succeed())
+	})
+})
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("pod disruption budgets", func() {
+	It("should be created per DC and removed when disabled", func() {
+		cc := &v1alpha1.CassandraCluster{
+			ObjectMeta: cassandraObjectMeta,
+			Spec: v1alpha1.CassandraClusterSpec{
+				DCs: []v1alpha1.DC{
+					{
+						Name:     "dc1",
+						Replicas: proto.Int32(3),
+					},
+				},
+				AdminRoleSecretName: "admin-role",
+				ImagePullSecretName: "pull-secret-name",
+			},
+		}
+		createReadyCluster(cc)
+
+		pdb := &policyv1.PodDisruptionBudget{}
+		pdbName := types.NamespacedName{Name: names.PodDisruptionBudget(cc.Name, "dc1"), Namespace: cc.Namespace}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, pdbName, pdb)
+		}, mediumTimeout, mediumRetry).Should(Succeed())
+		Expect(*pdb.Spec.MaxUnavailable).To(Equal(intstr.FromInt(1)))
+		Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue(v1alpha1.CassandraClusterDC, "dc1"))
+		Expect(pdb.OwnerReferences).To(HaveLen(1))
+
+		By("changing maxUnavailable")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+		maxUnavailable := intstr.FromString("50%")
+		cc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+		Expect(k8sClient.Update(ctx, cc)).To(Succeed())
+
+		Eventually(func() intstr.IntOrString {
+			Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+			return *pdb.Spec.MaxUnavailable
+		}, mediumTimeout, mediumRetry).Should(Equal(maxUnavailable))
+
+		By("disabling the pod disruption budgets")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+		cc.Spec.PodDisruptionBudget.Enabled = proto.Bool(false)
+		Expect(k8sClient.Update(ctx, cc)).To(Succeed())
+
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, pdbName, pdb))
+		}, mediumTimeout, mediumRetry).Should(BeTrue())
+	})
+})
@@ -60,12 +60,34 @@ var _ = Describe("prober deployment", func() {
 				{Name: "JMX_PORT", Value: "7199"},
 				{Name: "ADMIN_SECRET_NAME", Value: "test-cassandra-cluster-auth-active-admin"},
 				{Name: "BASE_ADMIN_SECRET_NAME", Value: "admin-role"},
+				{Name: "STATE_CONFIGMAP_NAME", Value: "test-cassandra-cluster-cassandra-prober-state"},
 			}))
 			Expect(proberContainer.ImagePullPolicy).To(Equal(v1.PullIfNotPresent), "default values")
 			jolokiaContainer, found := getContainerByName(deployment.Spec.Template.Spec, "jolokia")
 			Expect(found).To(BeTrue())
 			Expect(jolokiaContainer.Image).To(Equal(operatorConfig.DefaultJolokiaImage), "default values")
 			Expect(jolokiaContainer.ImagePullPolicy).To(Equal(v1.PullIfNotPresent), "default values")
+			Expect(deployment.Spec.Template.Spec.Affinity).To(BeNil())
+
+			stateCM := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ProberStateConfigMap(cc.Name), Namespace: cc.Namespace}, stateCM)).To(Succeed())
+			Expect(stateCM.Labels).To(BeEquivalentTo(proberLabels))
+		})
+	})
+
+	Context("when cassandracluster created with multiple prober replicas", func() {
+		It("should spread prober pods across nodes", func() {
+			multiReplicaCC := cc.DeepCopy()
+			multiReplicaCC.Spec.Prober.Replicas = 3
+			createReadyCluster(multiReplicaCC)
+			deployment := &appsv1.Deployment{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: names.ProberDeployment(cc.Name), Namespace: cc.Namespace}, deployment)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Expect(deployment.Spec.Replicas).To(Equal(proto.Int32(3)))
+			Expect(deployment.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			Expect(deployment.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey).To(Equal(v1.LabelHostname))
 		})
 	})
 })
@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("restart policy", func() {
+	Context("Manual", func() {
+		It("should hold back changes of the pod template until approved", func() {
+			cc := &v1alpha1.CassandraCluster{
+				ObjectMeta: cassandraObjectMeta,
+				Spec: v1alpha1.CassandraClusterSpec{
+					DCs: []v1alpha1.DC{
+						{
+							Name:     "dc1",
+							Replicas: proto.Int32(3),
+						},
+					},
+					AdminRoleSecretName: "admin-role",
+					ImagePullSecretName: "pull-secret-name",
+					RestartPolicy:       &v1alpha1.RestartPolicy{Type: v1alpha1.RestartPolicyManual},
+				},
+			}
+			createReadyCluster(cc)
+
+			sts := &appsv1.StatefulSet{}
+			stsName := types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, stsName, sts)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+			Expect(sts.Spec.Template.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{Name: "LOG_LEVEL", Value: "info"}))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{LogLevel: "debug"}
+			Expect(k8sClient.Update(ctx, cc)).To(Succeed())
+
+			Eventually(func() *v1alpha1.PendingRestart {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.PendingRestart
+			}, mediumTimeout, mediumRetry).ShouldNot(BeNil())
+			Expect(cc.Status.PendingRestart.DCs).To(HaveLen(1))
+			Expect(cc.Status.PendingRestart.DCs[0].Name).To(Equal("dc1"))
+			Expect(cc.Status.PendingRestart.DCs[0].Diff).To(ContainSubstring("debug"))
+
+			Expect(k8sClient.Get(ctx, stsName, sts)).To(Succeed())
+			Expect(sts.Spec.Template.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{Name: "LOG_LEVEL", Value: "info"}))
+
+			By("approving the restart")
+			approvedAt := cc.Status.PendingRestart.Since
+			cc.Spec.RestartPolicy.ApprovedAt = &approvedAt
+			Expect(k8sClient.Update(ctx, cc)).To(Succeed())
+
+			Eventually(func() []v1.EnvVar {
+				Expect(k8sClient.Get(ctx, stsName, sts)).To(Succeed())
+				return sts.Spec.Template.Spec.Containers[0].Env
+			}, mediumTimeout, mediumRetry).Should(ContainElement(v1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}))
+
+			Eventually(func() *v1alpha1.PendingRestart {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.PendingRestart
+			}, mediumTimeout, mediumRetry).Should(BeNil())
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+)
+
+var _ = Describe("rolling restart", func() {
+	It("should delete the pod of the drained node", func() {
+		cc := &v1alpha1.CassandraCluster{
+			ObjectMeta: cassandraObjectMeta,
+			Spec: v1alpha1.CassandraClusterSpec{
+				DCs: []v1alpha1.DC{
+					{
+						Name:     "dc1",
+						Replicas: proto.Int32(3),
+					},
+				},
+				AdminRoleSecretName: "admin-role",
+				ImagePullSecretName: "pull-secret-name",
+			},
+		}
+		createReadyCluster(cc)
+
+		podName := types.NamespacedName{Name: names.DC(cc.Name, "dc1") + "-0", Namespace: cc.Namespace}
+		pod := &v1.Pod{}
+		Expect(k8sClient.Get(ctx, podName, pod)).To(Succeed())
+
+		// the drained node is reported unready by its pod and statefulset
+		mockNodectlClient.onDrain = func(nodeIP string) {
+			defer GinkgoRecover()
+			Expect(nodeIP).To(Equal("172.0.0.0"))
+			Eventually(func() error {
+				actualPod := &v1.Pod{}
+				Expect(k8sClient.Get(ctx, podName, actualPod)).To(Succeed())
+				actualPod.Status.ContainerStatuses[0].Ready = false
+				return k8sClient.Status().Update(ctx, actualPod)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+			Eventually(func() error {
+				sts := &apps.StatefulSet{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}, sts)).To(Succeed())
+				sts.Status.ReadyReplicas = *sts.Spec.Replicas - 1
+				return k8sClient.Status().Update(ctx, sts)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+		}
+
+		// the restart time has a precision of seconds, so the pod has to be created in an earlier second to be restarted
+		time.Sleep(time.Until(pod.CreationTimestamp.Add(time.Second)))
+		Eventually(func() error {
+			actualCC := &v1alpha1.CassandraCluster{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, actualCC)).To(Succeed())
+			actualCC.Spec.RollingRestart = &v1alpha1.RollingRestart{
+				RequestedAt: metav1.Now(),
+				Pods:        []v1alpha1.PodName{v1alpha1.PodName(podName.Name)},
+			}
+			return k8sClient.Update(ctx, actualCC)
+		}, mediumTimeout, mediumRetry).Should(Succeed())
+
+		// pods are not removed by envtest, as there is no kubelet to terminate them
+		Eventually(func() bool {
+			actualPod := &v1.Pod{}
+			err := k8sClient.Get(ctx, podName, actualPod)
+			if kerrors.IsNotFound(err) {
+				return true
+			}
+			Expect(err).ToNot(HaveOccurred())
+			return actualPod.DeletionTimestamp != nil
+		}, mediumTimeout, mediumRetry).Should(BeTrue())
+		Expect(mockNodectlClient.drainedNodes).To(Equal([]string{"172.0.0.0"}))
+	})
+})
@@ -0,0 +1,50 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("runtime settings", func() {
+	It("should be applied to the running nodes", func() {
+		cc := &v1alpha1.CassandraCluster{
+			ObjectMeta: cassandraObjectMeta,
+			Spec: v1alpha1.CassandraClusterSpec{
+				DCs: []v1alpha1.DC{
+					{
+						Name:     "dc1",
+						Replicas: proto.Int32(3),
+					},
+				},
+				Cassandra: &v1alpha1.Cassandra{
+					RuntimeSettings: &v1alpha1.RuntimeSettings{
+						CompactionThroughputMBPerSec: proto.Int32(64),
+						TraceProbability:             "0.001",
+					},
+				},
+				AdminRoleSecretName: "admin-role",
+				ImagePullSecretName: "pull-secret-name",
+			},
+		}
+		createReadyCluster(cc)
+
+		Eventually(func() []v1alpha1.NodeRuntimeSettings {
+			actualCC := &v1alpha1.CassandraCluster{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, actualCC)).To(Succeed())
+			return actualCC.Status.RuntimeSettings
+		}, mediumTimeout, mediumRetry).Should(And(
+			HaveLen(3),
+			HaveEach(HaveField("State", v1alpha1.RuntimeSettingsApplied)),
+		))
+
+		for _, podIP := range []string{"172.0.0.0", "172.0.0.1", "172.0.0.2"} {
+			Expect(mockNodectlClient.runtimeSettings[podIP]).To(Equal(map[string]float64{
+				"compactionThroughput": 64,
+				"traceProbability":     0.001,
+			}))
+		}
+	})
+})
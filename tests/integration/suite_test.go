@@ -30,10 +30,13 @@ import (
 	"time"
 
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/jobs"
 
 	"github.com/ibm/cassandra-operator/controllers/cassandrarestore"
 
 	"github.com/ibm/cassandra-operator/controllers/cassandrabackup"
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackupcatalog"
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackupschedule"
 
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
 
@@ -42,6 +45,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/webhooks"
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	nwv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 
 	"github.com/go-logr/zapr"
@@ -55,11 +59,13 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/prober"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -91,6 +97,8 @@ var mockNodetoolClient = &nodetoolMock{}
 var mockCQLClient = &cqlMock{}
 var mockReaperClient = &reaperMock{}
 var mockIcarusClient = &icarusMock{}
+var mockStorageClient = &storageMock{}
+var clockShift time.Duration //shifts the time seen by the backup schedule controller to trigger scheduled runs
 var operatorConfig = config.Config{}
 var ctx = context.Background()
 var logr = zap.NewNop()
@@ -226,10 +234,40 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(mgr).ToNot(BeNil())
 
+	err = (&v1alpha1.CassandraBackupSchedule{}).SetupWebhookWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(mgr).ToNot(BeNil())
+
+	err = (&v1alpha1.CassandraBackupCatalog{}).SetupWebhookWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(mgr).ToNot(BeNil())
+
 	err = (&v1alpha1.CassandraRestore{}).SetupWebhookWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(mgr).ToNot(BeNil())
 
+	cqlClient := func(clusterConfig *gocql.ClusterConfig) (cql.CqlClient, error) {
+		authenticator := clusterConfig.Authenticator.(*gocql.PasswordAuthenticator)
+		roles := mockCQLClient.cassandraRoles
+		for _, role := range roles {
+			if role.Role == authenticator.Username {
+				if role.Password != authenticator.Password {
+					return nil, errors.New("password is incorrect")
+				}
+				if !role.Login {
+					return nil, errors.New("user in not allowed to log in")
+				}
+				return mockCQLClient, nil
+			}
+		}
+
+		return nil, errors.New("user not found")
+	}
+
+	// the restore controller checks the decommissions run by the cluster controller, as in main.go
+	reconcileChan := make(chan event.GenericEvent)
+	jobManager := jobs.NewJobManager(reconcileChan, logr.Sugar())
+
 	cassandraCtrl := &controllers.CassandraClusterReconciler{
 		Log:    logr.Sugar(),
 		Scheme: sch,
@@ -239,23 +277,7 @@ var _ = BeforeSuite(func() {
 		ProberClient: func(url *url.URL, user, password string) prober.ProberClient {
 			return mockProberClient
 		},
-		CqlClient: func(clusterConfig *gocql.ClusterConfig) (cql.CqlClient, error) {
-			authenticator := clusterConfig.Authenticator.(*gocql.PasswordAuthenticator)
-			roles := mockCQLClient.cassandraRoles
-			for _, role := range roles {
-				if role.Role == authenticator.Username {
-					if role.Password != authenticator.Password {
-						return nil, errors.New("password is incorrect")
-					}
-					if !role.Login {
-						return nil, errors.New("user in not allowed to log in")
-					}
-					return mockCQLClient, nil
-				}
-			}
-
-			return nil, errors.New("user not found")
-		},
+		CqlClient: cqlClient,
 		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
 			mockReaperClient.clusterName = clusterName
 			return mockReaperClient
@@ -263,6 +285,10 @@ var _ = BeforeSuite(func() {
 		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
 			return mockNodectlClient
 		},
+		IcarusClient: func(icarusURL string) icarus.Icarus {
+			return mockIcarusClient
+		},
+		Jobs: jobManager,
 	}
 
 	cassandraBackupCtrl := &cassandrabackup.CassandraBackupReconciler{
@@ -274,6 +300,37 @@ var _ = BeforeSuite(func() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return mockIcarusClient
 		},
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return mockNodectlClient
+		},
+		StorageClient: func(secret *v1.Secret, insecure bool) storage.Storage {
+			return mockStorageClient
+		},
+	}
+
+	cassandraBackupScheduleCtrl := &cassandrabackupschedule.CassandraBackupScheduleReconciler{
+		Log:    logr.Sugar(),
+		Scheme: sch,
+		Client: k8sClient,
+		Cfg:    operatorConfig,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		Now: func() time.Time {
+			return time.Now().Add(clockShift)
+		},
+	}
+
+	cassandraBackupCatalogCtrl := &cassandrabackupcatalog.CassandraBackupCatalogReconciler{
+		Log:    logr.Sugar(),
+		Scheme: sch,
+		Client: k8sClient,
+		Cfg:    operatorConfig,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
+			return mockIcarusClient
+		},
+		StorageClient: func(secret *v1.Secret, insecure bool) storage.Storage {
+			return mockStorageClient
+		},
 	}
 
 	cassandraRestoreCtrl := &cassandrarestore.CassandraRestoreReconciler{
@@ -285,12 +342,21 @@ var _ = BeforeSuite(func() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return mockIcarusClient
 		},
+		CqlClient: cqlClient,
+		Jobs:      jobManager,
+		StorageClient: func(secret *v1.Secret, insecure bool) storage.Storage {
+			return mockStorageClient
+		},
 	}
 
 	testReconciler := SetupTestReconcile(cassandraCtrl)
-	Expect(controllers.SetupCassandraReconciler(testReconciler, mgr, zap.NewNop().Sugar(), make(chan event.GenericEvent))).To(Succeed())
+	Expect(controllers.SetupCassandraReconciler(testReconciler, mgr, zap.NewNop().Sugar(), reconcileChan)).To(Succeed())
 	testBackupReconciler := SetupTestReconcile(cassandraBackupCtrl)
 	Expect(cassandrabackup.SetupCassandraBackupReconciler(testBackupReconciler, mgr)).To(Succeed())
+	testBackupScheduleReconciler := SetupTestReconcile(cassandraBackupScheduleCtrl)
+	Expect(cassandrabackupschedule.SetupCassandraBackupScheduleReconciler(testBackupScheduleReconciler, mgr)).To(Succeed())
+	testBackupCatalogReconciler := SetupTestReconcile(cassandraBackupCatalogCtrl)
+	Expect(cassandrabackupcatalog.SetupCassandraBackupCatalogReconciler(testBackupCatalogReconciler, mgr)).To(Succeed())
 	testRestoreReconciler := SetupTestReconcile(cassandraRestoreCtrl)
 	Expect(cassandrarestore.SetupCassandraRestoreReconciler(testRestoreReconciler, mgr)).To(Succeed())
 
@@ -318,11 +384,19 @@ var _ = AfterEach(func() {
 	backup := &v1alpha1.CassandraBackup{}
 	err := k8sClient.Get(ctx, types.NamespacedName{Name: cassandraBackupObjectMeta.Name, Namespace: cassandraObjectMeta.Namespace}, backup)
 	if err == nil {
-		Expect(deleteResource(types.NamespacedName{Name: backup.Spec.SecretName, Namespace: cassandraBackupObjectMeta.Namespace}, &v1.Secret{})).To(Succeed())
-		expectResourceIsDeleted(types.NamespacedName{Name: backup.Spec.SecretName, Namespace: cassandraBackupObjectMeta.Namespace}, &v1.Secret{})
+		if len(backup.Spec.SecretName) > 0 {
+			Expect(deleteResource(types.NamespacedName{Name: backup.Spec.SecretName, Namespace: cassandraBackupObjectMeta.Namespace}, &v1.Secret{})).To(Succeed())
+			expectResourceIsDeleted(types.NamespacedName{Name: backup.Spec.SecretName, Namespace: cassandraBackupObjectMeta.Namespace}, &v1.Secret{})
+		}
 		Expect(k8sClient.Delete(ctx, backup)).To(Succeed())
 	}
 
+	// no garbage collection in envtest, so backups created by schedules are removed explicitly
+	Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.CassandraBackupSchedule{}, client.InNamespace(cassandraObjectMeta.Namespace))).To(Succeed())
+	Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.CassandraBackupCatalog{}, client.InNamespace(cassandraObjectMeta.Namespace))).To(Succeed())
+	Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.CassandraBackup{}, client.InNamespace(cassandraObjectMeta.Namespace),
+		client.HasLabels{v1alpha1.CassandraBackupScheduleLabel})).To(Succeed())
+
 	restore := &v1alpha1.CassandraRestore{}
 	err = k8sClient.Get(ctx, types.NamespacedName{Name: cassandraRestoreObjectMeta.Name, Namespace: cassandraRestoreObjectMeta.Namespace}, restore)
 	if err == nil {
@@ -332,12 +406,17 @@ var _ = AfterEach(func() {
 		}
 		Expect(k8sClient.Delete(ctx, restore)).To(Succeed())
 	}
+	Expect(k8sClient.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace(cassandraObjectMeta.Namespace),
+		client.HasLabels{v1alpha1.CassandraRestoreLabel}, client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+	removeFinalizers()
 	mockProberClient = &proberMock{}
 	mockNodectlClient = &nodectlMock{}
 	mockNodetoolClient = &nodetoolMock{}
 	mockCQLClient = &cqlMock{}
 	mockReaperClient = &reaperMock{}
 	mockIcarusClient = &icarusMock{}
+	mockStorageClient = &storageMock{}
+	clockShift = 0
 	testFinished = false
 })
 
@@ -393,6 +472,17 @@ concurrent_materialized_view_writes: 32
 concurrent_reads: 32
 concurrent_writes: 32
 counter_cache_save_period: 7200
+`,
+			"logback.xml": `<configuration scan="true">
+  <appender name="STDOUT" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder>
+      <pattern>%-5level [%thread] %date{ISO8601} - %msg%n</pattern>
+    </encoder>
+  </appender>
+  <root level="${LOG_LEVEL}">
+    <appender-ref ref="STDOUT" />
+  </root>
+</configuration>
 `,
 		},
 	}
@@ -474,6 +564,7 @@ func CleanUpCreatedResources(ccName, ccNamespace string) {
 		resourcesToDelete = append(resourcesToDelete, resourceToDelete{name: names.ReaperDeployment(cc.Name, dc.Name), objType: &apps.Deployment{}})
 		resourcesToDelete = append(resourcesToDelete, resourceToDelete{name: names.ConfigMap(cc.Name), objType: &v1.ConfigMap{}})
 		resourcesToDelete = append(resourcesToDelete, resourceToDelete{name: names.ReaperDeployment(cc.Name, dc.Name), objType: &v1.Pod{}})
+		resourcesToDelete = append(resourcesToDelete, resourceToDelete{name: names.PodDisruptionBudget(cc.Name, dc.Name), objType: &policyv1.PodDisruptionBudget{}})
 
 	}
 
@@ -523,6 +614,27 @@ func getInitContainerByName(pod v1.PodSpec, containerName string) (v1.Container,
 	return v1.Container{}, false
 }
 
+// removeFinalizers lets the backups and restores deleted by a test go away without the operator cancelling them
+func removeFinalizers() {
+	backups := &v1alpha1.CassandraBackupList{}
+	Expect(k8sClient.List(ctx, backups, client.InNamespace(cassandraObjectMeta.Namespace))).To(Succeed())
+	for i := range backups.Items {
+		if len(backups.Items[i].Finalizers) != 0 {
+			backups.Items[i].Finalizers = nil
+			Expect(k8sClient.Update(ctx, &backups.Items[i])).To(Succeed())
+		}
+	}
+
+	restores := &v1alpha1.CassandraRestoreList{}
+	Expect(k8sClient.List(ctx, restores, client.InNamespace(cassandraObjectMeta.Namespace))).To(Succeed())
+	for i := range restores.Items {
+		if len(restores.Items[i].Finalizers) != 0 {
+			restores.Items[i].Finalizers = nil
+			Expect(k8sClient.Update(ctx, &restores.Items[i])).To(Succeed())
+		}
+	}
+}
+
 func expectResourceIsDeleted(name types.NamespacedName, obj client.Object) {
 	Eventually(func() metav1.StatusReason {
 		err := k8sClient.Get(context.Background(), name, obj)
@@ -3,13 +3,16 @@ package integration
 import (
 	"github.com/gogo/protobuf/proto"
 	"strings"
+	"time"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -81,8 +84,11 @@ var _ = Describe("cassandracluster validation", func() {
 						{
 							DC: "dc1",
 							Pods: []v1alpha1.PodName{
-								"test-cluster-cassandra-dc1-0",
+								"test-cassandra-cluster-cassandra-dc1-0",
 							},
+							Reason:      "debugging",
+							RequestedBy: "jane",
+							Duration:    &metav1.Duration{Duration: time.Hour},
 						},
 					},
 					Reaper: &v1alpha1.Reaper{
@@ -577,6 +583,131 @@ var _ = Describe("cassandracluster validation", func() {
 			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra config override should be a string with valid YAML: error converting YAML to JSON: yaml: line 1: did not find expected key"))
 		})
 	})
+	Context("with unknown cassandra config overrides", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				ConfigOverrides: `concurent_reads: 40`,
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.configOverrides: `concurent_reads` is not a known cassandra.yaml setting"))
+		})
+	})
+	Context("with cassandra config overrides renamed in the version of the image", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Image:           "cassandra:4.1.3",
+				ConfigOverrides: `hinted_handoff_throttle_in_kb: 2048`,
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.configOverrides: `hinted_handoff_throttle_in_kb` was renamed to `hinted_handoff_throttle` in Cassandra 4.1"))
+		})
+	})
+	Context("with cassandra config overrides unknown in the version of the image", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Image:           "cassandra:3.11.13",
+				ConfigOverrides: `hinted_handoff_throttle: 2048KiB`,
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.configOverrides: `hinted_handoff_throttle` is not a known cassandra.yaml setting for Cassandra 3.11"))
+		})
+	})
+	Context("with a cassandra config override of a typed setting", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Image:           "cassandra:4.1.3",
+				Config:          &v1alpha1.CassandraConfig{MaxHintWindow: &metav1.Duration{Duration: time.Hour}},
+				ConfigOverrides: `max_hint_window: 2h`,
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.configOverrides: `max_hint_window` is already set in cassandra.config"))
+		})
+	})
+	Context("with runtime settings conflicting with cassandra.config", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Config: &v1alpha1.CassandraConfig{
+					CompactionThroughputMBPerSec: proto.Int32(32),
+					ConcurrentCompactors:         proto.Int32(4),
+				},
+				RuntimeSettings: &v1alpha1.RuntimeSettings{
+					CompactionThroughputMBPerSec: proto.Int32(64),
+					ConcurrentCompactors:         proto.Int32(4),
+				},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.runtimeSettings.compactionThroughputMBPerSec (64) conflicts with cassandra.config.compactionThroughputMBPerSec (32)"))
+		})
+	})
+	Context("with a heap size exceeding the memory limit", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+				JVMOptions: []string{"-Xms2G", "-Xmx6G"},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.jvmOptions: -Xmx6G exceeds the memory limit (4Gi) of the cassandra container"))
+		})
+	})
+	Context("with an invalid heap size", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				JVMOptions: []string{"-Xmn200MB"},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.jvmOptions: -Xmn200MB has an invalid size \"200MB\""))
+		})
+	})
+	Context("with an invalid logger name", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Logging: &v1alpha1.CassandraLogging{
+					Loggers: map[string]v1alpha1.LoggerLevel{"org.apache.cassandra..db": "DEBUG"},
+				},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.logging.loggers: \"org.apache.cassandra..db\" is not a valid logger name"))
+		})
+	})
+	Context("with the audit log enabled for Cassandra 3.11", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				Image:   "cassandra:3.11.13",
+				Logging: &v1alpha1.CassandraLogging{AuditLog: true},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.logging.auditLog requires Cassandra 4.0 or later"))
+		})
+	})
 	Context("with invalid seeds config", func() {
 		It("should fail the validation", func() {
 			cc := validCluster.DeepCopy()
@@ -604,4 +735,119 @@ var _ = Describe("cassandracluster validation", func() {
 			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("replication factor (4) is greater than number of replicas (3) for dc dc1"))
 		})
 	})
+	Context(".spec.icarus.backupVolume", func() {
+		It("should have exactly one volume source", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Icarus.BackupVolume = &v1alpha1.IcarusBackupVolume{}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("exactly one of icarus.backupVolume.persistentVolumeClaim and icarus.backupVolume.nfs must be set"))
+		})
+	})
+
+	Context(".spec.cassandra.commitLogArchiving", func() {
+		It("should require a secret for cloud storage providers", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{StorageLocation: "s3://bucket"},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo(`cassandra.commitLogArchiving.secretName must be set for the "s3" storage provider`))
+		})
+	})
+
+	Context(".spec.rollingRestart", func() {
+		It("should reference DCs and pods of the cluster", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.RollingRestart = &v1alpha1.RollingRestart{
+				RequestedAt: metav1.Now(),
+				Pods:        []v1alpha1.PodName{"other-cluster-cassandra-dc1-0"},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo(`rollingRestart.pods: pod "other-cluster-cassandra-dc1-0" is not a Cassandra pod of the cluster`))
+
+			cc.Spec.RollingRestart.Pods = nil
+			cc.Spec.RollingRestart.DCs = []string{"dc3"}
+			err = k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo(`rollingRestart.dcs: DC "dc3" is not defined in the cluster`))
+		})
+	})
+
+	Context(".spec.maintenance", func() {
+		It("should reference DCs and pods of the cluster", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Maintenance = []v1alpha1.Maintenance{
+				{
+					DC:   "dc1",
+					Pods: []v1alpha1.PodName{"test-cassandra-cluster-cassandra-dc1-3"},
+				},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo(`maintenance[0].pods: pod "test-cassandra-cluster-cassandra-dc1-3" doesn't exist in DC "dc1"`))
+
+			cc.Spec.Maintenance = []v1alpha1.Maintenance{{DC: "dc3"}}
+			err = k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo(`maintenance[0].dc: DC "dc3" is not defined in the cluster`))
+		})
+
+		It("can't set both until and duration", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Maintenance = []v1alpha1.Maintenance{
+				{
+					DC:       "dc1",
+					Until:    &metav1.Time{Time: time.Now().Add(time.Hour)},
+					Duration: &metav1.Duration{Duration: time.Hour},
+				},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("maintenance[0]: either `until` or `duration` should be set"))
+		})
+	})
+
+	Context(".spec.restartPolicy", func() {
+		It("should require maintenance windows for the MaintenanceWindow policy", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.RestartPolicy = &v1alpha1.RestartPolicy{Type: v1alpha1.RestartPolicyMaintenanceWindow}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("maintenanceWindows must be set for the MaintenanceWindow restart policy"))
+		})
+	})
+
+	Context(".spec.maintenanceWindows", func() {
+		It("should have a valid schedule and time zone", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", TimeZone: "Mars/Olympus_Mons", Duration: metav1.Duration{Duration: time.Hour}},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(ContainSubstring("maintenanceWindows[0]: invalid schedule"))
+		})
+	})
+
+	Context(".spec.podDisruptionBudget", func() {
+		It("should have a valid maxUnavailable", func() {
+			cc := validCluster.DeepCopy()
+			maxUnavailable := intstr.FromString("150%")
+			cc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("podDisruptionBudget.maxUnavailable should be a number or a percentage, e.g. 10%"))
+		})
+	})
 })